@@ -0,0 +1,86 @@
+// Command import loads time entries from a Toggl or Clockify CSV export, like the
+// POST /imports/tasks endpoint, and prints the import report as JSON.
+//
+//	import -file export.csv [-source toggl|clockify] [-timezone Europe/Moscow]
+//	       [-date-format MM/DD/YYYY|DD/MM/YYYY|YYYY-MM-DD|DD.MM.YYYY] [-dry-run]
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+
+	"github.com/Dor1ma/Time-Tracker/config"
+	"github.com/Dor1ma/Time-Tracker/internal/app"
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/notify"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/Dor1ma/Time-Tracker/internal/secrets"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	path := flag.String("file", "", "CSV export to import, - for standard input")
+	source := flag.String("source", "", "tracker the file comes from: toggl or clockify, detected by default")
+	timezone := flag.String("timezone", "", "IANA time zone of the times in the file, UTC by default")
+	dateFormat := flag.String("date-format", "", "format of the dates in the file, detected by default")
+	dryRun := flag.Bool("dry-run", false, "only report what would be imported")
+	flag.Parse()
+
+	log := logrus.New()
+	log.Out = os.Stderr
+	log.SetLevel(logrus.WarnLevel)
+
+	if *path == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var file io.Reader = os.Stdin
+	if *path != "-" {
+		opened, err := os.Open(*path)
+		if err != nil {
+			log.Fatalf("Failed to open file: %v", err)
+		}
+		defer opened.Close()
+		file = opened
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+
+	passportKeyring, err := secrets.NewKeyring(cfg.PassportKeys, cfg.PassportHashKeys)
+	if err != nil {
+		log.Fatalf("failed to load passport keys: %v", err)
+	}
+
+	db, _ := app.ConnectDatabase(cfg, log)
+
+	importService := services.NewImportServiceImpl(
+		repositories.NewTaskRepositoryImpl(db, notify.NewLogNotifier(log), log),
+		repositories.NewUserRepositoryImpl(db, passportKeyring, log),
+		repositories.NewProjectRepositoryImpl(db, log),
+		repositories.NewClientRepositoryImpl(db, log),
+		log)
+
+	result, err := importService.ImportTasks(context.Background(), dto.ImportTasksRequest{
+		Source:     *source,
+		DryRun:     *dryRun,
+		Timezone:   *timezone,
+		DateFormat: *dateFormat,
+	}, file)
+	if err != nil {
+		log.Fatalf("Failed to import tasks: %v", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		log.Fatalf("Failed to write report: %v", err)
+	}
+}
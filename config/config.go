@@ -1,9 +1,12 @@
 package config
 
 import (
+	"fmt"
 	"github.com/joho/godotenv"
 	"log"
 	"os"
+	"strconv"
+	"time"
 )
 
 type Config struct {
@@ -13,6 +16,65 @@ type Config struct {
 	DbName         string
 	DbPass         string
 	ExternalAPIURL string
+
+	// RunningTaskPolicy decides what starting a timer does while another one is running:
+	// reject it, or switch by stopping the running one.
+	RunningTaskPolicy string
+
+	// SweepInterval is how often timers left running are looked for, zero disables the sweeper.
+	// Timers are stopped after MaxRunningDuration, or at the end of the workday of their user
+	// once WorkdayEndGrace has passed since then.
+	SweepInterval      time.Duration
+	MaxRunningDuration time.Duration
+	WorkdayEndGrace    time.Duration
+
+	// RecurrenceInterval is how often the entries of recurrences are created, zero disables it.
+	// Planned entries are created PlanningHorizon ahead of their occurrence.
+	RecurrenceInterval time.Duration
+	PlanningHorizon    time.Duration
+
+	// FocusSessionInterval is how often pomodoro phases that are over are ended, zero disables it.
+	FocusSessionInterval time.Duration
+
+	// WebhookPollInterval is how often webhook deliveries due for a retry are sent, zero
+	// disables webhooks. A delivery is given up after WebhookMaxAttempts, waiting
+	// WebhookRetryBase after the first failure and twice as long after every further one.
+	WebhookPollInterval time.Duration
+	WebhookTimeout      time.Duration
+	WebhookMaxAttempts  int
+	WebhookRetryBase    time.Duration
+
+	// StreamHeartbeat is how often idle task streams get a heartbeat, and how often stored
+	// task events are relayed should a notification of them be lost. Events are kept for
+	// StreamRetention, the longest a client can be away and still catch up.
+	StreamHeartbeat time.Duration
+	StreamRetention time.Duration
+
+	// OutboxRelayInterval is how often domain events are published from the outbox, zero
+	// disables the relay. Failed events are retried after OutboxRetryBase, doubling after every
+	// further failure, and published events are deleted after OutboxRetention.
+	OutboxRelayInterval time.Duration
+	OutboxRetryBase     time.Duration
+	OutboxRetention     time.Duration
+
+	// UserPurgeInterval is how often deleted users are purged, zero disables purging. Users are
+	// deleted for good, together with their tasks, UserRetention after they were deleted.
+	UserPurgeInterval time.Duration
+	UserRetention     time.Duration
+
+	// PassportKeys are the master keys passport numbers are sealed under, and PassportHashKeys
+	// the keys of the hashes they are looked up by, both as comma-separated id:key pairs of
+	// base64 256-bit keys. Both are required and must be kept secret. New passport numbers are
+	// sealed and hashed under the first key of each; the others are kept to open and look up the
+	// passport numbers sealed and hashed under them, which are rewrapped and rehashed by the
+	// first keys every PassportRotationInterval, zero disables it.
+	PassportKeys             string
+	PassportHashKeys         string
+	PassportRotationInterval time.Duration
+
+	// AdminToken is the bearer token that authenticates requests made by an admin, who alone
+	// may change roles. Empty leaves no request authenticated as an admin's.
+	AdminToken string
 }
 
 func LoadConfig() (*Config, error) {
@@ -27,7 +89,115 @@ func LoadConfig() (*Config, error) {
 		DbName:         os.Getenv("DB_NAME"),
 		DbPass:         os.Getenv("DB_PASSWORD"),
 		ExternalAPIURL: os.Getenv("EXTERNAL_API_URL"),
+
+		RunningTaskPolicy: getEnv("RUNNING_TASK_POLICY", "reject"),
+
+		PassportKeys:     os.Getenv("PASSPORT_KEYS"),
+		PassportHashKeys: os.Getenv("PASSPORT_HASH_KEYS"),
+
+		AdminToken: os.Getenv("ADMIN_TOKEN"),
+	}
+
+	var err error
+	if config.SweepInterval, err = getDuration("SWEEP_INTERVAL", "5m"); err != nil {
+		return nil, err
+	}
+	if config.MaxRunningDuration, err = getDuration("MAX_RUNNING_DURATION", "12h"); err != nil {
+		return nil, err
+	}
+	if config.WorkdayEndGrace, err = getDuration("WORKDAY_END_GRACE", "2h"); err != nil {
+		return nil, err
+	}
+
+	if config.RecurrenceInterval, err = getDuration("RECURRENCE_INTERVAL", "15m"); err != nil {
+		return nil, err
+	}
+	if config.PlanningHorizon, err = getDuration("PLANNING_HORIZON", "168h"); err != nil {
+		return nil, err
+	}
+
+	if config.FocusSessionInterval, err = getDuration("FOCUS_SESSION_INTERVAL", "15s"); err != nil {
+		return nil, err
+	}
+
+	if config.WebhookPollInterval, err = getDuration("WEBHOOK_POLL_INTERVAL", "10s"); err != nil {
+		return nil, err
+	}
+	if config.WebhookTimeout, err = getDuration("WEBHOOK_TIMEOUT", "10s"); err != nil {
+		return nil, err
+	}
+	if config.WebhookMaxAttempts, err = getInt("WEBHOOK_MAX_ATTEMPTS", "8"); err != nil {
+		return nil, err
+	}
+	if config.WebhookRetryBase, err = getDuration("WEBHOOK_RETRY_BASE", "30s"); err != nil {
+		return nil, err
+	}
+
+	if config.StreamHeartbeat, err = getDuration("STREAM_HEARTBEAT", "15s"); err != nil {
+		return nil, err
+	}
+	if config.StreamRetention, err = getDuration("STREAM_RETENTION", "24h"); err != nil {
+		return nil, err
+	}
+	if config.OutboxRelayInterval, err = getDuration("OUTBOX_RELAY_INTERVAL", "1s"); err != nil {
+		return nil, err
+	}
+	if config.OutboxRetryBase, err = getDuration("OUTBOX_RETRY_BASE", "5s"); err != nil {
+		return nil, err
+	}
+	if config.OutboxRetention, err = getDuration("OUTBOX_RETENTION", "168h"); err != nil {
+		return nil, err
+	}
+
+	if config.UserPurgeInterval, err = getDuration("USER_PURGE_INTERVAL", "1h"); err != nil {
+		return nil, err
+	}
+	if config.UserRetention, err = getDuration("USER_RETENTION", "2160h"); err != nil {
+		return nil, err
+	}
+
+	if config.PassportRotationInterval, err = getDuration("PASSPORT_ROTATION_INTERVAL", "1h"); err != nil {
+		return nil, err
+	}
+
+	if config.RunningTaskPolicy != "reject" && config.RunningTaskPolicy != "switch" {
+		return nil, fmt.Errorf("invalid RUNNING_TASK_POLICY %q: must be reject or switch", config.RunningTaskPolicy)
+	}
+	if config.StreamHeartbeat <= 0 {
+		return nil, fmt.Errorf("invalid STREAM_HEARTBEAT: must be positive")
+	}
+	if config.PassportKeys == "" {
+		return nil, fmt.Errorf("missing PASSPORT_KEYS: passport numbers cannot be sealed without master keys")
+	}
+	if config.PassportHashKeys == "" {
+		return nil, fmt.Errorf("missing PASSPORT_HASH_KEYS: passport numbers cannot be looked up without hash keys")
 	}
 
 	return config, nil
 }
+
+func getEnv(key string, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+
+	return fallback
+}
+
+func getDuration(key string, fallback string) (time.Duration, error) {
+	duration, err := time.ParseDuration(getEnv(key, fallback))
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", key, err)
+	}
+
+	return duration, nil
+}
+
+func getInt(key string, fallback string) (int, error) {
+	value, err := strconv.Atoi(getEnv(key, fallback))
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", key, err)
+	}
+
+	return value, nil
+}
@@ -1,21 +1,21 @@
 package app
 
 import (
-	"fmt"
-	"time"
-
+	"context"
 	"github.com/Dor1ma/Time-Tracker/config"
 	_ "github.com/Dor1ma/Time-Tracker/docs"
+	"github.com/Dor1ma/Time-Tracker/internal/eventbus"
 	"github.com/Dor1ma/Time-Tracker/internal/handlers"
+	"github.com/Dor1ma/Time-Tracker/internal/notify"
 	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/Dor1ma/Time-Tracker/internal/secrets"
 	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/Dor1ma/Time-Tracker/internal/stream"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
-	"gorm.io/driver/postgres"
-	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	"net/http"
 	"os"
 )
 
@@ -30,46 +30,98 @@ func Start() {
 		return
 	}
 
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-		cfg.DbHost,
-		cfg.DbUser,
-		cfg.DbPass,
-		cfg.DbName,
-		cfg.DbPort)
-
-	var db *gorm.DB
-	var retries = 5
-	var attempt int
-
-	for attempt = 1; attempt <= retries; attempt++ {
-		db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{
-			Logger: logger.Default.LogMode(logger.Silent),
-		})
-		if err != nil {
-			log.Errorf("Attempt %d failed to connect to database: %v", attempt, err)
-			time.Sleep(5 * time.Second)
-			continue
-		}
-		break
-	}
-
-	if attempt > retries {
-		log.Fatalf("Failed to connect to database after %d attempts", retries)
+	passportKeyring, err := secrets.NewKeyring(cfg.PassportKeys, cfg.PassportHashKeys)
+	if err != nil {
+		log.Fatalf("failed to load passport keys: %v", err)
 		return
 	}
 
+	db, dsn := ConnectDatabase(cfg, log)
+
 	RunMigration(dsn, log)
 
-	userRepository := repositories.NewUserRepositoryImpl(db, log)
-	taskRepository := repositories.NewTaskRepositoryImpl(db, log)
+	userRepository := repositories.NewUserRepositoryImpl(db, passportKeyring, log)
+	taskRepository := repositories.NewTaskRepositoryImpl(db, notify.NewLogNotifier(log), log)
+	clientRepository := repositories.NewClientRepositoryImpl(db, log)
+	projectRepository := repositories.NewProjectRepositoryImpl(db, log)
+	tagRepository := repositories.NewTagRepositoryImpl(db, log)
+	rateRepository := repositories.NewRateRepositoryImpl(db, log)
+	invoiceRepository := repositories.NewInvoiceRepositoryImpl(db, log)
+	reportRepository := repositories.NewReportRepositoryImpl(db, log)
+	teamRepository := repositories.NewTeamRepositoryImpl(db, log)
+	timesheetRepository := repositories.NewTimesheetRepositoryImpl(db, log)
+	roundingPolicyRepository := repositories.NewRoundingPolicyRepositoryImpl(db, log)
+	budgetRepository := repositories.NewBudgetRepositoryImpl(db, log)
+	taskTemplateRepository := repositories.NewTaskTemplateRepositoryImpl(db, log)
+	recurrenceRepository := repositories.NewRecurrenceRepositoryImpl(db, log)
+	webhookRepository := repositories.NewWebhookRepositoryImpl(db, log)
+	taskEventRepository := repositories.NewTaskEventRepositoryImpl(db, log)
+	outboxRepository := repositories.NewOutboxRepositoryImpl(db, log)
+	auditRepository := repositories.NewAuditRepositoryImpl(db, log)
 
+	webhookDispatcher := services.NewWebhookDispatcher(webhookRepository, &http.Client{Timeout: cfg.WebhookTimeout},
+		cfg.WebhookMaxAttempts, cfg.WebhookRetryBase, log)
+	taskStreamService := services.NewTaskStreamServiceImpl(taskEventRepository, stream.NewHub(), log)
+
+	eventBus := eventbus.NewBus()
+	if cfg.WebhookPollInterval > 0 {
+		eventBus.SubscribeAll(webhookDispatcher.HandleEvent)
+	}
+	outboxRelay := services.NewOutboxRelay(outboxRepository, eventBus, cfg.OutboxRetryBase, log)
+
+	auditService := services.NewAuditServiceImpl(auditRepository, log)
 	userService := services.NewUserServiceImpl(userRepository, cfg.ExternalAPIURL, log)
-	taskService := services.NewTaskServiceImpl(taskRepository, log)
+	taskService := services.NewTaskServiceImpl(taskRepository, userRepository, roundingPolicyRepository, projectRepository,
+		cfg.RunningTaskPolicy, log)
+	clientService := services.NewClientServiceImpl(clientRepository, log)
+	projectService := services.NewProjectServiceImpl(projectRepository, clientRepository, log)
+	tagService := services.NewTagServiceImpl(tagRepository, log)
+	rateService := services.NewRateServiceImpl(rateRepository, log)
+	invoiceService := services.NewInvoiceServiceImpl(invoiceRepository, rateRepository, clientRepository, roundingPolicyRepository, log)
+	reportService := services.NewReportServiceImpl(reportRepository, userRepository, teamRepository, roundingPolicyRepository, log)
+	teamService := services.NewTeamServiceImpl(teamRepository, log)
+	importService := services.NewImportServiceImpl(taskRepository, userRepository, projectRepository, clientRepository, log)
+	timesheetService := services.NewTimesheetServiceImpl(timesheetRepository, userRepository, log)
+	roundingPolicyService := services.NewRoundingPolicyServiceImpl(roundingPolicyRepository, log)
+	budgetService := services.NewBudgetServiceImpl(budgetRepository, projectRepository, log)
+	taskTemplateService := services.NewTaskTemplateServiceImpl(taskTemplateRepository, userRepository, taskService, log)
+	recurrenceService := services.NewRecurrenceServiceImpl(recurrenceRepository, taskTemplateRepository, userRepository,
+		cfg.PlanningHorizon, log)
+	focusSessionService := services.NewFocusSessionServiceImpl(taskRepository, userRepository, log)
+	calendarService := services.NewCalendarServiceImpl(taskRepository, userRepository, log)
+	webhookService := services.NewWebhookServiceImpl(webhookRepository, log)
+	personalDataService := services.NewPersonalDataServiceImpl(userRepository, taskRepository, auditRepository, log)
+	sweepService := services.NewSweepServiceImpl(taskRepository, userRepository, cfg.MaxRunningDuration, cfg.WorkdayEndGrace, log)
+
+	// Passport numbers stored in plain text are sealed before they can be read.
+	if _, err := userService.RotatePassportKeys(); err != nil {
+		log.Fatalf("failed to seal passport numbers: %v", err)
+		return
+	}
 
 	userHandler := handlers.NewUserHandler(userService, log)
 	taskHandler := handlers.NewTaskHandler(taskService, log)
+	clientHandler := handlers.NewClientHandler(clientService, log)
+	projectHandler := handlers.NewProjectHandler(projectService, log)
+	tagHandler := handlers.NewTagHandler(tagService, log)
+	rateHandler := handlers.NewRateHandler(rateService, log)
+	invoiceHandler := handlers.NewInvoiceHandler(invoiceService, log)
+	reportHandler := handlers.NewReportHandler(reportService, log)
+	teamHandler := handlers.NewTeamHandler(teamService, log)
+	importHandler := handlers.NewImportHandler(importService, log)
+	timesheetHandler := handlers.NewTimesheetHandler(timesheetService, log)
+	roundingPolicyHandler := handlers.NewRoundingPolicyHandler(roundingPolicyService, log)
+	budgetHandler := handlers.NewBudgetHandler(budgetService, log)
+	calendarHandler := handlers.NewCalendarHandler(calendarService, log)
+	taskTemplateHandler := handlers.NewTaskTemplateHandler(taskTemplateService, log)
+	recurrenceHandler := handlers.NewRecurrenceHandler(recurrenceService, log)
+	webhookHandler := handlers.NewWebhookHandler(webhookService, log)
+	taskStreamHandler := handlers.NewTaskStreamHandler(taskStreamService, cfg.StreamHeartbeat, log)
+	auditHandler := handlers.NewAuditHandler(auditService, log)
+	personalDataHandler := handlers.NewPersonalDataHandler(personalDataService, log)
 
 	router := gin.Default()
+	router.Use(handlers.RequestContext(cfg.AdminToken))
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	userRoutes := router.Group("/users")
@@ -78,14 +130,174 @@ func Start() {
 		userRoutes.GET("", userHandler.GetUsers)
 		userRoutes.PUT("/:id", userHandler.UpdateUser)
 		userRoutes.DELETE("/:id", userHandler.DeleteUser)
+		userRoutes.POST("/:id/restore", userHandler.RestoreUser)
+		userRoutes.GET("/:id/personal-data", personalDataHandler.GetPersonalData)
+		userRoutes.POST("/:id/erasure", personalDataHandler.EraseUser)
+		userRoutes.GET("/:id/erasure", personalDataHandler.GetErasure)
+		userRoutes.GET("/:id/summary", reportHandler.GetUserSummary)
+		userRoutes.GET("/:id/summary/export", reportHandler.ExportUserSummary)
+		userRoutes.GET("/:id/calendar.ics", calendarHandler.GetUserCalendar)
+		userRoutes.POST("/:id/calendar-token", calendarHandler.RegenerateCalendarToken)
 	}
 
 	taskRoutes := router.Group("/tasks")
 	{
+		taskRoutes.POST("", taskHandler.CreateTask)
+		taskRoutes.PATCH("/:id", taskHandler.UpdateTask)
+		taskRoutes.GET("/stream", taskStreamHandler.StreamTasks)
 		taskRoutes.POST("/start", taskHandler.StartTask)
 		taskRoutes.POST("/stop", taskHandler.StopTask)
+		taskRoutes.POST("/:id/pause", taskHandler.PauseTask)
+		taskRoutes.POST("/:id/resume", taskHandler.ResumeTask)
+		taskRoutes.POST("/:id/confirm", taskHandler.ConfirmTask)
 		taskRoutes.GET("/user/:user_id", taskHandler.GetUserTasks)
+		taskRoutes.GET("/user/:user_id/active", taskHandler.GetActiveTask)
+		taskRoutes.GET("/user/:user_id/export", taskHandler.ExportUserTasks)
+	}
+
+	templateRoutes := router.Group("/templates")
+	{
+		templateRoutes.POST("", taskTemplateHandler.CreateTemplate)
+		templateRoutes.GET("", taskTemplateHandler.GetTemplates)
+		templateRoutes.GET("/:id", taskTemplateHandler.GetTemplate)
+		templateRoutes.PUT("/:id", taskTemplateHandler.UpdateTemplate)
+		templateRoutes.DELETE("/:id", taskTemplateHandler.DeleteTemplate)
+		templateRoutes.POST("/:id/start", taskTemplateHandler.StartTemplate)
+	}
+
+	recurrenceRoutes := router.Group("/recurrences")
+	{
+		recurrenceRoutes.POST("", recurrenceHandler.CreateRecurrence)
+		recurrenceRoutes.GET("", recurrenceHandler.GetRecurrences)
+		recurrenceRoutes.DELETE("/:id", recurrenceHandler.DeleteRecurrence)
+		recurrenceRoutes.POST("/:id/skips", recurrenceHandler.SkipOccurrence)
+	}
+
+	clientRoutes := router.Group("/clients")
+	{
+		clientRoutes.POST("", clientHandler.CreateClient)
+		clientRoutes.GET("", clientHandler.GetClients)
+		clientRoutes.GET("/:id", clientHandler.GetClient)
+		clientRoutes.PUT("/:id", clientHandler.UpdateClient)
+		clientRoutes.DELETE("/:id", clientHandler.DeleteClient)
+	}
+
+	projectRoutes := router.Group("/projects")
+	{
+		projectRoutes.POST("", projectHandler.CreateProject)
+		projectRoutes.GET("", projectHandler.GetProjects)
+		projectRoutes.GET("/:id", projectHandler.GetProject)
+		projectRoutes.PUT("/:id", projectHandler.UpdateProject)
+		projectRoutes.DELETE("/:id", projectHandler.DeleteProject)
+	}
+
+	tagRoutes := router.Group("/tags")
+	{
+		tagRoutes.POST("", tagHandler.CreateTag)
+		tagRoutes.GET("", tagHandler.GetTags)
+		tagRoutes.PUT("/:id", tagHandler.RenameTag)
+		tagRoutes.DELETE("/:id", tagHandler.DeleteTag)
+		tagRoutes.POST("/:id/merge", tagHandler.MergeTags)
+	}
+
+	rateRoutes := router.Group("/rates")
+	{
+		rateRoutes.POST("", rateHandler.CreateRate)
+		rateRoutes.GET("", rateHandler.GetRates)
+		rateRoutes.DELETE("/:id", rateHandler.DeleteRate)
+	}
+
+	roundingPolicyRoutes := router.Group("/rounding-policies")
+	{
+		roundingPolicyRoutes.POST("", roundingPolicyHandler.SetRoundingPolicy)
+		roundingPolicyRoutes.GET("", roundingPolicyHandler.GetRoundingPolicies)
+		roundingPolicyRoutes.DELETE("/:id", roundingPolicyHandler.DeleteRoundingPolicy)
+	}
+
+	budgetRoutes := router.Group("/budgets")
+	{
+		budgetRoutes.POST("", budgetHandler.SetBudget)
+		budgetRoutes.GET("", budgetHandler.GetBudgets)
+		budgetRoutes.GET("/:id/progress", budgetHandler.GetBudgetProgress)
+		budgetRoutes.DELETE("/:id", budgetHandler.DeleteBudget)
+	}
+
+	invoiceRoutes := router.Group("/invoices")
+	{
+		invoiceRoutes.POST("", invoiceHandler.CreateInvoice)
+		invoiceRoutes.GET("", invoiceHandler.GetInvoices)
+		invoiceRoutes.GET("/:id", invoiceHandler.GetInvoice)
+	}
+
+	teamRoutes := router.Group("/teams")
+	{
+		teamRoutes.POST("", teamHandler.CreateTeam)
+		teamRoutes.GET("", teamHandler.GetTeams)
+		teamRoutes.GET("/:id", teamHandler.GetTeam)
+		teamRoutes.PUT("/:id", teamHandler.UpdateTeam)
+		teamRoutes.DELETE("/:id", teamHandler.DeleteTeam)
+	}
+
+	timesheetRoutes := router.Group("/timesheets")
+	{
+		timesheetRoutes.POST("", timesheetHandler.CreateTimesheet)
+		timesheetRoutes.GET("", timesheetHandler.GetTimesheets)
+		timesheetRoutes.GET("/:id", timesheetHandler.GetTimesheet)
+		timesheetRoutes.POST("/:id/submit", timesheetHandler.SubmitTimesheet)
+		timesheetRoutes.POST("/:id/approve", timesheetHandler.ApproveTimesheet)
+		timesheetRoutes.POST("/:id/reject", timesheetHandler.RejectTimesheet)
+		timesheetRoutes.POST("/:id/reopen", timesheetHandler.ReopenTimesheet)
+	}
+
+	reportRoutes := router.Group("/reports")
+	{
+		reportRoutes.GET("/pivot", reportHandler.GetPivotReport)
+	}
+
+	importRoutes := router.Group("/imports")
+	{
+		importRoutes.POST("/tasks", importHandler.ImportTasks)
+	}
+
+	webhookRoutes := router.Group("/webhooks")
+	{
+		webhookRoutes.POST("", webhookHandler.CreateWebhook)
+		webhookRoutes.GET("", webhookHandler.GetWebhooks)
+		webhookRoutes.GET("/:id", webhookHandler.GetWebhook)
+		webhookRoutes.PUT("/:id", webhookHandler.UpdateWebhook)
+		webhookRoutes.DELETE("/:id", webhookHandler.DeleteWebhook)
+		webhookRoutes.GET("/:id/deliveries", webhookHandler.GetWebhookDeliveries)
+		webhookRoutes.POST("/:id/deliveries/:delivery_id/replay", webhookHandler.ReplayWebhookDelivery)
+	}
+
+	auditRoutes := router.Group("/audit")
+	{
+		auditRoutes.GET("", auditHandler.GetAuditEntries)
+		auditRoutes.GET("/export", auditHandler.ExportAuditEntries)
+	}
+
+	if cfg.PassportRotationInterval > 0 {
+		go runPassportRotation(context.Background(), userService, cfg.PassportRotationInterval, log)
+	}
+	if cfg.UserPurgeInterval > 0 {
+		go runUserPurge(context.Background(), userService, cfg.UserPurgeInterval, cfg.UserRetention, log)
+	}
+	if cfg.SweepInterval > 0 {
+		go runSweeper(context.Background(), sweepService, cfg.SweepInterval, log)
+	}
+	if cfg.FocusSessionInterval > 0 {
+		go runFocusSessions(context.Background(), focusSessionService, cfg.FocusSessionInterval, log)
+	}
+	if cfg.RecurrenceInterval > 0 {
+		go runRecurrences(context.Background(), recurrenceService, cfg.RecurrenceInterval, log)
+	}
+	if cfg.WebhookPollInterval > 0 {
+		go webhookDispatcher.Run(context.Background(), cfg.WebhookPollInterval)
+	}
+	if cfg.OutboxRelayInterval > 0 {
+		go runOutboxRelay(context.Background(), outboxRelay, cfg.OutboxRelayInterval, cfg.OutboxRetention, log)
 	}
+	go runTaskStream(context.Background(), dsn, taskStreamService, cfg.StreamHeartbeat, cfg.StreamRetention, log)
 
 	err = router.Run(":8080")
 	if err != nil {
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/config"
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// ConnectDatabase opens the database described by cfg, retrying while it starts up,
+// and returns it together with its DSN. It exits the process if the database stays unreachable.
+func ConnectDatabase(cfg *config.Config, log *logrus.Logger) (*gorm.DB, string) {
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
+		cfg.DbHost,
+		cfg.DbUser,
+		cfg.DbPass,
+		cfg.DbName,
+		cfg.DbPort)
+
+	var db *gorm.DB
+	var err error
+	var retries = 5
+	var attempt int
+
+	for attempt = 1; attempt <= retries; attempt++ {
+		db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		if err != nil {
+			log.Errorf("Attempt %d failed to connect to database: %v", attempt, err)
+			time.Sleep(5 * time.Second)
+			continue
+		}
+		break
+	}
+
+	if attempt > retries {
+		log.Fatalf("Failed to connect to database after %d attempts", retries)
+	}
+
+	return db, dsn
+}
@@ -0,0 +1,27 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/sirupsen/logrus"
+)
+
+// runFocusSessions moves pomodoro sessions on to their next phase every interval until ctx is done.
+func runFocusSessions(ctx context.Context, focusSessionService services.FocusSessionService, interval time.Duration, log *logrus.Logger) {
+	log.Infof("Focus sessions have started with interval %s", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := focusSessionService.AdvanceFocusSessions(ctx, now); err != nil {
+				log.Errorf("Focus sessions failed to advance: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/sirupsen/logrus"
+)
+
+// outboxPruneInterval is how often published outbox events older than the retention are deleted.
+const outboxPruneInterval = time.Hour
+
+// runOutboxRelay publishes the events of the outbox every interval until ctx is done, and
+// deletes the events published longer than retention ago.
+func runOutboxRelay(ctx context.Context, relay *services.OutboxRelay, interval, retention time.Duration, log *logrus.Logger) {
+	log.Infof("Outbox relay has started with interval %s", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	pruneTicker := time.NewTicker(outboxPruneInterval)
+	defer pruneTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := relay.PublishPending(ctx, now); err != nil {
+				log.Errorf("Outbox relay failed to publish events: %v", err)
+			}
+		case now := <-pruneTicker.C:
+			if _, err := relay.PruneEvents(now.Add(-retention)); err != nil {
+				log.Errorf("Outbox relay failed to prune events: %v", err)
+			}
+		}
+	}
+}
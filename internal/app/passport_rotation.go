@@ -0,0 +1,28 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/sirupsen/logrus"
+)
+
+// runPassportRotation rewraps and rehashes, every interval until ctx is done, the passport
+// numbers sealed or hashed under a key other than the current one.
+func runPassportRotation(ctx context.Context, userService services.UserService, interval time.Duration, log *logrus.Logger) {
+	log.Infof("Passport key rotation has started with interval %s", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := userService.RotatePassportKeys(); err != nil {
+				log.Errorf("Passport key rotation failed to rewrap and rehash passport numbers: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/sirupsen/logrus"
+)
+
+// runRecurrences creates the entries of recurrences that have become due every interval
+// until ctx is done, starting right away so that no occurrence waits for the first tick.
+func runRecurrences(ctx context.Context, recurrenceService services.RecurrenceService, interval time.Duration, log *logrus.Logger) {
+	log.Infof("Recurrences have started with interval %s", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	now := time.Now()
+	for {
+		if _, err := recurrenceService.CreateDueEntries(ctx, now); err != nil {
+			log.Errorf("Recurrences failed to create due entries: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case now = <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/sirupsen/logrus"
+)
+
+// runSweeper stops forgotten timers every interval until ctx is done.
+func runSweeper(ctx context.Context, sweepService services.SweepService, interval time.Duration, log *logrus.Logger) {
+	log.Infof("Sweeper has started with interval %s", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := sweepService.StopForgottenTasks(ctx, now); err != nil {
+				log.Errorf("Sweeper failed to stop forgotten tasks: %v", err)
+			}
+		}
+	}
+}
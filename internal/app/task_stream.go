@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+// taskEventPruneInterval is how often task events older than the retention are deleted.
+const taskEventPruneInterval = time.Hour
+
+// runTaskStream relays the task events stored by every instance to the stream clients of
+// this one until ctx is done. It is woken up by notifications on models.TaskEventsChannel,
+// and also relays every interval in case notifications were lost while reconnecting.
+// Events older than retention are deleted.
+func runTaskStream(ctx context.Context, dsn string, taskStreamService services.TaskStreamService,
+	interval, retention time.Duration, log *logrus.Logger) {
+	listener := pq.NewListener(dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Errorf("Task stream listener failed: %v", err)
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(models.TaskEventsChannel); err != nil {
+		log.Errorf("Task stream failed to listen on %s: %v", models.TaskEventsChannel, err)
+	}
+
+	log.Infof("Task stream has started with interval %s", interval)
+	relay := func() {
+		if _, err := taskStreamService.RelayEvents(time.Now()); err != nil {
+			log.Errorf("Task stream failed to relay events: %v", err)
+		}
+	}
+	relay()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	pruneTicker := time.NewTicker(taskEventPruneInterval)
+	defer pruneTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-listener.Notify:
+			relay()
+		case <-ticker.C:
+			relay()
+		case now := <-pruneTicker.C:
+			if _, err := taskStreamService.PruneEvents(now.Add(-retention)); err != nil {
+				log.Errorf("Task stream failed to prune events: %v", err)
+			}
+		}
+	}
+}
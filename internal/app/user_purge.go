@@ -0,0 +1,28 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/sirupsen/logrus"
+)
+
+// runUserPurge deletes for good, every interval until ctx is done, the users deleted longer
+// than retention ago.
+func runUserPurge(ctx context.Context, userService services.UserService, interval, retention time.Duration, log *logrus.Logger) {
+	log.Infof("User purge has started with interval %s", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := userService.PurgeDeletedUsers(ctx, now.Add(-retention)); err != nil {
+				log.Errorf("User purge failed to purge deleted users: %v", err)
+			}
+		}
+	}
+}
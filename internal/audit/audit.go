@@ -0,0 +1,125 @@
+// Package audit carries the origin of a request through to the audit log and computes the
+// changes recorded in it.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Actor tells who made a request: the ID of the acting user, if known, the ID of the
+// request, which ties together the entries recorded while handling it, and whether the
+// request was authenticated as made by an admin.
+type Actor struct {
+	UserID    *uint
+	RequestID string
+	Admin     bool
+}
+
+type actorKey struct{}
+
+// NewContext returns a copy of ctx carrying actor.
+func NewContext(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// FromContext returns the actor carried by ctx, the zero Actor if there is none. Changes
+// made by the application itself, such as the sweeper stopping timers, have no actor.
+func FromContext(ctx context.Context) Actor {
+	if ctx == nil {
+		return Actor{}
+	}
+
+	actor, _ := ctx.Value(actorKey{}).(Actor)
+	return actor
+}
+
+// Change is the value of a field before and after a change, null where it did not exist.
+type Change struct {
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+var null = json.RawMessage("null")
+
+// Diff compares the JSON objects of before and after, either of which may be nil, and
+// returns the fields whose values differ.
+func Diff(before, after any) (map[string]Change, error) {
+	beforeFields, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]Change)
+	for name, value := range beforeFields {
+		if afterValue, ok := afterFields[name]; !ok || string(afterValue) != string(value) {
+			changes[name] = Change{Before: value, After: valueOrNull(afterValue)}
+		}
+	}
+	for name, value := range afterFields {
+		if _, ok := beforeFields[name]; !ok {
+			changes[name] = Change{Before: null, After: value}
+		}
+	}
+
+	return changes, nil
+}
+
+func fields(value any) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if value == nil {
+		return fields, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func valueOrNull(value json.RawMessage) json.RawMessage {
+	if value == nil {
+		return null
+	}
+
+	return value
+}
+
+// Erased replaces the values of erased fields in recorded changes.
+var Erased = json.RawMessage(`"[erased]"`)
+
+// Redact replaces the values before and after of the named fields in changes, a JSON object
+// as made by Diff, with Erased. It reports whether any field was redacted.
+func Redact(changes string, names []string) (string, bool, error) {
+	var fields map[string]Change
+	if err := json.Unmarshal([]byte(changes), &fields); err != nil {
+		return "", false, err
+	}
+
+	var redacted bool
+	for _, name := range names {
+		if _, ok := fields[name]; ok {
+			fields[name] = Change{Before: Erased, After: Erased}
+			redacted = true
+		}
+	}
+	if !redacted {
+		return changes, false, nil
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return "", false, err
+	}
+
+	return string(data), true, nil
+}
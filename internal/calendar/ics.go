@@ -0,0 +1,124 @@
+// Package calendar writes events as an iCalendar (RFC 5545) document one event at a time,
+// so that feeds of any length are streamed to the client instead of being built in memory.
+package calendar
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+)
+
+const ContentType = "text/calendar; charset=utf-8"
+
+const (
+	dateTimeLayout = "20060102T150405Z"
+	// lineLimit is the length in octets after which content lines are folded.
+	lineLimit = 75
+)
+
+// Event is a VEVENT. Start and End are written in UTC.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time
+}
+
+// Writer writes the events of a calendar called name. Nothing reaches the underlying writer
+// before the first event is written, and Close must be called to complete the document.
+type Writer struct {
+	writer  *bufio.Writer
+	name    string
+	stamp   time.Time
+	started bool
+	err     error
+}
+
+// NewWriter returns a writer of a calendar whose events are stamped with the time stamp.
+func NewWriter(w io.Writer, name string, stamp time.Time) *Writer {
+	return &Writer{
+		writer: bufio.NewWriter(w),
+		name:   name,
+		stamp:  stamp,
+	}
+}
+
+func (w *Writer) WriteEvent(event Event) error {
+	w.start()
+	w.writeLine("BEGIN:VEVENT")
+	w.writeLine("UID:" + escapeText(event.UID))
+	w.writeLine("DTSTAMP:" + formatDateTime(w.stamp))
+	w.writeLine("DTSTART:" + formatDateTime(event.Start))
+	w.writeLine("DTEND:" + formatDateTime(event.End))
+	w.writeLine("SUMMARY:" + escapeText(event.Summary))
+	if event.Description != "" {
+		w.writeLine("DESCRIPTION:" + escapeText(event.Description))
+	}
+	w.writeLine("END:VEVENT")
+
+	return w.err
+}
+
+func (w *Writer) Close() error {
+	w.start()
+	w.writeLine("END:VCALENDAR")
+	if w.err != nil {
+		return w.err
+	}
+
+	return w.writer.Flush()
+}
+
+func (w *Writer) start() {
+	if w.started {
+		return
+	}
+
+	w.started = true
+	w.writeLine("BEGIN:VCALENDAR")
+	w.writeLine("VERSION:2.0")
+	w.writeLine("PRODID:-//Time Tracker//Tracked time//EN")
+	w.writeLine("CALSCALE:GREGORIAN")
+	w.writeLine("METHOD:PUBLISH")
+	w.writeLine("X-WR-CALNAME:" + escapeText(w.name))
+}
+
+// writeLine writes a content line, folding it into lines of at most lineLimit octets
+// without splitting UTF-8 sequences. The first error is kept in w.err.
+func (w *Writer) writeLine(line string) {
+	limit := lineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		w.write(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts toward the limit.
+		limit = lineLimit - 1
+	}
+	w.write(line + "\r\n")
+}
+
+func (w *Writer) write(text string) {
+	if w.err == nil {
+		_, w.err = w.writer.WriteString(text)
+	}
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+func formatDateTime(t time.Time) string {
+	return t.UTC().Format(dateTimeLayout)
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// escapeText escapes a TEXT value, so that names such as "Review; part 2" stay one value.
+func escapeText(text string) string {
+	return textEscaper.Replace(text)
+}
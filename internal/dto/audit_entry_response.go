@@ -0,0 +1,16 @@
+package dto
+
+import "encoding/json"
+
+// AuditEntryResponse is an entry of the audit log. Changes maps every field that changed to
+// its value before and after, null where it did not exist.
+type AuditEntryResponse struct {
+	ID         uint64          `json:"id"`
+	ActorID    *uint           `json:"actor_id"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   uint            `json:"entity_id"`
+	RequestID  string          `json:"request_id,omitempty"`
+	CreatedAt  string          `json:"created_at"`
+	Changes    json.RawMessage `json:"changes" swaggertype:"object"`
+}
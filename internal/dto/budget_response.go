@@ -0,0 +1,17 @@
+package dto
+
+type BudgetResponse struct {
+	ID              uint    `json:"id"`
+	ProjectID       *uint   `json:"project_id,omitempty"`
+	TaskName        *string `json:"task_name,omitempty"`
+	EstimateMinutes int     `json:"estimate_minutes"`
+}
+
+// BudgetProgressResponse compares the recorded time in the scope of a budget with its estimate.
+// RemainingMinutes stops at zero, while Percentage goes beyond 100 once the budget is overrun.
+type BudgetProgressResponse struct {
+	BudgetResponse
+	ConsumedMinutes  int     `json:"consumed_minutes"`
+	RemainingMinutes int     `json:"remaining_minutes"`
+	Percentage       float64 `json:"percentage"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+// CalendarTokenResponse holds a new calendar token. It is only shown once: the feed is then
+// subscribed to at FeedURL, and a lost token is replaced by generating a new one.
+type CalendarTokenResponse struct {
+	Token   string `json:"token"`
+	FeedURL string `json:"feed_url" example:"/users/1/calendar.ics?token=..."`
+}
@@ -0,0 +1,6 @@
+package dto
+
+type ClientResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
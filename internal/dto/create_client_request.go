@@ -0,0 +1,5 @@
+package dto
+
+type CreateClientRequest struct {
+	Name string `json:"name" binding:"required"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+// CreateInvoiceRequest bills the unbilled tasks of a client for the period between
+// PeriodStart and PeriodEnd inclusive, both in format YYYY-MM-DD.
+type CreateInvoiceRequest struct {
+	ClientID    uint   `json:"client_id" binding:"required"`
+	PeriodStart string `json:"period_start" binding:"required"`
+	PeriodEnd   string `json:"period_end" binding:"required"`
+}
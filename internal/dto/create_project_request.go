@@ -0,0 +1,6 @@
+package dto
+
+type CreateProjectRequest struct {
+	Name     string `json:"name" binding:"required"`
+	ClientID *uint  `json:"client_id"`
+}
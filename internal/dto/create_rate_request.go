@@ -0,0 +1,12 @@
+package dto
+
+// CreateRateRequest adds an hourly rate. Leaving all of UserID, ProjectID and ClientID
+// empty creates the default rate. EffectiveFrom is a date in format YYYY-MM-DD.
+type CreateRateRequest struct {
+	UserID          *uint  `json:"user_id"`
+	ProjectID       *uint  `json:"project_id"`
+	ClientID        *uint  `json:"client_id"`
+	HourlyRateCents int64  `json:"hourly_rate_cents" binding:"min=0"`
+	Currency        string `json:"currency" binding:"required,len=3"`
+	EffectiveFrom   string `json:"effective_from" binding:"required"`
+}
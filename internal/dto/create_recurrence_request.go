@@ -0,0 +1,12 @@
+package dto
+
+// CreateRecurrenceRequest schedules entries of a template. Rule is an RRULE such as
+// FREQ=WEEKLY;BYDAY=MO,WE,FR; StartDate, in format YYYY-MM-DD, and StartTime, as HH:MM,
+// give the first occurrence in the time zone of the user of the template.
+type CreateRecurrenceRequest struct {
+	TemplateID uint   `json:"template_id" binding:"required"`
+	Rule       string `json:"rule" binding:"required,max=255" example:"FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"`
+	StartDate  string `json:"start_date" binding:"required" example:"2024-07-01"`
+	StartTime  string `json:"start_time" binding:"required,datetime=15:04" example:"09:30"`
+	Mode       string `json:"mode" binding:"required,oneof=planned actual" example:"actual"`
+}
@@ -0,0 +1,5 @@
+package dto
+
+type CreateTagRequest struct {
+	Name string `json:"name" binding:"required"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+// CreateTaskRequest logs a finished task after the fact. The end of the task is
+// given either as EndTime or as DurationMinutes counted from StartTime.
+type CreateTaskRequest struct {
+	UserID          uint       `json:"user_id" binding:"required"`
+	TaskName        string     `json:"task_name" binding:"required"`
+	ProjectID       *uint      `json:"project_id"`
+	Tags            []string   `json:"tags"`
+	Billable        *bool      `json:"billable"`
+	StartTime       time.Time  `json:"start_time" binding:"required"`
+	EndTime         *time.Time `json:"end_time"`
+	DurationMinutes int        `json:"duration_minutes" binding:"min=0"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+// CreateTaskTemplateRequest describes a task that is tracked again and again. DurationMinutes
+// is the length of the entries created by recurrences of the template.
+type CreateTaskTemplateRequest struct {
+	UserID          uint     `json:"user_id" binding:"required"`
+	TaskName        string   `json:"task_name" binding:"required,max=100"`
+	ProjectID       *uint    `json:"project_id"`
+	Tags            []string `json:"tags"`
+	Billable        *bool    `json:"billable"`
+	DurationMinutes int      `json:"duration_minutes" binding:"required,min=1" example:"15"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+type CreateTeamRequest struct {
+	Name    string `json:"name" binding:"required"`
+	UserIDs []uint `json:"user_ids"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+// CreateTimesheetRequest opens a draft timesheet for the week that Week, any of its days, falls in.
+type CreateTimesheetRequest struct {
+	UserID uint   `json:"user_id" binding:"required"`
+	Week   string `json:"week" binding:"required" example:"2024-07-01"`
+}
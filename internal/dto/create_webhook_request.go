@@ -0,0 +1,9 @@
+package dto
+
+// CreateWebhookRequest subscribes URL to Events, which are task.started, task.paused,
+// task.resumed, task.stopped, user.created, user.updated and user.deleted. Webhooks are active unless Active is false.
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" binding:"required,url,max=2048" example:"https://example.com/hooks/time-tracker"`
+	Events []string `json:"events" binding:"required,min=1,dive,required" example:"task.started,task.stopped"`
+	Active *bool    `json:"active"`
+}
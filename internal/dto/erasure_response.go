@@ -0,0 +1,15 @@
+package dto
+
+// ErasureResponse is the proof that the personal data of a user was erased: when, at whose
+// request, which fields, and how many audit entries and webhook deliveries were redacted or
+// deleted along with them.
+type ErasureResponse struct {
+	ID                       uint     `json:"id"`
+	UserID                   uint     `json:"user_id"`
+	ActorID                  *uint    `json:"actor_id"`
+	RequestID                string   `json:"request_id,omitempty"`
+	Fields                   []string `json:"fields"`
+	AuditEntriesRedacted     int      `json:"audit_entries_redacted"`
+	WebhookDeliveriesDeleted int      `json:"webhook_deliveries_deleted"`
+	ErasedAt                 string   `json:"erased_at"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+// ExportOptions holds the query parameters shared by the export endpoints. Columns may be
+// repeated or comma separated. Without Format the Accept header decides between CSV and
+// XLSX; without Locale the Accept-Language header decides how numbers are written.
+type ExportOptions struct {
+	Columns []string `form:"columns"`
+	Format  string   `form:"format" binding:"omitempty,oneof=csv xlsx"`
+	Locale  string   `form:"locale"`
+}
+
+type ExportUserTasksRequest struct {
+	GetUserTasksRequest
+	ExportOptions
+}
+
+type ExportUserSummaryRequest struct {
+	GetUserSummaryRequest
+	ExportOptions
+}
@@ -0,0 +1,9 @@
+package dto
+
+// FocusSessionRequest starts a task as a pomodoro of Cycles work intervals of WorkMinutes,
+// separated by breaks of BreakMinutes.
+type FocusSessionRequest struct {
+	WorkMinutes  int `json:"work_minutes" binding:"required,min=1,max=240" example:"25"`
+	BreakMinutes int `json:"break_minutes" binding:"required,min=1,max=120" example:"5"`
+	Cycles       int `json:"cycles" binding:"required,min=1,max=24" example:"4"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+// FocusSessionResponse tells the phase a pomodoro is in, work, break or done, and how many
+// work intervals have been completed. PhaseEndsAt is empty once the session is done.
+type FocusSessionResponse struct {
+	WorkMinutes     int    `json:"work_minutes"`
+	BreakMinutes    int    `json:"break_minutes"`
+	Cycles          int    `json:"cycles"`
+	CompletedCycles int    `json:"completed_cycles"`
+	Phase           string `json:"phase"`
+	PhaseEndsAt     string `json:"phase_ends_at,omitempty"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import "time"
+
+// GetAuditEntriesRequest holds the query of the audit log: the latest Limit entries, 100 by
+// default, optionally only those about an entity, by an actor, of an action or made from
+// From up to To. BeforeID pages back through the log from the entry with that ID.
+type GetAuditEntriesRequest struct {
+	EntityType string    `form:"entity_type" binding:"omitempty,oneof=user task"`
+	EntityID   *uint     `form:"entity_id"`
+	ActorID    *uint     `form:"actor_id"`
+	Action     string    `form:"action" binding:"omitempty,oneof=create update delete start pause resume stop confirm restore purge erase"`
+	From       time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To         time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	BeforeID   uint64    `form:"before_id"`
+	Limit      int       `form:"limit" binding:"omitempty,min=1,max=1000"`
+}
+
+// ExportAuditEntriesRequest exports every entry matching the filters, oldest first; Limit
+// does not apply.
+type ExportAuditEntriesRequest struct {
+	GetAuditEntriesRequest
+	ExportOptions
+}
@@ -0,0 +1,7 @@
+package dto
+
+// GetPersonalDataRequest holds the query of the personal data export: a JSON document, the
+// default, or a ZIP archive of JSON files.
+type GetPersonalDataRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=json zip"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+// GetPivotReportRequest holds the query of GetPivotReport. UserIDs may be repeated or comma
+// separated and are combined with the members of TeamID; without either the report covers
+// every user. From and To are inclusive dates in format YYYY-MM-DD.
+type GetPivotReportRequest struct {
+	UserIDs  []string `form:"user_ids"`
+	TeamID   *uint    `form:"team_id"`
+	From     string   `form:"from" binding:"required"`
+	To       string   `form:"to" binding:"required"`
+	Rows     string   `form:"rows" binding:"omitempty,oneof=user day week month task_name project client"`
+	Columns  string   `form:"columns" binding:"omitempty,oneof=user day week month task_name project client"`
+	Page     int      `form:"page" binding:"omitempty,min=1"`
+	PageSize int      `form:"page_size" binding:"omitempty,min=1,max=500"`
+}
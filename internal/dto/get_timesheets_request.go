@@ -0,0 +1,6 @@
+package dto
+
+type GetTimesheetsRequest struct {
+	UserID *uint  `form:"user_id"`
+	Status string `form:"status" binding:"omitempty,oneof=draft submitted approved rejected"`
+}
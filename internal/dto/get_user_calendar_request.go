@@ -0,0 +1,10 @@
+package dto
+
+// GetUserCalendarRequest holds the query of the calendar feed. StartDate and EndDate, in format
+// YYYY-MM-DD in the time zone of the user, are inclusive and default to the last 90 days.
+type GetUserCalendarRequest struct {
+	Token     string `form:"token" binding:"required"`
+	StartDate string `form:"start_date"`
+	EndDate   string `form:"end_date"`
+	ProjectID *uint  `form:"project_id"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+// GetUserSummaryRequest holds the query of GetUserSummary. From and To are inclusive dates
+// in format YYYY-MM-DD, GroupBy defaults to day.
+type GetUserSummaryRequest struct {
+	From    string `form:"from" binding:"required"`
+	To      string `form:"to" binding:"required"`
+	GroupBy string `form:"group_by" binding:"omitempty,oneof=day week month task_name project client"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+// GetUserTasksRequest holds the query of GetUserTasks. Tags may be repeated or comma separated,
+// TagsMatch selects whether a task needs any or all of them. AutoStopped narrows the tasks
+// down to the ones stopped by the sweeper, or to the other ones, for review.
+type GetUserTasksRequest struct {
+	StartDate   string   `form:"start_date"`
+	EndDate     string   `form:"end_date"`
+	Segments    bool     `form:"segments"`
+	ProjectID   *uint    `form:"project_id"`
+	ClientID    *uint    `form:"client_id"`
+	Tags        []string `form:"tags"`
+	TagsMatch   string   `form:"tags_match" binding:"omitempty,oneof=any all"`
+	AutoStopped *bool    `form:"auto_stopped"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+// GetWebhookDeliveriesRequest holds the query of the delivery log: the latest Limit
+// deliveries, 50 by default, optionally only those with Status.
+type GetWebhookDeliveriesRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending succeeded failed"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=500"`
+}
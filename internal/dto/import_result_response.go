@@ -0,0 +1,25 @@
+package dto
+
+type ImportResultResponse struct {
+	Source          string              `json:"source"`
+	DryRun          bool                `json:"dry_run"`
+	TotalRows       int                 `json:"total_rows"`
+	Created         int                 `json:"created"`
+	Duplicates      int                 `json:"duplicates"`
+	Invalid         int                 `json:"invalid"`
+	ProjectsCreated []string            `json:"projects_created,omitempty"`
+	Rows            []ImportRowResponse `json:"rows"`
+}
+
+// ImportRowResponse reports what happened to one line of the file. Status is one of
+// created, would_create, duplicate and invalid.
+type ImportRowResponse struct {
+	Line      int    `json:"line"`
+	Status    string `json:"status"`
+	UserID    *uint  `json:"user_id,omitempty"`
+	TaskID    *uint  `json:"task_id,omitempty"`
+	TaskName  string `json:"task_name,omitempty"`
+	StartTime string `json:"start_time,omitempty"`
+	EndTime   string `json:"end_time,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
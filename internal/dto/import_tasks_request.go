@@ -0,0 +1,12 @@
+package dto
+
+// ImportTasksRequest holds the form fields sent along with an uploaded export. Source is
+// detected from the file when empty. Timezone is the IANA zone the export's times are
+// written in, UTC by default. DateFormat is detected from the file when empty; it must be
+// given when the dates of the file could be read in several formats.
+type ImportTasksRequest struct {
+	Source     string `form:"source" binding:"omitempty,oneof=toggl clockify"`
+	DryRun     bool   `form:"dry_run"`
+	Timezone   string `form:"timezone"`
+	DateFormat string `form:"date_format" binding:"omitempty,oneof=YYYY-MM-DD MM/DD/YYYY DD/MM/YYYY DD.MM.YYYY"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+type InvoiceLineResponse struct {
+	TaskID          uint   `json:"task_id"`
+	Description     string `json:"description"`
+	Minutes         int    `json:"minutes"`
+	RoundedMinutes  int    `json:"rounded_minutes"`
+	HourlyRateCents int64  `json:"hourly_rate_cents"`
+	AmountCents     int64  `json:"amount_cents"`
+}
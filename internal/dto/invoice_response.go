@@ -0,0 +1,13 @@
+package dto
+
+type InvoiceResponse struct {
+	ID          uint                  `json:"id"`
+	Number      string                `json:"number"`
+	ClientID    uint                  `json:"client_id"`
+	PeriodStart string                `json:"period_start"`
+	PeriodEnd   string                `json:"period_end"`
+	Currency    string                `json:"currency"`
+	TotalCents  int64                 `json:"total_cents"`
+	CreatedAt   string                `json:"created_at"`
+	Lines       []InvoiceLineResponse `json:"lines,omitempty"`
+}
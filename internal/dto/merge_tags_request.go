@@ -0,0 +1,5 @@
+package dto
+
+type MergeTagsRequest struct {
+	TargetID uint `json:"target_id" binding:"required"`
+}
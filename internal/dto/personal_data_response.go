@@ -0,0 +1,30 @@
+package dto
+
+// PersonalDataResponse is everything stored about a user, as exported to them on request:
+// their profile, all their tasks and the audit entries about them, their tasks or made by
+// them. Erasure is set once their personal data has been erased.
+type PersonalDataResponse struct {
+	ExportedAt   string               `json:"exported_at"`
+	Profile      PersonalDataProfile  `json:"profile"`
+	Tasks        []TaskResponse       `json:"tasks"`
+	AuditEntries []AuditEntryResponse `json:"audit_entries"`
+	Erasure      *ErasureResponse     `json:"erasure,omitempty"`
+}
+
+// PersonalDataProfile is the profile of a user in full, passport number included. The passport
+// number is masked, as 12** ****90, unless the request is authenticated by the admin token.
+type PersonalDataProfile struct {
+	ID             uint    `json:"id"`
+	PassportNumber string  `json:"passport_number"`
+	Surname        string  `json:"surname"`
+	Name           string  `json:"name"`
+	Patronymic     string  `json:"patronymic"`
+	Address        string  `json:"address"`
+	Role           string  `json:"role"`
+	TimeZone       string  `json:"time_zone"`
+	WorkdayEnd     *string `json:"workday_end,omitempty"`
+	CreatedAt      string  `json:"created_at"`
+	UpdatedAt      string  `json:"updated_at"`
+	DeletedAt      string  `json:"deleted_at,omitempty"`
+	ErasedAt       string  `json:"erased_at,omitempty"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+// PivotReportResponse is a page of a pivot table. Cells of every row line up with Columns,
+// whose totals cover all rows of the report rather than only the current page.
+type PivotReportResponse struct {
+	From            string                `json:"from"`
+	To              string                `json:"to"`
+	RowDimension    string                `json:"row_dimension"`
+	ColumnDimension string                `json:"column_dimension"`
+	Page            int                   `json:"page"`
+	PageSize        int                   `json:"page_size"`
+	TotalRows       int64                 `json:"total_rows"`
+	Columns         []PivotColumnResponse `json:"columns"`
+	Rows            []PivotRowResponse    `json:"rows"`
+	TotalSeconds    int64                 `json:"total_seconds"`
+	TotalDuration   string                `json:"total_duration"`
+}
+
+type PivotColumnResponse struct {
+	Key       string `json:"key"`
+	ID        *uint  `json:"id,omitempty"`
+	TaskCount int64  `json:"task_count"`
+	Seconds   int64  `json:"seconds"`
+	Duration  string `json:"duration"`
+}
+
+type PivotRowResponse struct {
+	Key           string  `json:"key"`
+	ID            *uint   `json:"id,omitempty"`
+	Cells         []int64 `json:"cells"`
+	TotalSeconds  int64   `json:"total_seconds"`
+	TotalDuration string  `json:"total_duration"`
+}
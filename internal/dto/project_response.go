@@ -0,0 +1,9 @@
+package dto
+
+type ProjectResponse struct {
+	ID         uint   `json:"id"`
+	Name       string `json:"name"`
+	ClientID   *uint  `json:"client_id,omitempty"`
+	ClientName string `json:"client_name,omitempty"`
+	Archived   bool   `json:"archived"`
+}
@@ -0,0 +1,11 @@
+package dto
+
+type RateResponse struct {
+	ID              uint   `json:"id"`
+	UserID          *uint  `json:"user_id,omitempty"`
+	ProjectID       *uint  `json:"project_id,omitempty"`
+	ClientID        *uint  `json:"client_id,omitempty"`
+	HourlyRateCents int64  `json:"hourly_rate_cents"`
+	Currency        string `json:"currency"`
+	EffectiveFrom   string `json:"effective_from"`
+}
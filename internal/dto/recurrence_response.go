@@ -0,0 +1,12 @@
+package dto
+
+type RecurrenceResponse struct {
+	ID             uint     `json:"id"`
+	TemplateID     uint     `json:"template_id"`
+	Rule           string   `json:"rule"`
+	StartDate      string   `json:"start_date"`
+	StartTime      string   `json:"start_time"`
+	Mode           string   `json:"mode"`
+	GeneratedUntil string   `json:"generated_until,omitempty"`
+	SkippedDates   []string `json:"skipped_dates,omitempty"`
+}
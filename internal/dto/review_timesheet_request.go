@@ -0,0 +1,7 @@
+package dto
+
+// ReviewTimesheetRequest comments on approving, rejecting or reopening a timesheet. The
+// reviewer is the actor of the request. A comment is required when rejecting.
+type ReviewTimesheetRequest struct {
+	Comment string `json:"comment"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+type RoundingPolicyResponse struct {
+	ID               uint   `json:"id"`
+	ProjectID        *uint  `json:"project_id,omitempty"`
+	ClientID         *uint  `json:"client_id,omitempty"`
+	IncrementMinutes int    `json:"increment_minutes"`
+	Mode             string `json:"mode"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+// SetBudgetRequest sets the estimate of a project or of the tasks of a name, such as a
+// ticket number. Exactly one of ProjectID and TaskName must be given.
+type SetBudgetRequest struct {
+	ProjectID       *uint   `json:"project_id"`
+	TaskName        *string `json:"task_name" binding:"omitempty,min=1,max=100"`
+	EstimateMinutes int     `json:"estimate_minutes" binding:"required,min=1" example:"2400"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+// SetRoundingPolicyRequest sets the rounding policy of a project, of a client, or the global
+// one when neither is given, replacing the policy previously set for that scope.
+type SetRoundingPolicyRequest struct {
+	ProjectID        *uint  `json:"project_id"`
+	ClientID         *uint  `json:"client_id"`
+	IncrementMinutes int    `json:"increment_minutes" binding:"required,oneof=6 15" example:"15"`
+	Mode             string `json:"mode" binding:"required,oneof=up down nearest" example:"up"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+// SkipOccurrenceRequest cancels the occurrence of a recurrence on Date, in format YYYY-MM-DD
+// in the time zone of the user of the recurrence.
+type SkipOccurrenceRequest struct {
+	Date string `json:"date" binding:"required" example:"2024-07-15"`
+}
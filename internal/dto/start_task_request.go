@@ -1,6 +1,13 @@
 package dto
 
+// StartTaskRequest starts a timer. With FocusSession set, the timer runs as a pomodoro:
+// it is paused for the breaks and stopped after the last work interval.
 type StartTaskRequest struct {
-	UserID   uint   `json:"user_id" binding:"required"`
-	TaskName string `json:"task_name" binding:"required"`
+	UserID    uint     `json:"user_id" binding:"required"`
+	TaskName  string   `json:"task_name" binding:"required"`
+	ProjectID *uint    `json:"project_id"`
+	Tags      []string `json:"tags"`
+	Billable  *bool    `json:"billable"`
+
+	FocusSession *FocusSessionRequest `json:"focus_session"`
 }
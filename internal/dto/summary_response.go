@@ -0,0 +1,25 @@
+package dto
+
+type SummaryResponse struct {
+	UserID        uint   `json:"user_id"`
+	From          string `json:"from"`
+	To            string `json:"to"`
+	GroupBy       string `json:"group_by"`
+	TotalSeconds  int64  `json:"total_seconds"`
+	TotalDuration string `json:"total_duration"`
+	// The rounded totals sum the durations of the tasks, each rounded by the policy that applies to it.
+	RoundedTotalSeconds  int64                  `json:"rounded_total_seconds"`
+	RoundedTotalDuration string                 `json:"rounded_total_duration"`
+	Groups               []SummaryGroupResponse `json:"groups"`
+}
+
+type SummaryGroupResponse struct {
+	Key       string `json:"key"`
+	ID        *uint  `json:"id,omitempty"`
+	TaskCount int64  `json:"task_count"`
+	Seconds   int64  `json:"seconds"`
+	Duration  string `json:"duration"`
+
+	RoundedSeconds  int64  `json:"rounded_seconds"`
+	RoundedDuration string `json:"rounded_duration"`
+}
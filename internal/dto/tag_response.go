@@ -0,0 +1,6 @@
+package dto
+
+type TagResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
@@ -1,11 +1,25 @@
 package dto
 
 type TaskResponse struct {
-	ID        uint   `json:"id"`
-	UserID    uint   `json:"user_id"`
-	TaskName  string `json:"task_name"`
-	Hours     int    `json:"hours"`
-	Minutes   int    `json:"minutes"`
-	StartTime string `json:"start_time"`
-	EndTime   string `json:"end_time"`
+	ID             uint     `json:"id"`
+	UserID         uint     `json:"user_id"`
+	TaskName       string   `json:"task_name"`
+	ProjectID      *uint    `json:"project_id,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	Billable       bool     `json:"billable"`
+	InvoiceID      *uint    `json:"invoice_id,omitempty"`
+	Status         string   `json:"status"`
+	AutoStopped    bool     `json:"auto_stopped"`
+	AutoStopReason string   `json:"auto_stop_reason,omitempty"`
+	RecurrenceID   *uint    `json:"recurrence_id,omitempty"`
+	Hours          int      `json:"hours"`
+	Minutes        int      `json:"minutes"`
+	// RoundedMinutes is the reported duration after applying the rounding policy RoundingPolicyID,
+	// while Minutes keeps the recorded one. Both are only set when listing tasks.
+	RoundedMinutes   *int                  `json:"rounded_minutes,omitempty"`
+	RoundingPolicyID *uint                 `json:"rounding_policy_id,omitempty"`
+	StartTime        string                `json:"start_time"`
+	EndTime          string                `json:"end_time"`
+	Segments         []TaskSegmentResponse `json:"segments,omitempty"`
+	FocusSession     *FocusSessionResponse `json:"focus_session,omitempty"`
 }
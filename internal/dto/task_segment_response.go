@@ -0,0 +1,7 @@
+package dto
+
+type TaskSegmentResponse struct {
+	ID        uint   `json:"id"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
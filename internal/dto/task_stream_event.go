@@ -0,0 +1,13 @@
+package dto
+
+import "encoding/json"
+
+// TaskStreamEvent is an event of the live task stream. IDs increase, so a client that
+// reconnects passes the last one it received to be sent the events it missed.
+type TaskStreamEvent struct {
+	ID         uint64          `json:"id"`
+	UserID     uint            `json:"user_id"`
+	Event      string          `json:"event"`
+	OccurredAt string          `json:"occurred_at"`
+	Data       json.RawMessage `json:"data" swaggertype:"object"`
+}
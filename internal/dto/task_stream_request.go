@@ -0,0 +1,9 @@
+package dto
+
+// TaskStreamRequest holds the query of the task stream: the users to follow, repeated or
+// comma separated, and the ID of the last event received before reconnecting. The
+// Last-Event-ID header, sent by browsers when they reconnect, takes precedence over it.
+type TaskStreamRequest struct {
+	UserIDs     []string `form:"user_ids" binding:"required"`
+	LastEventID uint64   `form:"last_event_id"`
+}
@@ -0,0 +1,11 @@
+package dto
+
+type TaskTemplateResponse struct {
+	ID              uint     `json:"id"`
+	UserID          uint     `json:"user_id"`
+	TaskName        string   `json:"task_name"`
+	ProjectID       *uint    `json:"project_id,omitempty"`
+	Tags            []string `json:"tags,omitempty"`
+	Billable        bool     `json:"billable"`
+	DurationMinutes int      `json:"duration_minutes"`
+}
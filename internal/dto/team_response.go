@@ -0,0 +1,7 @@
+package dto
+
+type TeamResponse struct {
+	ID      uint   `json:"id"`
+	Name    string `json:"name"`
+	UserIDs []uint `json:"user_ids"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+type TimesheetResponse struct {
+	ID          uint                          `json:"id"`
+	UserID      uint                          `json:"user_id"`
+	WeekStart   string                        `json:"week_start"`
+	WeekEnd     string                        `json:"week_end"`
+	Status      string                        `json:"status"`
+	Transitions []TimesheetTransitionResponse `json:"transitions,omitempty"`
+}
+
+type TimesheetTransitionResponse struct {
+	FromStatus string `json:"from_status,omitempty"`
+	ToStatus   string `json:"to_status"`
+	ActorID    *uint  `json:"actor_id,omitempty"`
+	Comment    string `json:"comment,omitempty"`
+	CreatedAt  string `json:"created_at"`
+}
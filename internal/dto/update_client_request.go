@@ -0,0 +1,5 @@
+package dto
+
+type UpdateClientRequest struct {
+	Name string `json:"name" binding:"required"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+type UpdateProjectRequest struct {
+	Name     string `json:"name" binding:"required"`
+	ClientID *uint  `json:"client_id"`
+	Archived bool   `json:"archived"`
+}
@@ -0,0 +1,5 @@
+package dto
+
+type UpdateTagRequest struct {
+	Name string `json:"name" binding:"required"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+// UpdateTaskRequest corrects an existing task. Only the fields that are set are changed,
+// an empty Tags list removes all tags from the task.
+type UpdateTaskRequest struct {
+	TaskName        *string    `json:"task_name"`
+	ProjectID       *uint      `json:"project_id"`
+	Tags            []string   `json:"tags"`
+	Billable        *bool      `json:"billable"`
+	StartTime       *time.Time `json:"start_time"`
+	EndTime         *time.Time `json:"end_time"`
+	DurationMinutes *int       `json:"duration_minutes" binding:"omitempty,min=1"`
+}
@@ -0,0 +1,11 @@
+package dto
+
+// UpdateTaskTemplateRequest replaces the fields of a template. Entries already created
+// from the template are not changed.
+type UpdateTaskTemplateRequest struct {
+	TaskName        string   `json:"task_name" binding:"required,max=100"`
+	ProjectID       *uint    `json:"project_id"`
+	Tags            []string `json:"tags"`
+	Billable        *bool    `json:"billable"`
+	DurationMinutes int      `json:"duration_minutes" binding:"required,min=1" example:"15"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+// UpdateTeamRequest renames a team and replaces its members with UserIDs.
+type UpdateTeamRequest struct {
+	Name    string `json:"name" binding:"required"`
+	UserIDs []uint `json:"user_ids"`
+}
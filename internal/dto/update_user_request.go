@@ -1,8 +1,11 @@
 package dto
 
 type UpdateUserRequest struct {
-	Surname    string `json:"surname" binding:"required"`
-	Name       string `json:"name" binding:"required"`
-	Patronymic string `json:"patronymic" binding:"required"`
-	Address    string `json:"address" binding:"required"`
+	Surname    string  `json:"surname" binding:"required"`
+	Name       string  `json:"name" binding:"required"`
+	Patronymic string  `json:"patronymic" binding:"required"`
+	Address    string  `json:"address" binding:"required"`
+	TimeZone   *string `json:"time_zone" example:"Asia/Vladivostok"`
+	WorkdayEnd *string `json:"workday_end" binding:"omitempty,datetime=15:04" example:"18:00"`
+	Role       *string `json:"role" binding:"omitempty,oneof=user manager admin"`
 }
@@ -0,0 +1,9 @@
+package dto
+
+// UpdateWebhookRequest replaces the URL and events of a webhook; Active pauses or resumes
+// deliveries and is left unchanged when omitted. The secret is kept.
+type UpdateWebhookRequest struct {
+	URL    string   `json:"url" binding:"required,url,max=2048" example:"https://example.com/hooks/time-tracker"`
+	Events []string `json:"events" binding:"required,min=1,dive,required" example:"task.started,task.stopped"`
+	Active *bool    `json:"active"`
+}
@@ -1,10 +1,16 @@
 package dto
 
 type UserResponse struct {
-	ID             uint   `json:"id"`
-	PassportNumber string `json:"passport_number"`
-	Surname        string `json:"surname"`
-	Name           string `json:"name"`
-	Patronymic     string `json:"patronymic"`
-	Address        string `json:"address"`
+	ID uint `json:"id"`
+	// PassportNumber is masked, as 12** ****90, unless the request is authenticated by the admin token.
+	PassportNumber string  `json:"passport_number"`
+	Surname        string  `json:"surname"`
+	Name           string  `json:"name"`
+	Patronymic     string  `json:"patronymic"`
+	Address        string  `json:"address"`
+	Role           string  `json:"role"`
+	TimeZone       string  `json:"time_zone"`
+	WorkdayEnd     *string `json:"workday_end,omitempty"`
+	// DeletedAt is when the user was deleted, empty for active users.
+	DeletedAt string `json:"deleted_at,omitempty"`
 }
@@ -0,0 +1,21 @@
+package dto
+
+import "encoding/json"
+
+// WebhookDeliveryResponse is an entry of the delivery log of a webhook. LastStatusCode and
+// LastError tell how the latest attempt went; NextAttemptAt is set while retries are pending.
+type WebhookDeliveryResponse struct {
+	ID             uint            `json:"id"`
+	WebhookID      uint            `json:"webhook_id"`
+	EventID        string          `json:"event_id"`
+	Event          string          `json:"event"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  string          `json:"next_attempt_at,omitempty"`
+	LastStatusCode *int            `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	DeliveredAt    string          `json:"delivered_at,omitempty"`
+	ReplayOf       *uint           `json:"replay_of,omitempty"`
+	CreatedAt      string          `json:"created_at"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+// WebhookEvent is the JSON payload of webhook deliveries. ID identifies the event, so that
+// receivers can recognise retries and replays of a delivery they have already processed.
+type WebhookEvent struct {
+	ID         string `json:"id"`
+	Event      string `json:"event"`
+	OccurredAt string `json:"occurred_at"`
+	Data       any    `json:"data"`
+}
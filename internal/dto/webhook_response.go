@@ -0,0 +1,11 @@
+package dto
+
+// WebhookResponse describes a webhook. Secret, used to sign its deliveries, is only
+// returned when the webhook is created.
+type WebhookResponse struct {
+	ID     uint     `json:"id"`
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Active bool     `json:"active"`
+	Secret string   `json:"secret,omitempty"`
+}
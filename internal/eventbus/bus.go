@@ -0,0 +1,72 @@
+// Package eventbus publishes the domain events relayed from the outbox.
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+)
+
+// Publisher publishes a domain event. An event whose publishing fails is published again
+// later, so publishers may see an event more than once. A broker can be plugged in by
+// implementing Publisher.
+type Publisher interface {
+	Publish(ctx context.Context, event *models.OutboxEvent) error
+}
+
+// Handler handles a domain event. It must be idempotent, as events are delivered at least
+// once: event.ID identifies an event across deliveries.
+type Handler func(ctx context.Context, event *models.OutboxEvent) error
+
+// Bus is a Publisher that delivers events to the handlers subscribed in this process.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+	all      []Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{
+		handlers: make(map[string][]Handler),
+	}
+}
+
+// Subscribe calls handler with every published event of the given name.
+func (b *Bus) Subscribe(event string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[event] = append(b.handlers[event], handler)
+}
+
+// SubscribeAll calls handler with every published event.
+func (b *Bus) SubscribeAll(handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.all = append(b.all, handler)
+}
+
+// Publish calls the handlers of the event in the order they subscribed. Every handler is
+// called even if an earlier one fails; the failures are returned together, and the event is
+// then published again to all of them.
+func (b *Bus) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	b.mu.RLock()
+	handlers := append(append([]Handler(nil), b.handlers[event.Event]...), b.all...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("event %s with ID %d: %w", event.Event, event.ID, errors.Join(errs...))
+	}
+
+	return nil
+}
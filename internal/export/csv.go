@@ -0,0 +1,51 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+type csvWriter struct {
+	writer *csv.Writer
+	locale Locale
+	record []string
+}
+
+func newCSVWriter(w io.Writer, locale Locale) *csvWriter {
+	writer := csv.NewWriter(w)
+	writer.Comma = locale.CSVSeparator
+
+	return &csvWriter{
+		writer: writer,
+		locale: locale,
+	}
+}
+
+func (w *csvWriter) WriteRow(cells []any) error {
+	w.record = w.record[:0]
+	for _, cell := range cells {
+		text := formatCell(cell, w.locale)
+		if _, ok := cell.(string); ok {
+			text = escapeFormula(text)
+		}
+		w.record = append(w.record, text)
+	}
+
+	return w.writer.Write(w.record)
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// escapeFormula keeps spreadsheets from evaluating text that looks like a formula,
+// such as a task named "=HYPERLINK(...)".
+func escapeFormula(text string) string {
+	if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+
+	return text
+}
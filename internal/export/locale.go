@@ -0,0 +1,45 @@
+package export
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Locale holds the number formatting conventions of a language.
+type Locale struct {
+	DecimalSeparator string
+	// CSVSeparator separates CSV fields. Spreadsheets expect a semicolon where the
+	// comma is the decimal separator.
+	CSVSeparator rune
+}
+
+var (
+	localeDecimalPoint = Locale{DecimalSeparator: ".", CSVSeparator: ','}
+	localeDecimalComma = Locale{DecimalSeparator: ",", CSVSeparator: ';'}
+)
+
+// decimalCommaLanguages are the languages that write 1,5 rather than 1.5.
+var decimalCommaLanguages = map[string]bool{
+	"be": true, "cs": true, "da": true, "de": true, "es": true, "fi": true, "fr": true,
+	"it": true, "kk": true, "nb": true, "nl": true, "pl": true, "pt": true, "ru": true,
+	"sv": true, "tr": true, "uk": true,
+}
+
+// ParseLocale picks the locale of the first language of a tag such as "ru-RU" or
+// an Accept-Language header value. Unknown languages use a decimal point.
+func ParseLocale(value string) Locale {
+	language := strings.Split(value, ",")[0]
+	language = strings.Split(language, ";")[0]
+	language = strings.Split(strings.ReplaceAll(language, "_", "-"), "-")[0]
+	language = strings.ToLower(strings.TrimSpace(language))
+	if decimalCommaLanguages[language] {
+		return localeDecimalComma
+	}
+
+	return localeDecimalPoint
+}
+
+// FormatFloat renders value with two decimals and the locale's decimal separator.
+func (l Locale) FormatFloat(value float64) string {
+	return strings.Replace(strconv.FormatFloat(value, 'f', 2, 64), ".", l.DecimalSeparator, 1)
+}
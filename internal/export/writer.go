@@ -0,0 +1,76 @@
+// Package export writes tabular data as CSV or XLSX one row at a time, so that
+// large exports are streamed to the client instead of being built in memory.
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+const (
+	ContentTypeCSV  = "text/csv"
+	ContentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// Writer writes rows of cells. A cell is nil, a string, an integer, a float64 or a
+// time.Time. Nothing reaches the underlying writer before the first row is written,
+// and Close must be called to complete the document.
+type Writer interface {
+	WriteRow(cells []any) error
+	Close() error
+}
+
+// NewWriter returns a writer for format, which is one of FormatCSV and FormatXLSX.
+func NewWriter(format string, w io.Writer, locale Locale) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, locale), nil
+	case FormatXLSX:
+		return newXLSXWriter(w), nil
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// ContentType returns the MIME type of format.
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return ContentTypeXLSX
+	}
+
+	return ContentTypeCSV
+}
+
+// formatCell renders a cell as text; numbers use the decimal separator of the locale.
+func formatCell(cell any, locale Locale) string {
+	switch value := cell.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case int:
+		return strconv.Itoa(value)
+	case int64:
+		return strconv.FormatInt(value, 10)
+	case uint:
+		return strconv.FormatUint(uint64(value), 10)
+	case float64:
+		return locale.FormatFloat(value)
+	case bool:
+		return strconv.FormatBool(value)
+	case time.Time:
+		if value.IsZero() {
+			return ""
+		}
+		return value.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(value)
+	}
+}
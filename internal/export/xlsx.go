@@ -0,0 +1,137 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`</Types>`
+
+const xlsxRootRelationships = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets>` +
+	`</workbook>`
+
+const xlsxWorkbookRelationships = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`</Relationships>`
+
+const (
+	xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetFooter = `</sheetData></worksheet>`
+)
+
+// xlsxWriter writes a workbook with a single sheet. The fixed parts of the package
+// are written up front, then the sheet is streamed into its zip entry row by row
+// using inline strings, so no shared string table has to be kept in memory.
+type xlsxWriter struct {
+	archive *zip.Writer
+	sheet   io.Writer
+	row     int
+	builder strings.Builder
+}
+
+func newXLSXWriter(w io.Writer) *xlsxWriter {
+	return &xlsxWriter{archive: zip.NewWriter(w)}
+}
+
+func (w *xlsxWriter) WriteRow(cells []any) error {
+	if w.sheet == nil {
+		if err := w.start(); err != nil {
+			return err
+		}
+	}
+
+	w.row++
+	w.builder.Reset()
+	fmt.Fprintf(&w.builder, `<row r="%d">`, w.row)
+	for i, cell := range cells {
+		reference := columnName(i) + strconv.Itoa(w.row)
+		switch value := cell.(type) {
+		case nil:
+			continue
+		case int, int64, uint, float64:
+			fmt.Fprintf(&w.builder, `<c r="%s"><v>%s</v></c>`, reference, formatCell(value, localeDecimalPoint))
+		default:
+			fmt.Fprintf(&w.builder, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, reference)
+			if err := xml.EscapeText(&w.builder, []byte(formatCell(value, localeDecimalPoint))); err != nil {
+				return err
+			}
+			w.builder.WriteString(`</t></is></c>`)
+		}
+	}
+	w.builder.WriteString(`</row>`)
+
+	_, err := io.WriteString(w.sheet, w.builder.String())
+	return err
+}
+
+func (w *xlsxWriter) Close() error {
+	if w.sheet == nil {
+		if err := w.start(); err != nil {
+			return err
+		}
+	}
+	if _, err := io.WriteString(w.sheet, xlsxSheetFooter); err != nil {
+		return err
+	}
+
+	return w.archive.Close()
+}
+
+func (w *xlsxWriter) start() error {
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRelationships},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRelationships},
+	}
+	for _, part := range parts {
+		entry, err := w.archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(entry, part.content); err != nil {
+			return err
+		}
+	}
+
+	sheet, err := w.archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	w.sheet = sheet
+
+	_, err = io.WriteString(w.sheet, xlsxSheetHeader)
+	return err
+}
+
+// columnName returns the spreadsheet name of the zero-based column index: A, B, ..., Z, AA, AB, ...
+func columnName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+
+	return name
+}
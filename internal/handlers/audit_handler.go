@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/export"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type AuditHandler struct {
+	auditService services.AuditService
+	logger       *logrus.Logger
+}
+
+func NewAuditHandler(auditService services.AuditService, logger *logrus.Logger) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+		logger:       logger,
+	}
+}
+
+// GetAuditEntries godoc
+// @Summary Get the audit log
+// @Description Get the latest entries of the audit log of users and tasks, newest first. Each entry tells who did what to which entity, when, in which request, and the fields that changed with their values before and after. Requests name their actor in the X-Actor-ID header; entries without an actor were made by the application itself
+// @Tags audit
+// @Accept json
+// @Produce json
+// @Param entity_type query string false "Only entries about users or tasks" Enums(user, task)
+// @Param entity_id query int false "Only entries about the entity with this ID"
+// @Param actor_id query int false "Only entries made by this user"
+// @Param action query string false "Only entries of this action" Enums(create, update, delete, start, pause, resume, stop, confirm, restore, purge, erase)
+// @Param from query string false "Only entries made at or after this time, RFC 3339"
+// @Param to query string false "Only entries made before this time, RFC 3339"
+// @Param before_id query int false "Only entries older than the one with this ID, to page back through the log"
+// @Param limit query int false "Number of entries, 100 by default and at most 1000"
+// @Success 200 {array} dto.AuditEntryResponse
+// @Failure 400 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /audit [get]
+func (h *AuditHandler) GetAuditEntries(c *gin.Context) {
+	var request dto.GetAuditEntriesRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		h.logger.Debugf("GetAuditEntries: invalid query: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("GetAuditEntries: fetching audit entries")
+	entries, err := h.auditService.GetEntries(request)
+	if err != nil {
+		h.logger.Debugf("GetAuditEntries: failed to fetch audit entries: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("GetAuditEntries: fetched %d audit entries", len(entries))
+	c.JSON(http.StatusOK, entries)
+}
+
+// ExportAuditEntries godoc
+// @Summary Export the audit log
+// @Description Export the entries of the audit log matching the filters of GetAuditEntries as CSV or XLSX, oldest first, streamed row by row. The changes are written as JSON
+// @Tags audit
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param entity_type query string false "Only entries about users or tasks" Enums(user, task)
+// @Param entity_id query int false "Only entries about the entity with this ID"
+// @Param actor_id query int false "Only entries made by this user"
+// @Param action query string false "Only entries of this action" Enums(create, update, delete, start, pause, resume, stop, confirm, restore, purge, erase)
+// @Param from query string false "Only entries made at or after this time, RFC 3339"
+// @Param to query string false "Only entries made before this time, RFC 3339"
+// @Param columns query string false "Comma separated columns: id, created_at, actor_id, action, entity_type, entity_id, request_id, changes"
+// @Param format query string false "Export format, negotiated from the Accept header by default" Enums(csv, xlsx)
+// @Param locale query string false "Locale of numbers such as ru or en, taken from Accept-Language by default"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /audit/export [get]
+func (h *AuditHandler) ExportAuditEntries(c *gin.Context) {
+	var request dto.ExportAuditEntriesRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		h.logger.Debugf("ExportAuditEntries: invalid query: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("ExportAuditEntries: received request to export audit entries")
+	streamExport(c, h.logger, "audit", request.ExportOptions, func(writer export.Writer) error {
+		return h.auditService.ExportEntries(request, writer)
+	})
+}
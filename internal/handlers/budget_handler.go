@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+type BudgetHandler struct {
+	budgetService services.BudgetService
+	logger        *logrus.Logger
+}
+
+func NewBudgetHandler(budgetService services.BudgetService, logger *logrus.Logger) *BudgetHandler {
+	return &BudgetHandler{
+		budgetService: budgetService,
+		logger:        logger,
+	}
+}
+
+// SetBudget godoc
+// @Summary Set a budget
+// @Description Set the estimate of a project or of the tasks of a name, such as a ticket number, changing the estimate of an existing budget of the same scope. Alerts are sent when stopping a task takes the time spent to 80% and to 100% of the estimate
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Param budget body dto.SetBudgetRequest true "Set budget request"
+// @Success 200 {object} dto.BudgetResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /budgets [post]
+func (h *BudgetHandler) SetBudget(c *gin.Context) {
+	var request dto.SetBudgetRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Debugf("SetBudget: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("SetBudget: setting budget to %d minutes", request.EstimateMinutes)
+	budget, err := h.budgetService.SetBudget(request)
+	if err != nil {
+		h.logger.Debugf("SetBudget: failed to set budget: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("SetBudget: budget set with ID: %d", budget.ID)
+	c.JSON(http.StatusOK, budget)
+}
+
+// GetBudgets godoc
+// @Summary Get budgets
+// @Description Get the budgets of projects and task names
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Success 200 {array} dto.BudgetResponse
+// @Failure 500 {object} map[string]any
+// @Router /budgets [get]
+func (h *BudgetHandler) GetBudgets(c *gin.Context) {
+	h.logger.Info("GetBudgets: fetching budgets")
+	budgets, err := h.budgetService.GetBudgets()
+	if err != nil {
+		h.logger.Debugf("GetBudgets: failed to fetch budgets: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("GetBudgets: fetched %d budgets", len(budgets))
+	c.JSON(http.StatusOK, budgets)
+}
+
+// GetBudgetProgress godoc
+// @Summary Get the progress of a budget
+// @Description Get the minutes recorded by all users in the scope of a budget, the minutes remaining and the percentage of the estimate consumed. Running tasks count with the time recorded until they were last paused
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Param id path int true "Budget ID"
+// @Success 200 {object} dto.BudgetProgressResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /budgets/{id}/progress [get]
+func (h *BudgetHandler) GetBudgetProgress(c *gin.Context) {
+	budgetID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("GetBudgetProgress: invalid budget ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid budget ID"})
+		return
+	}
+
+	h.logger.Infof("GetBudgetProgress: getting progress of budget with ID: %d", budgetID)
+	progress, err := h.budgetService.GetBudgetProgress(uint(budgetID))
+	if err != nil {
+		h.logger.Debugf("GetBudgetProgress: failed to get budget progress: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, progress)
+}
+
+// DeleteBudget godoc
+// @Summary Delete a budget
+// @Description Delete a budget. The tasks in its scope are kept
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Param id path int true "Budget ID"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /budgets/{id} [delete]
+func (h *BudgetHandler) DeleteBudget(c *gin.Context) {
+	budgetID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("DeleteBudget: invalid budget ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid budget ID"})
+		return
+	}
+
+	h.logger.Infof("DeleteBudget: deleting budget with ID: %d", budgetID)
+	if err := h.budgetService.DeleteBudget(uint(budgetID)); err != nil {
+		h.logger.Debugf("DeleteBudget: failed to delete budget: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("DeleteBudget: budget deleted with ID: %d", budgetID)
+	c.JSON(http.StatusOK, gin.H{"budget": nil})
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"fmt"
+	"github.com/Dor1ma/Time-Tracker/internal/calendar"
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+type CalendarHandler struct {
+	calendarService services.CalendarService
+	logger          *logrus.Logger
+}
+
+func NewCalendarHandler(calendarService services.CalendarService, logger *logrus.Logger) *CalendarHandler {
+	return &CalendarHandler{
+		calendarService: calendarService,
+		logger:          logger,
+	}
+}
+
+// RegenerateCalendarToken godoc
+// @Summary Regenerate the calendar token of a user
+// @Description Generate a new token for the calendar feed of a user and return it with the feed URL. The previous token stops working, which revokes access for everyone subscribed with it
+// @Tags calendar
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} dto.CalendarTokenResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /users/{id}/calendar-token [post]
+func (h *CalendarHandler) RegenerateCalendarToken(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("RegenerateCalendarToken: invalid user ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	h.logger.Infof("RegenerateCalendarToken: regenerating calendar token for user ID: %d", userID)
+	token, err := h.calendarService.RegenerateToken(uint(userID))
+	if err != nil {
+		h.logger.Debugf("RegenerateCalendarToken: failed to regenerate token: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, token)
+}
+
+// GetUserCalendar godoc
+// @Summary Get the calendar feed of a user
+// @Description Read-only iCalendar feed of the tracked time of a user, for subscribing from calendar apps. Each task is an event with its name and duration; running and paused tasks are open until now. Requires the token of the user
+// @Tags calendar
+// @Produce text/calendar
+// @Param id path int true "User ID"
+// @Param token query string true "Calendar token"
+// @Param start_date query string false "Start date in format YYYY-MM-DD, in the time zone of the user, 90 days before the end date by default"
+// @Param end_date query string false "End date in format YYYY-MM-DD, in the time zone of the user, today by default"
+// @Param project_id query int false "Only tasks of this project"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]any
+// @Failure 403 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /users/{id}/calendar.ics [get]
+func (h *CalendarHandler) GetUserCalendar(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("GetUserCalendar: invalid user ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var request dto.GetUserCalendarRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		h.logger.Debugf("GetUserCalendar: invalid query: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("GetUserCalendar: received request for calendar of user ID: %d", userID)
+	c.Header("Content-Type", calendar.ContentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="calendar-%d.ics"`, userID))
+	c.Header("Cache-Control", "private, max-age=300")
+
+	err = h.calendarService.WriteUserCalendar(uint(userID), request, c.Writer)
+	if err == nil {
+		return
+	}
+
+	if !c.Writer.Written() {
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		c.Writer.Header().Del("Cache-Control")
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Errorf("GetUserCalendar: calendar of user ID %d failed after streaming started: %v", userID, err)
+	c.Abort()
+}
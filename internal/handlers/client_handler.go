@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+type ClientHandler struct {
+	clientService services.ClientService
+	logger        *logrus.Logger
+}
+
+func NewClientHandler(clientService services.ClientService, logger *logrus.Logger) *ClientHandler {
+	return &ClientHandler{
+		clientService: clientService,
+		logger:        logger,
+	}
+}
+
+// CreateClient godoc
+// @Summary Create a new client
+// @Description Create a new client that projects can be billed to
+// @Tags clients
+// @Accept json
+// @Produce json
+// @Param client body dto.CreateClientRequest true "Create client request"
+// @Success 201 {object} dto.ClientResponse
+// @Failure 400 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /clients [post]
+func (h *ClientHandler) CreateClient(c *gin.Context) {
+	var request dto.CreateClientRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Debugf("CreateClient: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("CreateClient: creating client with name: %s", request.Name)
+	client, err := h.clientService.CreateClient(request)
+	if err != nil {
+		h.logger.Debugf("CreateClient: failed to create client: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("CreateClient: client created with ID: %d", client.ID)
+	c.JSON(http.StatusCreated, client)
+}
+
+// GetClients godoc
+// @Summary Get all clients
+// @Description Get all clients ordered by name
+// @Tags clients
+// @Accept json
+// @Produce json
+// @Success 200 {array} dto.ClientResponse
+// @Failure 500 {object} map[string]any
+// @Router /clients [get]
+func (h *ClientHandler) GetClients(c *gin.Context) {
+	h.logger.Info("GetClients: fetching all clients")
+	clients, err := h.clientService.GetAllClients()
+	if err != nil {
+		h.logger.Debugf("GetClients: failed to fetch clients: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("GetClients: fetched %d clients", len(clients))
+	c.JSON(http.StatusOK, clients)
+}
+
+// GetClient godoc
+// @Summary Get a client
+// @Description Get a client by ID
+// @Tags clients
+// @Accept json
+// @Produce json
+// @Param id path int true "Client ID"
+// @Success 200 {object} dto.ClientResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /clients/{id} [get]
+func (h *ClientHandler) GetClient(c *gin.Context) {
+	clientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("GetClient: invalid client ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid client ID"})
+		return
+	}
+
+	client, err := h.clientService.GetClientById(uint(clientID))
+	if err != nil {
+		h.logger.Debugf("GetClient: failed to get client: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client)
+}
+
+// UpdateClient godoc
+// @Summary Update an existing client
+// @Description Rename an existing client
+// @Tags clients
+// @Accept json
+// @Produce json
+// @Param id path int true "Client ID"
+// @Param client body dto.UpdateClientRequest true "Update client request"
+// @Success 200 {object} dto.ClientResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /clients/{id} [put]
+func (h *ClientHandler) UpdateClient(c *gin.Context) {
+	clientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("UpdateClient: invalid client ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid client ID"})
+		return
+	}
+
+	var request dto.UpdateClientRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Debugf("UpdateClient: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("UpdateClient: updating client with ID: %d", clientID)
+	client, err := h.clientService.UpdateClient(uint(clientID), request)
+	if err != nil {
+		h.logger.Debugf("UpdateClient: failed to update client: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("UpdateClient: client updated with ID: %d", clientID)
+	c.JSON(http.StatusOK, client)
+}
+
+// DeleteClient godoc
+// @Summary Delete an existing client
+// @Description Delete a client that has no projects
+// @Tags clients
+// @Accept json
+// @Produce json
+// @Param id path int true "Client ID"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]any
+// @Failure 409 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /clients/{id} [delete]
+func (h *ClientHandler) DeleteClient(c *gin.Context) {
+	clientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("DeleteClient: invalid client ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid client ID"})
+		return
+	}
+
+	h.logger.Infof("DeleteClient: deleting client with ID: %d", clientID)
+	if err := h.clientService.DeleteClient(uint(clientID)); err != nil {
+		h.logger.Debugf("DeleteClient: failed to delete client: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("DeleteClient: client deleted with ID: %d", clientID)
+	c.JSON(http.StatusOK, gin.H{"client": nil})
+}
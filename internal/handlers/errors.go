@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// errorStatus maps errors returned by the services to HTTP status codes.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrInvalidTaskInterval),
+		errors.Is(err, models.ErrTaskEndRequired),
+		errors.Is(err, models.ErrEmptyTagName),
+		errors.Is(err, models.ErrInvalidTagMerge),
+		errors.Is(err, models.ErrInvalidRateScope),
+		errors.Is(err, models.ErrInvalidRoundingScope),
+		errors.Is(err, models.ErrInvalidBudgetScope),
+		errors.Is(err, models.ErrInvalidRecurrenceRule),
+		errors.Is(err, models.ErrInvalidDateFormat),
+		errors.Is(err, models.ErrInvalidPeriod),
+		errors.Is(err, models.ErrInvalidIDList),
+		errors.Is(err, models.ErrSameReportDimension),
+		errors.Is(err, models.ErrUnknownExportColumn),
+		errors.Is(err, models.ErrInvalidImportFile),
+		errors.Is(err, models.ErrInvalidTimezone),
+		errors.Is(err, models.ErrUnknownWebhookEvent),
+		errors.Is(err, models.ErrReviewCommentRequired),
+		errors.Is(err, models.ErrReviewerRequired):
+		return http.StatusBadRequest
+	case errors.Is(err, models.ErrNotManager),
+		errors.Is(err, models.ErrNotAdmin),
+		errors.Is(err, models.ErrOwnTimesheetReview),
+		errors.Is(err, models.ErrRoleChange),
+		errors.Is(err, models.ErrInvalidCalendarToken):
+		return http.StatusForbidden
+	case errors.Is(err, models.ErrTaskNotRunning),
+		errors.Is(err, models.ErrTaskNotPaused),
+		errors.Is(err, models.ErrTaskNotStopped),
+		errors.Is(err, models.ErrTaskAlreadyStopped),
+		errors.Is(err, models.ErrTaskNotPlanned),
+		errors.Is(err, models.ErrOccurrenceRecorded),
+		errors.Is(err, models.ErrTaskAlreadyRunning),
+		errors.Is(err, models.ErrProjectArchived),
+		errors.Is(err, models.ErrUserDeleted),
+		errors.Is(err, models.ErrUserNotDeleted),
+		errors.Is(err, models.ErrUserErased),
+		errors.Is(err, models.ErrProjectInUse),
+		errors.Is(err, models.ErrClientInUse),
+		errors.Is(err, models.ErrTagExists),
+		errors.Is(err, models.ErrTaskInvoiced),
+		errors.Is(err, models.ErrTaskOverlap),
+		errors.Is(err, models.ErrTimesheetExists),
+		errors.Is(err, models.ErrTimesheetLocked),
+		errors.Is(err, models.ErrTimesheetHasOpenTasks),
+		errors.Is(err, models.ErrInvalidTimesheetStatus):
+		return http.StatusConflict
+	case errors.Is(err, models.ErrRateNotFound),
+		errors.Is(err, models.ErrCurrencyMismatch),
+		errors.Is(err, models.ErrNothingToInvoice):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondError writes err as a JSON error body, attaching the clashing tasks for conflicts.
+func respondError(c *gin.Context, err error) {
+	var conflict *services.TaskConflictError
+	if errors.As(err, &conflict) {
+		taskIDs := make([]uint, len(conflict.Tasks))
+		for i, task := range conflict.Tasks {
+			taskIDs[i] = task.ID
+		}
+
+		c.JSON(http.StatusConflict, gin.H{
+			"error":                err.Error(),
+			"conflicting_task_ids": taskIDs,
+			"conflicting_tasks":    conflict.Tasks,
+		})
+		return
+	}
+
+	c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+}
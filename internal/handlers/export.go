@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/export"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// streamExport writes an export as an attachment named filename. The format comes from the
+// format parameter or else the Accept header, the locale from the locale parameter or else
+// the Accept-Language header. Errors are reported as JSON as long as nothing has been sent;
+// once rows are streamed the response can only be cut short.
+func streamExport(c *gin.Context, logger *logrus.Logger, filename string, options dto.ExportOptions,
+	write func(writer export.Writer) error) {
+	format := options.Format
+	if format == "" && c.NegotiateFormat(export.ContentTypeCSV, export.ContentTypeXLSX) == export.ContentTypeXLSX {
+		format = export.FormatXLSX
+	} else if format == "" {
+		format = export.FormatCSV
+	}
+
+	locale := options.Locale
+	if locale == "" {
+		locale = c.GetHeader("Accept-Language")
+	}
+
+	writer, err := export.NewWriter(format, c.Writer, export.ParseLocale(locale))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", export.ContentType(format))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, filename, format))
+
+	err = write(writer)
+	if err == nil {
+		err = writer.Close()
+	}
+	if err == nil {
+		return
+	}
+
+	if !c.Writer.Written() {
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		respondError(c, err)
+		return
+	}
+
+	logger.Errorf("streamExport: export %s failed after streaming started: %v", filename, err)
+	c.Abort()
+}
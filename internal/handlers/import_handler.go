@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type ImportHandler struct {
+	importService services.ImportService
+	logger        *logrus.Logger
+}
+
+func NewImportHandler(importService services.ImportService, logger *logrus.Logger) *ImportHandler {
+	return &ImportHandler{
+		importService: importService,
+		logger:        logger,
+	}
+}
+
+// ImportTasks godoc
+// @Summary Import tasks from another time tracker
+// @Description Import the entries of a Toggl or Clockify CSV export. Users are matched by a "User ID" column or by name, missing projects and clients are created. Entries imported before are reported as duplicates, and a dry run reports what would be created without writing anything
+// @Tags imports
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV export"
+// @Param source formData string false "Tracker the file comes from, detected by default" Enums(toggl, clockify)
+// @Param dry_run formData bool false "Only report what would be imported"
+// @Param timezone formData string false "IANA time zone of the times in the file, UTC by default"
+// @Param date_format formData string false "Format of the dates in the file, detected by default. Required when the dates could be read in several formats" Enums(YYYY-MM-DD, MM/DD/YYYY, DD/MM/YYYY, DD.MM.YYYY)
+// @Success 200 {object} dto.ImportResultResponse
+// @Failure 400 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /imports/tasks [post]
+func (h *ImportHandler) ImportTasks(c *gin.Context) {
+	var request dto.ImportTasksRequest
+	if err := c.ShouldBind(&request); err != nil {
+		h.logger.Debugf("ImportTasks: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		h.logger.Debugf("ImportTasks: missing file: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File is required"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.logger.Debugf("ImportTasks: failed to open file: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	h.logger.Infof("ImportTasks: importing file %s of %d bytes", fileHeader.Filename, fileHeader.Size)
+	result, err := h.importService.ImportTasks(c.Request.Context(), request, file)
+	if err != nil {
+		h.logger.Debugf("ImportTasks: failed to import tasks: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("ImportTasks: imported %d of %d rows", result.Created, result.TotalRows)
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+type InvoiceHandler struct {
+	invoiceService services.InvoiceService
+	logger         *logrus.Logger
+}
+
+func NewInvoiceHandler(invoiceService services.InvoiceService, logger *logrus.Logger) *InvoiceHandler {
+	return &InvoiceHandler{
+		invoiceService: invoiceService,
+		logger:         logger,
+	}
+}
+
+// CreateInvoice godoc
+// @Summary Create an invoice
+// @Description Invoice the client's unbilled, billable and stopped tasks started within the period. Both period dates are inclusive
+// @Tags invoices
+// @Accept json
+// @Produce json
+// @Param invoice body dto.CreateInvoiceRequest true "Create invoice request"
+// @Success 201 {object} dto.InvoiceResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 409 {object} map[string]any
+// @Failure 422 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /invoices [post]
+func (h *InvoiceHandler) CreateInvoice(c *gin.Context) {
+	var request dto.CreateInvoiceRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Debugf("CreateInvoice: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("CreateInvoice: creating invoice for client ID: %d", request.ClientID)
+	invoice, err := h.invoiceService.CreateInvoice(request)
+	if err != nil {
+		h.logger.Debugf("CreateInvoice: failed to create invoice: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("CreateInvoice: invoice %s created with ID: %d", invoice.Number, invoice.ID)
+	c.JSON(http.StatusCreated, invoice)
+}
+
+// GetInvoices godoc
+// @Summary Get invoices
+// @Description Get invoices without their lines, optionally of one client
+// @Tags invoices
+// @Accept json
+// @Produce json
+// @Param client_id query int false "Client ID"
+// @Success 200 {array} dto.InvoiceResponse
+// @Failure 400 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /invoices [get]
+func (h *InvoiceHandler) GetInvoices(c *gin.Context) {
+	clientID, err := optionalIDQuery(c, "client_id")
+	if err != nil {
+		h.logger.Debugf("GetInvoices: invalid client ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid client ID"})
+		return
+	}
+
+	h.logger.Info("GetInvoices: fetching invoices")
+	invoices, err := h.invoiceService.GetInvoices(clientID)
+	if err != nil {
+		h.logger.Debugf("GetInvoices: failed to fetch invoices: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("GetInvoices: fetched %d invoices", len(invoices))
+	c.JSON(http.StatusOK, invoices)
+}
+
+// GetInvoice godoc
+// @Summary Get an invoice
+// @Description Get an invoice by ID together with its lines
+// @Tags invoices
+// @Accept json
+// @Produce json
+// @Param id path int true "Invoice ID"
+// @Success 200 {object} dto.InvoiceResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /invoices/{id} [get]
+func (h *InvoiceHandler) GetInvoice(c *gin.Context) {
+	invoiceID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("GetInvoice: invalid invoice ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invoice ID"})
+		return
+	}
+
+	invoice, err := h.invoiceService.GetInvoice(uint(invoiceID))
+	if err != nil {
+		h.logger.Debugf("GetInvoice: failed to get invoice: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invoice)
+}
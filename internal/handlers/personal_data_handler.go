@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+type PersonalDataHandler struct {
+	personalDataService services.PersonalDataService
+	logger              *logrus.Logger
+}
+
+func NewPersonalDataHandler(personalDataService services.PersonalDataService, logger *logrus.Logger) *PersonalDataHandler {
+	return &PersonalDataHandler{
+		personalDataService: personalDataService,
+		logger:              logger,
+	}
+}
+
+// GetPersonalData godoc
+// @Summary Export the personal data of a user
+// @Description Download everything stored about a user: their profile with the passport number, masked unless the request is authenticated by the admin token, all their tasks and the audit entries about them, their tasks or made by them. As a JSON document, or as a ZIP archive of profile.json, tasks.json and audit_entries.json, with erasure.json once the data has been erased
+// @Tags users
+// @Produce json
+// @Produce application/zip
+// @Param id path int true "User ID"
+// @Param format query string false "Download format, json by default" Enums(json, zip)
+// @Success 200 {object} dto.PersonalDataResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /users/{id}/personal-data [get]
+func (h *PersonalDataHandler) GetPersonalData(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("GetPersonalData: invalid user ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var request dto.GetPersonalDataRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		h.logger.Debugf("GetPersonalData: invalid query: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("GetPersonalData: exporting personal data of user with ID: %d", userID)
+	data, err := h.personalDataService.GetPersonalData(c.Request.Context(), uint(userID))
+	if err != nil {
+		h.logger.Debugf("GetPersonalData: failed to collect personal data: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("personal-data-%d", userID)
+	if request.Format != "zip" {
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.json"`, filename))
+		c.JSON(http.StatusOK, data)
+		return
+	}
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.zip"`, filename))
+	c.Status(http.StatusOK)
+	if err := writePersonalDataArchive(c.Writer, data); err != nil {
+		h.logger.Errorf("GetPersonalData: archive of user with ID %d failed after streaming started: %v", userID, err)
+		c.Abort()
+	}
+}
+
+// EraseUser godoc
+// @Summary Erase the personal data of a user
+// @Description Irreversibly anonymise the passport number, name, address and workday end of a user, redact them from the audit log and delete the webhook deliveries that carried them. The tasks of the user are kept, so that the time tracked can still be accounted for. Returns the proof of erasure, which is kept even after the user is purged
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} dto.ErasureResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 409 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /users/{id}/erasure [post]
+func (h *PersonalDataHandler) EraseUser(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("EraseUser: invalid user ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	h.logger.Infof("EraseUser: erasing personal data of user with ID: %d", userID)
+	erasure, err := h.personalDataService.EraseUser(c.Request.Context(), uint(userID))
+	if err != nil {
+		h.logger.Debugf("EraseUser: failed to erase personal data: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("EraseUser: personal data of user with ID %d erased", userID)
+	c.JSON(http.StatusOK, erasure)
+}
+
+// GetErasure godoc
+// @Summary Get the proof of erasure of a user
+// @Description Get the proof that the personal data of a user was erased
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} dto.ErasureResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /users/{id}/erasure [get]
+func (h *PersonalDataHandler) GetErasure(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("GetErasure: invalid user ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	erasure, err := h.personalDataService.GetErasure(uint(userID))
+	if err != nil {
+		h.logger.Debugf("GetErasure: failed to get erasure: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, erasure)
+}
+
+// archiveFile is a JSON file of a personal data archive.
+type archiveFile struct {
+	name    string
+	content any
+}
+
+// writePersonalDataArchive writes the personal data to w as a ZIP archive with a JSON file
+// for each part of it.
+func writePersonalDataArchive(w io.Writer, data *dto.PersonalDataResponse) error {
+	files := []archiveFile{
+		{"profile.json", data.Profile},
+		{"tasks.json", data.Tasks},
+		{"audit_entries.json", data.AuditEntries},
+	}
+	if data.Erasure != nil {
+		files = append(files, archiveFile{"erasure.json", data.Erasure})
+	}
+
+	archive := zip.NewWriter(w)
+	for _, file := range files {
+		fileWriter, err := archive.Create(file.name)
+		if err != nil {
+			return err
+		}
+
+		encoder := json.NewEncoder(fileWriter)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.content); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+type ProjectHandler struct {
+	projectService services.ProjectService
+	logger         *logrus.Logger
+}
+
+func NewProjectHandler(projectService services.ProjectService, logger *logrus.Logger) *ProjectHandler {
+	return &ProjectHandler{
+		projectService: projectService,
+		logger:         logger,
+	}
+}
+
+// CreateProject godoc
+// @Summary Create a new project
+// @Description Create a new project, optionally belonging to a client
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param project body dto.CreateProjectRequest true "Create project request"
+// @Success 201 {object} dto.ProjectResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /projects [post]
+func (h *ProjectHandler) CreateProject(c *gin.Context) {
+	var request dto.CreateProjectRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Debugf("CreateProject: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("CreateProject: creating project with name: %s", request.Name)
+	project, err := h.projectService.CreateProject(request)
+	if err != nil {
+		h.logger.Debugf("CreateProject: failed to create project: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("CreateProject: project created with ID: %d", project.ID)
+	c.JSON(http.StatusCreated, project)
+}
+
+// GetProjects godoc
+// @Summary Get projects
+// @Description Get projects, optionally of one client. Archived projects are hidden unless requested
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param client_id query int false "Client ID"
+// @Param include_archived query bool false "Include archived projects"
+// @Success 200 {array} dto.ProjectResponse
+// @Failure 400 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /projects [get]
+func (h *ProjectHandler) GetProjects(c *gin.Context) {
+	var clientID *uint
+	if value, ok := c.GetQuery("client_id"); ok {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			h.logger.Debugf("GetProjects: invalid client ID: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid client ID"})
+			return
+		}
+		clientIDValue := uint(id)
+		clientID = &clientIDValue
+	}
+
+	includeArchived, err := strconv.ParseBool(c.DefaultQuery("include_archived", "false"))
+	if err != nil {
+		h.logger.Debugf("GetProjects: invalid include_archived flag: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid include_archived flag"})
+		return
+	}
+
+	h.logger.Info("GetProjects: fetching projects")
+	projects, err := h.projectService.GetProjects(clientID, includeArchived)
+	if err != nil {
+		h.logger.Debugf("GetProjects: failed to fetch projects: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("GetProjects: fetched %d projects", len(projects))
+	c.JSON(http.StatusOK, projects)
+}
+
+// GetProject godoc
+// @Summary Get a project
+// @Description Get a project by ID, including archived ones
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 200 {object} dto.ProjectResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /projects/{id} [get]
+func (h *ProjectHandler) GetProject(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("GetProject: invalid project ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	project, err := h.projectService.GetProjectById(uint(projectID))
+	if err != nil {
+		h.logger.Debugf("GetProject: failed to get project: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+// UpdateProject godoc
+// @Summary Update an existing project
+// @Description Rename, move to another client, archive or unarchive a project
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param project body dto.UpdateProjectRequest true "Update project request"
+// @Success 200 {object} dto.ProjectResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /projects/{id} [put]
+func (h *ProjectHandler) UpdateProject(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("UpdateProject: invalid project ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	var request dto.UpdateProjectRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Debugf("UpdateProject: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("UpdateProject: updating project with ID: %d", projectID)
+	project, err := h.projectService.UpdateProject(uint(projectID), request)
+	if err != nil {
+		h.logger.Debugf("UpdateProject: failed to update project: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("UpdateProject: project updated with ID: %d", projectID)
+	c.JSON(http.StatusOK, project)
+}
+
+// DeleteProject godoc
+// @Summary Delete an existing project
+// @Description Delete a project without tasks. Projects with tasks should be archived instead
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]any
+// @Failure 409 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /projects/{id} [delete]
+func (h *ProjectHandler) DeleteProject(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("DeleteProject: invalid project ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	h.logger.Infof("DeleteProject: deleting project with ID: %d", projectID)
+	if err := h.projectService.DeleteProject(uint(projectID)); err != nil {
+		h.logger.Debugf("DeleteProject: failed to delete project: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("DeleteProject: project deleted with ID: %d", projectID)
+	c.JSON(http.StatusOK, gin.H{"project": nil})
+}
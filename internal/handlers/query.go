@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// optionalIDQuery reads an optional numeric ID from the query string; it is nil when absent.
+func optionalIDQuery(c *gin.Context, name string) (*uint, error) {
+	value, ok := c.GetQuery(name)
+	if !ok {
+		return nil, nil
+	}
+
+	id, err := strconv.ParseUint(value, 10, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	idValue := uint(id)
+	return &idValue, nil
+}
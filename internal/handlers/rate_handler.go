@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+type RateHandler struct {
+	rateService services.RateService
+	logger      *logrus.Logger
+}
+
+func NewRateHandler(rateService services.RateService, logger *logrus.Logger) *RateHandler {
+	return &RateHandler{
+		rateService: rateService,
+		logger:      logger,
+	}
+}
+
+// CreateRate godoc
+// @Summary Create a new hourly rate
+// @Description Create an effective-dated hourly rate for a user, a project or a client. The most specific rate wins when billing
+// @Tags rates
+// @Accept json
+// @Produce json
+// @Param rate body dto.CreateRateRequest true "Create rate request"
+// @Success 201 {object} dto.RateResponse
+// @Failure 400 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /rates [post]
+func (h *RateHandler) CreateRate(c *gin.Context) {
+	var request dto.CreateRateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Debugf("CreateRate: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("CreateRate: creating rate effective from %s", request.EffectiveFrom)
+	rate, err := h.rateService.CreateRate(request)
+	if err != nil {
+		h.logger.Debugf("CreateRate: failed to create rate: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("CreateRate: rate created with ID: %d", rate.ID)
+	c.JSON(http.StatusCreated, rate)
+}
+
+// GetRates godoc
+// @Summary Get hourly rates
+// @Description Get hourly rates, optionally restricted to a user, project or client
+// @Tags rates
+// @Accept json
+// @Produce json
+// @Param user_id query int false "User ID"
+// @Param project_id query int false "Project ID"
+// @Param client_id query int false "Client ID"
+// @Success 200 {array} dto.RateResponse
+// @Failure 400 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /rates [get]
+func (h *RateHandler) GetRates(c *gin.Context) {
+	userID, err := optionalIDQuery(c, "user_id")
+	if err != nil {
+		h.logger.Debugf("GetRates: invalid user ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	projectID, err := optionalIDQuery(c, "project_id")
+	if err != nil {
+		h.logger.Debugf("GetRates: invalid project ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	clientID, err := optionalIDQuery(c, "client_id")
+	if err != nil {
+		h.logger.Debugf("GetRates: invalid client ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid client ID"})
+		return
+	}
+
+	h.logger.Info("GetRates: fetching rates")
+	rates, err := h.rateService.GetRates(userID, projectID, clientID)
+	if err != nil {
+		h.logger.Debugf("GetRates: failed to fetch rates: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("GetRates: fetched %d rates", len(rates))
+	c.JSON(http.StatusOK, rates)
+}
+
+// DeleteRate godoc
+// @Summary Delete an hourly rate
+// @Description Delete an hourly rate. Invoices already issued keep the rate they were priced with
+// @Tags rates
+// @Accept json
+// @Produce json
+// @Param id path int true "Rate ID"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /rates/{id} [delete]
+func (h *RateHandler) DeleteRate(c *gin.Context) {
+	rateID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("DeleteRate: invalid rate ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rate ID"})
+		return
+	}
+
+	h.logger.Infof("DeleteRate: deleting rate with ID: %d", rateID)
+	if err := h.rateService.DeleteRate(uint(rateID)); err != nil {
+		h.logger.Debugf("DeleteRate: failed to delete rate: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("DeleteRate: rate deleted with ID: %d", rateID)
+	c.JSON(http.StatusOK, gin.H{"rate": nil})
+}
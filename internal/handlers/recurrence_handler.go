@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+type RecurrenceHandler struct {
+	recurrenceService services.RecurrenceService
+	logger            *logrus.Logger
+}
+
+func NewRecurrenceHandler(recurrenceService services.RecurrenceService, logger *logrus.Logger) *RecurrenceHandler {
+	return &RecurrenceHandler{
+		recurrenceService: recurrenceService,
+		logger:            logger,
+	}
+}
+
+// CreateRecurrence godoc
+// @Summary Create a recurrence
+// @Description Schedule entries of a template by an RRULE with FREQ of DAILY, WEEKLY or MONTHLY and optional INTERVAL, BYDAY, COUNT and UNTIL (YYYYMMDD). Occurrences keep their local start time in the time zone of the user of the template. In mode planned an entry to confirm is created ahead of each occurrence, in mode actual a stopped entry is recorded once the occurrence has ended
+// @Tags recurrences
+// @Accept json
+// @Produce json
+// @Param recurrence body dto.CreateRecurrenceRequest true "Create recurrence request"
+// @Success 200 {object} dto.RecurrenceResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /recurrences [post]
+func (h *RecurrenceHandler) CreateRecurrence(c *gin.Context) {
+	var request dto.CreateRecurrenceRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Debugf("CreateRecurrence: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("CreateRecurrence: creating recurrence for template ID: %d", request.TemplateID)
+	recurrence, err := h.recurrenceService.CreateRecurrence(request)
+	if err != nil {
+		h.logger.Debugf("CreateRecurrence: failed to create recurrence: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("CreateRecurrence: recurrence created with ID: %d", recurrence.ID)
+	c.JSON(http.StatusOK, recurrence)
+}
+
+// GetRecurrences godoc
+// @Summary Get recurrences
+// @Description Get recurrences with their skipped dates, optionally of one template
+// @Tags recurrences
+// @Accept json
+// @Produce json
+// @Param template_id query int false "Template ID"
+// @Success 200 {array} dto.RecurrenceResponse
+// @Failure 400 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /recurrences [get]
+func (h *RecurrenceHandler) GetRecurrences(c *gin.Context) {
+	templateID, err := optionalIDQuery(c, "template_id")
+	if err != nil {
+		h.logger.Debugf("GetRecurrences: invalid template ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	h.logger.Info("GetRecurrences: fetching recurrences")
+	recurrences, err := h.recurrenceService.GetRecurrences(templateID)
+	if err != nil {
+		h.logger.Debugf("GetRecurrences: failed to fetch recurrences: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("GetRecurrences: fetched %d recurrences", len(recurrences))
+	c.JSON(http.StatusOK, recurrences)
+}
+
+// DeleteRecurrence godoc
+// @Summary Delete a recurrence
+// @Description Delete a recurrence together with its planned entries. Recorded entries are kept
+// @Tags recurrences
+// @Accept json
+// @Produce json
+// @Param id path int true "Recurrence ID"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /recurrences/{id} [delete]
+func (h *RecurrenceHandler) DeleteRecurrence(c *gin.Context) {
+	recurrenceID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("DeleteRecurrence: invalid recurrence ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recurrence ID"})
+		return
+	}
+
+	h.logger.Infof("DeleteRecurrence: deleting recurrence with ID: %d", recurrenceID)
+	if err := h.recurrenceService.DeleteRecurrence(uint(recurrenceID)); err != nil {
+		h.logger.Debugf("DeleteRecurrence: failed to delete recurrence: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("DeleteRecurrence: recurrence deleted with ID: %d", recurrenceID)
+	c.JSON(http.StatusOK, gin.H{"recurrence": nil})
+}
+
+// SkipOccurrence godoc
+// @Summary Skip an occurrence
+// @Description Skip the occurrence of a recurrence on a day in the time zone of the user, removing its planned entry if one has been created. An occurrence that has already been recorded or confirmed cannot be skipped
+// @Tags recurrences
+// @Accept json
+// @Produce json
+// @Param id path int true "Recurrence ID"
+// @Param skip body dto.SkipOccurrenceRequest true "Skip occurrence request"
+// @Success 200 {object} dto.RecurrenceResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 409 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /recurrences/{id}/skips [post]
+func (h *RecurrenceHandler) SkipOccurrence(c *gin.Context) {
+	recurrenceID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("SkipOccurrence: invalid recurrence ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recurrence ID"})
+		return
+	}
+
+	var request dto.SkipOccurrenceRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Debugf("SkipOccurrence: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("SkipOccurrence: skipping occurrence of recurrence ID %d on %s", recurrenceID, request.Date)
+	recurrence, err := h.recurrenceService.SkipOccurrence(uint(recurrenceID), request)
+	if err != nil {
+		h.logger.Debugf("SkipOccurrence: failed to skip occurrence: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recurrence)
+}
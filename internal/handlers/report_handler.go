@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"fmt"
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/export"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+type ReportHandler struct {
+	reportService services.ReportService
+	logger        *logrus.Logger
+}
+
+func NewReportHandler(reportService services.ReportService, logger *logrus.Logger) *ReportHandler {
+	return &ReportHandler{
+		reportService: reportService,
+		logger:        logger,
+	}
+}
+
+// GetUserSummary godoc
+// @Summary Get a summary of a user's tracked time
+// @Description Get the time a user tracked between two dates inclusive, totalled per day, week, month, task name, project or client
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param from query string true "From date (YYYY-MM-DD) in the time zone of the user"
+// @Param to query string true "To date (YYYY-MM-DD) in the time zone of the user"
+// @Param group_by query string false "Grouping" Enums(day, week, month, task_name, project, client)
+// @Success 200 {object} dto.SummaryResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /users/{id}/summary [get]
+func (h *ReportHandler) GetUserSummary(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("GetUserSummary: invalid user ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var request dto.GetUserSummaryRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		h.logger.Debugf("GetUserSummary: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("GetUserSummary: summarizing tasks for user ID: %d", userID)
+	summary, err := h.reportService.GetUserSummary(uint(userID), request)
+	if err != nil {
+		h.logger.Debugf("GetUserSummary: failed to summarize tasks: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("GetUserSummary: summarized %d groups for user ID: %d", len(summary.Groups), userID)
+	c.JSON(http.StatusOK, summary)
+}
+
+// ExportUserSummary godoc
+// @Summary Export a summary of a user's tracked time
+// @Description Export the summary of GetUserSummary as CSV or XLSX
+// @Tags users
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id path int true "User ID"
+// @Param from query string true "From date (YYYY-MM-DD) in the time zone of the user"
+// @Param to query string true "To date (YYYY-MM-DD) in the time zone of the user"
+// @Param group_by query string false "Grouping" Enums(day, week, month, task_name, project, client)
+// @Param columns query string false "Comma separated columns: key, id, task_count, seconds, hours, duration"
+// @Param format query string false "Export format, negotiated from the Accept header by default" Enums(csv, xlsx)
+// @Param locale query string false "Locale of numbers such as ru or en, taken from Accept-Language by default"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /users/{id}/summary/export [get]
+func (h *ReportHandler) ExportUserSummary(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("ExportUserSummary: invalid user ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var request dto.ExportUserSummaryRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		h.logger.Debugf("ExportUserSummary: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("ExportUserSummary: exporting summary for user ID: %d", userID)
+	streamExport(c, h.logger, fmt.Sprintf("summary-%d", userID), request.ExportOptions, func(writer export.Writer) error {
+		return h.reportService.ExportUserSummary(uint(userID), request, writer)
+	})
+}
+
+// GetPivotReport godoc
+// @Summary Get a pivot report across users
+// @Description Get the time tracked by a set of users, a team or the whole organisation between two dates inclusive, as a matrix of rows by columns with row and column totals. Rows are paged
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param user_ids query string false "Comma separated user IDs"
+// @Param team_id query int false "Team ID"
+// @Param from query string true "From date (YYYY-MM-DD) in the time zone of the user"
+// @Param to query string true "To date (YYYY-MM-DD) in the time zone of the user"
+// @Param rows query string false "Row dimension, user by default" Enums(user, day, week, month, task_name, project, client)
+// @Param columns query string false "Column dimension, day by default" Enums(user, day, week, month, task_name, project, client)
+// @Param page query int false "Page number"
+// @Param page_size query int false "Rows per page, 50 by default"
+// @Success 200 {object} dto.PivotReportResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /reports/pivot [get]
+func (h *ReportHandler) GetPivotReport(c *gin.Context) {
+	var request dto.GetPivotReportRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		h.logger.Debugf("GetPivotReport: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("GetPivotReport: building report from %s to %s", request.From, request.To)
+	report, err := h.reportService.GetPivotReport(request)
+	if err != nil {
+		h.logger.Debugf("GetPivotReport: failed to build report: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("GetPivotReport: built report with %d rows", len(report.Rows))
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Dor1ma/Time-Tracker/internal/audit"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// RequestIDHeader carries the ID of a request. A valid ID sent by the client is kept,
+	// otherwise one is generated; either way it is sent back in the response.
+	RequestIDHeader = "X-Request-ID"
+	// ActorIDHeader carries the ID of the user making a request, recorded in the audit log.
+	ActorIDHeader = "X-Actor-ID"
+
+	maxRequestIDLength = 64
+)
+
+// RequestContext puts the actor and ID of every request into its context, from where the
+// services record them in the audit log. A request that sends adminToken as its bearer token
+// is authenticated as made by an admin; one that sends another token is rejected.
+func RequestContext(adminToken string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var actor audit.Actor
+		if value := c.GetHeader("Authorization"); value != "" {
+			if !validAdminToken(value, adminToken) {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid admin token"})
+				return
+			}
+			actor.Admin = true
+		}
+
+		if value := c.GetHeader(ActorIDHeader); value != "" {
+			actorID, err := strconv.ParseUint(value, 10, 0)
+			if err != nil || actorID == 0 {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid actor ID"})
+				return
+			}
+			id := uint(actorID)
+			actor.UserID = &id
+		}
+
+		actor.RequestID = c.GetHeader(RequestIDHeader)
+		if !validRequestID(actor.RequestID) {
+			actor.RequestID = newRequestID()
+		}
+
+		c.Header(RequestIDHeader, actor.RequestID)
+		c.Request = c.Request.WithContext(audit.NewContext(c.Request.Context(), actor))
+		c.Next()
+	}
+}
+
+// validAdminToken reports whether the Authorization header carries adminToken as its bearer
+// token. The tokens are compared by their hashes in constant time, so that neither their
+// contents nor their lengths can be told from how long the comparison takes.
+func validAdminToken(header, adminToken string) bool {
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || adminToken == "" {
+		return false
+	}
+
+	got, want := sha256.Sum256([]byte(token)), sha256.Sum256([]byte(adminToken))
+	return subtle.ConstantTimeCompare(got[:], want[:]) == 1
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newRequestID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
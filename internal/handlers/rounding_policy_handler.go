@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+type RoundingPolicyHandler struct {
+	roundingPolicyService services.RoundingPolicyService
+	logger                *logrus.Logger
+}
+
+func NewRoundingPolicyHandler(roundingPolicyService services.RoundingPolicyService, logger *logrus.Logger) *RoundingPolicyHandler {
+	return &RoundingPolicyHandler{
+		roundingPolicyService: roundingPolicyService,
+		logger:                logger,
+	}
+}
+
+// SetRoundingPolicy godoc
+// @Summary Set a rounding policy
+// @Description Set how reported durations of a project, of a client, or of everything else are rounded, replacing the policy previously set for the same scope. Recorded task times are never changed
+// @Tags rounding-policies
+// @Accept json
+// @Produce json
+// @Param policy body dto.SetRoundingPolicyRequest true "Set rounding policy request"
+// @Success 200 {object} dto.RoundingPolicyResponse
+// @Failure 400 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /rounding-policies [post]
+func (h *RoundingPolicyHandler) SetRoundingPolicy(c *gin.Context) {
+	var request dto.SetRoundingPolicyRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Debugf("SetRoundingPolicy: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("SetRoundingPolicy: setting rounding policy to %d minutes %s", request.IncrementMinutes, request.Mode)
+	policy, err := h.roundingPolicyService.SetRoundingPolicy(request)
+	if err != nil {
+		h.logger.Debugf("SetRoundingPolicy: failed to set rounding policy: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("SetRoundingPolicy: rounding policy set with ID: %d", policy.ID)
+	c.JSON(http.StatusOK, policy)
+}
+
+// GetRoundingPolicies godoc
+// @Summary Get rounding policies
+// @Description Get the global rounding policy and the ones of projects and clients
+// @Tags rounding-policies
+// @Accept json
+// @Produce json
+// @Success 200 {array} dto.RoundingPolicyResponse
+// @Failure 500 {object} map[string]any
+// @Router /rounding-policies [get]
+func (h *RoundingPolicyHandler) GetRoundingPolicies(c *gin.Context) {
+	h.logger.Info("GetRoundingPolicies: fetching rounding policies")
+	policies, err := h.roundingPolicyService.GetRoundingPolicies()
+	if err != nil {
+		h.logger.Debugf("GetRoundingPolicies: failed to fetch rounding policies: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("GetRoundingPolicies: fetched %d rounding policies", len(policies))
+	c.JSON(http.StatusOK, policies)
+}
+
+// DeleteRoundingPolicy godoc
+// @Summary Delete a rounding policy
+// @Description Delete a rounding policy. Invoices already issued keep the durations they were billed with
+// @Tags rounding-policies
+// @Accept json
+// @Produce json
+// @Param id path int true "Rounding policy ID"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /rounding-policies/{id} [delete]
+func (h *RoundingPolicyHandler) DeleteRoundingPolicy(c *gin.Context) {
+	policyID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("DeleteRoundingPolicy: invalid rounding policy ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rounding policy ID"})
+		return
+	}
+
+	h.logger.Infof("DeleteRoundingPolicy: deleting rounding policy with ID: %d", policyID)
+	if err := h.roundingPolicyService.DeleteRoundingPolicy(uint(policyID)); err != nil {
+		h.logger.Debugf("DeleteRoundingPolicy: failed to delete rounding policy: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("DeleteRoundingPolicy: rounding policy deleted with ID: %d", policyID)
+	c.JSON(http.StatusOK, gin.H{"rounding_policy": nil})
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+type TagHandler struct {
+	tagService services.TagService
+	logger     *logrus.Logger
+}
+
+func NewTagHandler(tagService services.TagService, logger *logrus.Logger) *TagHandler {
+	return &TagHandler{
+		tagService: tagService,
+		logger:     logger,
+	}
+}
+
+// CreateTag godoc
+// @Summary Create a new tag
+// @Description Create a new tag. Tag names are stored in lower case
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param tag body dto.CreateTagRequest true "Create tag request"
+// @Success 201 {object} dto.TagResponse
+// @Failure 400 {object} map[string]any
+// @Failure 409 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /tags [post]
+func (h *TagHandler) CreateTag(c *gin.Context) {
+	var request dto.CreateTagRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Debugf("CreateTag: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("CreateTag: creating tag with name: %s", request.Name)
+	tag, err := h.tagService.CreateTag(request)
+	if err != nil {
+		h.logger.Debugf("CreateTag: failed to create tag: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("CreateTag: tag created with ID: %d", tag.ID)
+	c.JSON(http.StatusCreated, tag)
+}
+
+// GetTags godoc
+// @Summary Get all tags
+// @Description Get all tags ordered by name
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Success 200 {array} dto.TagResponse
+// @Failure 500 {object} map[string]any
+// @Router /tags [get]
+func (h *TagHandler) GetTags(c *gin.Context) {
+	h.logger.Info("GetTags: fetching all tags")
+	tags, err := h.tagService.GetAllTags()
+	if err != nil {
+		h.logger.Debugf("GetTags: failed to fetch tags: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("GetTags: fetched %d tags", len(tags))
+	c.JSON(http.StatusOK, tags)
+}
+
+// RenameTag godoc
+// @Summary Rename a tag
+// @Description Rename a tag. Tasks keep the tag under its new name
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Param tag body dto.UpdateTagRequest true "Rename tag request"
+// @Success 200 {object} dto.TagResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 409 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /tags/{id} [put]
+func (h *TagHandler) RenameTag(c *gin.Context) {
+	tagID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("RenameTag: invalid tag ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag ID"})
+		return
+	}
+
+	var request dto.UpdateTagRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Debugf("RenameTag: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("RenameTag: renaming tag with ID: %d", tagID)
+	tag, err := h.tagService.RenameTag(uint(tagID), request)
+	if err != nil {
+		h.logger.Debugf("RenameTag: failed to rename tag: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("RenameTag: tag renamed with ID: %d", tagID)
+	c.JSON(http.StatusOK, tag)
+}
+
+// DeleteTag godoc
+// @Summary Delete a tag
+// @Description Delete a tag and remove it from all tasks
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /tags/{id} [delete]
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	tagID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("DeleteTag: invalid tag ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag ID"})
+		return
+	}
+
+	h.logger.Infof("DeleteTag: deleting tag with ID: %d", tagID)
+	if err := h.tagService.DeleteTag(uint(tagID)); err != nil {
+		h.logger.Debugf("DeleteTag: failed to delete tag: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("DeleteTag: tag deleted with ID: %d", tagID)
+	c.JSON(http.StatusOK, gin.H{"tag": nil})
+}
+
+// MergeTags godoc
+// @Summary Merge two tags
+// @Description Move all tasks of the tag to the target tag and delete the tag
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path int true "ID of the tag to merge away"
+// @Param merge body dto.MergeTagsRequest true "Merge tags request"
+// @Success 200 {object} dto.TagResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /tags/{id}/merge [post]
+func (h *TagHandler) MergeTags(c *gin.Context) {
+	tagID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("MergeTags: invalid tag ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag ID"})
+		return
+	}
+
+	var request dto.MergeTagsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Debugf("MergeTags: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("MergeTags: merging tag with ID %d into tag with ID %d", tagID, request.TargetID)
+	tag, err := h.tagService.MergeTags(uint(tagID), request)
+	if err != nil {
+		h.logger.Debugf("MergeTags: failed to merge tags: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("MergeTags: tag with ID %d merged into tag with ID %d", tagID, tag.ID)
+	c.JSON(http.StatusOK, tag)
+}
@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"fmt"
 	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/export"
 	"github.com/Dor1ma/Time-Tracker/internal/services"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
@@ -23,13 +25,14 @@ func NewTaskHandler(taskService services.TaskService, logger *logrus.Logger) *Ta
 
 // StartTask godoc
 // @Summary Start a new task
-// @Description Start a new task for a user
+// @Description Start a new task for a user. With focus_session set, the task runs as a pomodoro: work intervals end automatically and pause the task for a break, the next one starts after the break, and the task is stopped after the last cycle. Only work intervals count toward the duration. Pausing or stopping the task by hand ends the session, resuming it during a break starts the next work interval right away
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Param task body dto.StartTaskRequest true "Start task request"
-// @Success 200 {object} models.Task
+// @Success 200 {object} dto.TaskResponse
 // @Failure 400 {object} map[string]any
+// @Failure 409 {object} map[string]any
 // @Failure 500 {object} map[string]any
 // @Router /tasks/start [post]
 func (h *TaskHandler) StartTask(c *gin.Context) {
@@ -41,10 +44,10 @@ func (h *TaskHandler) StartTask(c *gin.Context) {
 	}
 
 	h.logger.Infof("StartTask: received request to start task for user ID: %d, task name: %s", request.UserID, request.TaskName)
-	task, err := h.taskService.StartTask(request)
+	task, err := h.taskService.StartTask(c.Request.Context(), request)
 	if err != nil {
 		h.logger.Debugf("StartTask: failed to start task: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -52,6 +55,72 @@ func (h *TaskHandler) StartTask(c *gin.Context) {
 	c.JSON(http.StatusOK, task)
 }
 
+// PauseTask godoc
+// @Summary Pause a running task
+// @Description Close the current time segment of a running task
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Success 200 {object} dto.TaskResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 409 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /tasks/{id}/pause [post]
+func (h *TaskHandler) PauseTask(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("PauseTask: invalid task ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	h.logger.Infof("PauseTask: received request to pause task with ID: %d", taskID)
+	task, err := h.taskService.PauseTask(c.Request.Context(), uint(taskID))
+	if err != nil {
+		h.logger.Debugf("PauseTask: failed to pause task: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("PauseTask: successfully paused task with ID: %d", task.ID)
+	c.JSON(http.StatusOK, task)
+}
+
+// ResumeTask godoc
+// @Summary Resume a paused task
+// @Description Open a new time segment for a paused task
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Success 200 {object} dto.TaskResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 409 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /tasks/{id}/resume [post]
+func (h *TaskHandler) ResumeTask(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("ResumeTask: invalid task ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	h.logger.Infof("ResumeTask: received request to resume task with ID: %d", taskID)
+	task, err := h.taskService.ResumeTask(c.Request.Context(), uint(taskID))
+	if err != nil {
+		h.logger.Debugf("ResumeTask: failed to resume task: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("ResumeTask: successfully resumed task with ID: %d", task.ID)
+	c.JSON(http.StatusOK, task)
+}
+
 // StopTask godoc
 // @Summary Stop an existing task
 // @Description Stop an existing task for a user
@@ -59,8 +128,10 @@ func (h *TaskHandler) StartTask(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param task body dto.StopTaskRequest true "Stop task request"
-// @Success 200 {object} models.Task
+// @Success 200 {object} dto.TaskResponse
 // @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 409 {object} map[string]any
 // @Failure 500 {object} map[string]any
 // @Router /tasks/stop [post]
 func (h *TaskHandler) StopTask(c *gin.Context) {
@@ -72,10 +143,10 @@ func (h *TaskHandler) StopTask(c *gin.Context) {
 	}
 
 	h.logger.Infof("StopTask: received request to stop task with ID: %d", request.TaskID)
-	task, err := h.taskService.StopTask(request)
+	task, err := h.taskService.StopTask(c.Request.Context(), request)
 	if err != nil {
 		h.logger.Debugf("StopTask: failed to stop task: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -83,6 +154,144 @@ func (h *TaskHandler) StopTask(c *gin.Context) {
 	c.JSON(http.StatusOK, task)
 }
 
+// ConfirmTask godoc
+// @Summary Confirm a planned task
+// @Description Record the planned interval of a task created ahead of time by a recurrence, as if it had been logged by hand
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Success 200 {object} dto.TaskResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 409 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /tasks/{id}/confirm [post]
+func (h *TaskHandler) ConfirmTask(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("ConfirmTask: invalid task ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	h.logger.Infof("ConfirmTask: received request to confirm task with ID: %d", taskID)
+	task, err := h.taskService.ConfirmTask(c.Request.Context(), uint(taskID))
+	if err != nil {
+		h.logger.Debugf("ConfirmTask: failed to confirm task: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("ConfirmTask: successfully confirmed task with ID: %d", task.ID)
+	c.JSON(http.StatusOK, task)
+}
+
+// CreateTask godoc
+// @Summary Log a task manually
+// @Description Create a finished task with an explicit start and either an end time or a duration
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param task body dto.CreateTaskRequest true "Create task request"
+// @Success 201 {object} dto.TaskResponse
+// @Failure 400 {object} map[string]any
+// @Failure 409 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /tasks [post]
+func (h *TaskHandler) CreateTask(c *gin.Context) {
+	var request dto.CreateTaskRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Debugf("CreateTask: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("CreateTask: received request to create task for user ID: %d, task name: %s", request.UserID, request.TaskName)
+	task, err := h.taskService.CreateTask(c.Request.Context(), request)
+	if err != nil {
+		h.logger.Debugf("CreateTask: failed to create task: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("CreateTask: successfully created task with ID: %d", task.ID)
+	c.JSON(http.StatusCreated, task)
+}
+
+// UpdateTask godoc
+// @Summary Correct an existing task
+// @Description Change the name or the interval of a task. The interval can only be changed on stopped tasks
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param task body dto.UpdateTaskRequest true "Update task request"
+// @Success 200 {object} dto.TaskResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 409 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /tasks/{id} [patch]
+func (h *TaskHandler) UpdateTask(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("UpdateTask: invalid task ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var request dto.UpdateTaskRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Debugf("UpdateTask: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("UpdateTask: received request to update task with ID: %d", taskID)
+	task, err := h.taskService.UpdateTask(c.Request.Context(), uint(taskID), request)
+	if err != nil {
+		h.logger.Debugf("UpdateTask: failed to update task: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("UpdateTask: successfully updated task with ID: %d", task.ID)
+	c.JSON(http.StatusOK, task)
+}
+
+// GetActiveTask godoc
+// @Summary Get the running task of a user
+// @Description Get the task whose timer is currently running for a user
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Success 200 {object} dto.TaskResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /tasks/user/{user_id}/active [get]
+func (h *TaskHandler) GetActiveTask(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		h.logger.Debugf("GetActiveTask: invalid user ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	h.logger.Infof("GetActiveTask: received request to fetch running task for user ID: %d", userID)
+	task, err := h.taskService.GetActiveTask(uint(userID))
+	if err != nil {
+		h.logger.Debugf("GetActiveTask: failed to fetch running task: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("GetActiveTask: successfully fetched running task with ID: %d", task.ID)
+	c.JSON(http.StatusOK, task)
+}
+
 // GetUserTasks godoc
 // @Summary Get user tasks
 // @Description Get tasks for a user within a specified date range, sorted by total time spent
@@ -90,9 +299,15 @@ func (h *TaskHandler) StopTask(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param user_id path int true "User ID"
-// @Param start_date query string true "Start date in format YYYY-MM-DD"
-// @Param end_date query string true "End date in format YYYY-MM-DD"
-// @Success 200 {array} models.Task
+// @Param start_date query string true "Start date in format YYYY-MM-DD, in the time zone of the user"
+// @Param end_date query string true "End date in format YYYY-MM-DD, in the time zone of the user"
+// @Param segments query bool false "Include the breakdown of time segments"
+// @Param project_id query int false "Only tasks of this project"
+// @Param client_id query int false "Only tasks of projects of this client"
+// @Param tags query string false "Comma separated tag names"
+// @Param tags_match query string false "Whether tasks need any or all of the tags" Enums(any, all)
+// @Param auto_stopped query bool false "Only tasks stopped by the sweeper, or only the other ones"
+// @Success 200 {array} dto.TaskResponse
 // @Failure 400 {object} map[string]any
 // @Failure 500 {object} map[string]any
 // @Router /users/{user_id}/tasks [get]
@@ -104,11 +319,15 @@ func (h *TaskHandler) GetUserTasks(c *gin.Context) {
 		return
 	}
 
-	startDate := c.Query("start_date")
-	endDate := c.Query("end_date")
+	var request dto.GetUserTasksRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		h.logger.Debugf("GetUserTasks: invalid query: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	h.logger.Infof("GetUserTasks: received request to fetch tasks for user ID: %d", userID)
-	tasks, err := h.taskService.GetUserTasks(uint(userID), startDate, endDate)
+	tasks, err := h.taskService.GetUserTasks(uint(userID), request)
 	if err != nil {
 		h.logger.Debugf("GetUserTasks: failed to fetch tasks: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -118,3 +337,45 @@ func (h *TaskHandler) GetUserTasks(c *gin.Context) {
 	h.logger.Infof("GetUserTasks: successfully fetched %d tasks for user ID: %d", len(tasks), userID)
 	c.JSON(http.StatusOK, tasks)
 }
+
+// ExportUserTasks godoc
+// @Summary Export user tasks
+// @Description Export the tasks of a user within a date range as CSV or XLSX, streamed row by row. Takes the filters of GetUserTasks
+// @Tags tasks
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param user_id path int true "User ID"
+// @Param start_date query string true "Start date in format YYYY-MM-DD, in the time zone of the user"
+// @Param end_date query string true "End date in format YYYY-MM-DD, in the time zone of the user"
+// @Param project_id query int false "Only tasks of this project"
+// @Param client_id query int false "Only tasks of projects of this client"
+// @Param tags query string false "Comma separated tag names"
+// @Param tags_match query string false "Whether tasks need any or all of the tags" Enums(any, all)
+// @Param auto_stopped query bool false "Only tasks stopped by the sweeper, or only the other ones"
+// @Param columns query string false "Comma separated columns: id, user_id, task_name, project_id, tags, billable, status, start_time, end_time, minutes, hours, duration"
+// @Param format query string false "Export format, negotiated from the Accept header by default" Enums(csv, xlsx)
+// @Param locale query string false "Locale of numbers such as ru or en, taken from Accept-Language by default"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /tasks/user/{user_id}/export [get]
+func (h *TaskHandler) ExportUserTasks(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		h.logger.Debugf("ExportUserTasks: invalid user ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var request dto.ExportUserTasksRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		h.logger.Debugf("ExportUserTasks: invalid query: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("ExportUserTasks: received request to export tasks for user ID: %d", userID)
+	streamExport(c, h.logger, fmt.Sprintf("tasks-%d", userID), request.ExportOptions, func(writer export.Writer) error {
+		return h.taskService.ExportUserTasks(uint(userID), request, writer)
+	})
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// streamRetry is how long clients wait before reconnecting, in milliseconds.
+const streamRetry = 3000
+
+type TaskStreamHandler struct {
+	taskStreamService services.TaskStreamService
+	heartbeat         time.Duration
+	logger            *logrus.Logger
+}
+
+// NewTaskStreamHandler returns a handler that writes a heartbeat comment to idle streams
+// every heartbeat, so that proxies keep them open and clients notice dead connections.
+func NewTaskStreamHandler(taskStreamService services.TaskStreamService, heartbeat time.Duration, logger *logrus.Logger) *TaskStreamHandler {
+	return &TaskStreamHandler{
+		taskStreamService: taskStreamService,
+		heartbeat:         heartbeat,
+		logger:            logger,
+	}
+}
+
+// StreamTasks godoc
+// @Summary Stream task events
+// @Description Server-Sent Events stream of the task.created, task.updated, task.confirmed, task.started, task.paused, task.resumed and task.stopped events of a set of users, from every API instance. Each event has its ID, its type as the event name and a dto.TaskStreamEvent as data. A comment is sent as a heartbeat while the stream is idle. After reconnecting, the events missed since the Last-Event-ID header or the last_event_id query are sent first
+// @Tags tasks
+// @Produce text/event-stream
+// @Param user_ids query []string true "User IDs, repeated or comma separated" collectionFormat(multi)
+// @Param last_event_id query int false "ID of the last event received"
+// @Param Last-Event-ID header int false "ID of the last event received, sent by browsers when they reconnect"
+// @Success 200 {object} dto.TaskStreamEvent
+// @Failure 400 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /tasks/stream [get]
+func (h *TaskStreamHandler) StreamTasks(c *gin.Context) {
+	var request dto.TaskStreamRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		h.logger.Debugf("StreamTasks: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if header := c.GetHeader("Last-Event-ID"); header != "" {
+		lastEventID, err := strconv.ParseUint(header, 10, 64)
+		if err != nil {
+			h.logger.Debugf("StreamTasks: invalid last event ID: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid last event ID"})
+			return
+		}
+		request.LastEventID = lastEventID
+	}
+
+	taskStream, err := h.taskStreamService.Subscribe(request)
+	if err != nil {
+		h.logger.Debugf("StreamTasks: failed to subscribe: %v", err)
+		respondError(c, err)
+		return
+	}
+	defer taskStream.Close()
+
+	h.logger.Infof("StreamTasks: streaming task events of users %v, %d missed", request.UserIDs, len(taskStream.Backlog))
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	if _, err := fmt.Fprintf(c.Writer, "retry: %d\n\n", streamRetry); err != nil {
+		return
+	}
+
+	// Live events may repeat the backlog and do not always come in ID order, so they are
+	// checked against the IDs of the backlog.
+	sent := make(map[uint64]bool, len(taskStream.Backlog))
+	for _, event := range taskStream.Backlog {
+		if err := writeStreamEvent(c.Writer, event); err != nil {
+			return
+		}
+		sent[event.ID] = true
+	}
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			h.logger.Infof("StreamTasks: client of users %v disconnected", request.UserIDs)
+			return
+		case event, ok := <-taskStream.Events():
+			if !ok {
+				h.logger.Debugf("StreamTasks: client of users %v fell behind, closing stream", request.UserIDs)
+				return
+			}
+			if sent[event.ID] {
+				continue
+			}
+			if err := writeStreamEvent(c.Writer, event); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case <-ticker.C:
+			if _, err := io.WriteString(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+func writeStreamEvent(w io.Writer, event dto.TaskStreamEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Event, data)
+	return err
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+type TaskTemplateHandler struct {
+	templateService services.TaskTemplateService
+	logger          *logrus.Logger
+}
+
+func NewTaskTemplateHandler(templateService services.TaskTemplateService, logger *logrus.Logger) *TaskTemplateHandler {
+	return &TaskTemplateHandler{
+		templateService: templateService,
+		logger:          logger,
+	}
+}
+
+// CreateTemplate godoc
+// @Summary Create a task template
+// @Description Create a template of a task that is tracked again and again, with its project, tags, billable flag and the duration of the entries created by its recurrences
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param template body dto.CreateTaskTemplateRequest true "Create task template request"
+// @Success 200 {object} dto.TaskTemplateResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /templates [post]
+func (h *TaskTemplateHandler) CreateTemplate(c *gin.Context) {
+	var request dto.CreateTaskTemplateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Debugf("CreateTemplate: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("CreateTemplate: creating template %s for user ID: %d", request.TaskName, request.UserID)
+	template, err := h.templateService.CreateTemplate(request)
+	if err != nil {
+		h.logger.Debugf("CreateTemplate: failed to create template: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("CreateTemplate: template created with ID: %d", template.ID)
+	c.JSON(http.StatusOK, template)
+}
+
+// GetTemplates godoc
+// @Summary Get task templates
+// @Description Get task templates, optionally of one user
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param user_id query int false "User ID"
+// @Success 200 {array} dto.TaskTemplateResponse
+// @Failure 400 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /templates [get]
+func (h *TaskTemplateHandler) GetTemplates(c *gin.Context) {
+	userID, err := optionalIDQuery(c, "user_id")
+	if err != nil {
+		h.logger.Debugf("GetTemplates: invalid user ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	h.logger.Info("GetTemplates: fetching templates")
+	templates, err := h.templateService.GetTemplates(userID)
+	if err != nil {
+		h.logger.Debugf("GetTemplates: failed to fetch templates: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("GetTemplates: fetched %d templates", len(templates))
+	c.JSON(http.StatusOK, templates)
+}
+
+// GetTemplate godoc
+// @Summary Get a task template
+// @Description Get a task template by ID
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param id path int true "Template ID"
+// @Success 200 {object} dto.TaskTemplateResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /templates/{id} [get]
+func (h *TaskTemplateHandler) GetTemplate(c *gin.Context) {
+	templateID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("GetTemplate: invalid template ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	template, err := h.templateService.GetTemplate(uint(templateID))
+	if err != nil {
+		h.logger.Debugf("GetTemplate: failed to get template: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// UpdateTemplate godoc
+// @Summary Update a task template
+// @Description Replace the fields of a task template. Entries already created from it are not changed
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param id path int true "Template ID"
+// @Param template body dto.UpdateTaskTemplateRequest true "Update task template request"
+// @Success 200 {object} dto.TaskTemplateResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /templates/{id} [put]
+func (h *TaskTemplateHandler) UpdateTemplate(c *gin.Context) {
+	templateID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("UpdateTemplate: invalid template ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	var request dto.UpdateTaskTemplateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Debugf("UpdateTemplate: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("UpdateTemplate: updating template with ID: %d", templateID)
+	template, err := h.templateService.UpdateTemplate(uint(templateID), request)
+	if err != nil {
+		h.logger.Debugf("UpdateTemplate: failed to update template: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("UpdateTemplate: template updated with ID: %d", template.ID)
+	c.JSON(http.StatusOK, template)
+}
+
+// DeleteTemplate godoc
+// @Summary Delete a task template
+// @Description Delete a task template together with its recurrences and their planned entries. Recorded entries are kept
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param id path int true "Template ID"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /templates/{id} [delete]
+func (h *TaskTemplateHandler) DeleteTemplate(c *gin.Context) {
+	templateID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("DeleteTemplate: invalid template ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	h.logger.Infof("DeleteTemplate: deleting template with ID: %d", templateID)
+	if err := h.templateService.DeleteTemplate(uint(templateID)); err != nil {
+		h.logger.Debugf("DeleteTemplate: failed to delete template: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("DeleteTemplate: template deleted with ID: %d", templateID)
+	c.JSON(http.StatusOK, gin.H{"template": nil})
+}
+
+// StartTemplate godoc
+// @Summary Start a timer from a template
+// @Description Start a timer for the user of the template with its task name, project, tags and billable flag
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param id path int true "Template ID"
+// @Success 200 {object} dto.TaskResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 409 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /templates/{id}/start [post]
+func (h *TaskTemplateHandler) StartTemplate(c *gin.Context) {
+	templateID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("StartTemplate: invalid template ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	h.logger.Infof("StartTemplate: starting timer from template with ID: %d", templateID)
+	task, err := h.templateService.StartTemplate(c.Request.Context(), uint(templateID))
+	if err != nil {
+		h.logger.Debugf("StartTemplate: failed to start timer: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("StartTemplate: task started with ID: %d", task.ID)
+	c.JSON(http.StatusOK, task)
+}
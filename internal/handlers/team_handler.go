@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+type TeamHandler struct {
+	teamService services.TeamService
+	logger      *logrus.Logger
+}
+
+func NewTeamHandler(teamService services.TeamService, logger *logrus.Logger) *TeamHandler {
+	return &TeamHandler{
+		teamService: teamService,
+		logger:      logger,
+	}
+}
+
+// CreateTeam godoc
+// @Summary Create a new team
+// @Description Create a new team of users that reports can be built for
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param team body dto.CreateTeamRequest true "Create team request"
+// @Success 201 {object} dto.TeamResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /teams [post]
+func (h *TeamHandler) CreateTeam(c *gin.Context) {
+	var request dto.CreateTeamRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Debugf("CreateTeam: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("CreateTeam: creating team with name: %s", request.Name)
+	team, err := h.teamService.CreateTeam(request)
+	if err != nil {
+		h.logger.Debugf("CreateTeam: failed to create team: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("CreateTeam: team created with ID: %d", team.ID)
+	c.JSON(http.StatusCreated, team)
+}
+
+// GetTeams godoc
+// @Summary Get all teams
+// @Description Get all teams with their members, ordered by name
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Success 200 {array} dto.TeamResponse
+// @Failure 500 {object} map[string]any
+// @Router /teams [get]
+func (h *TeamHandler) GetTeams(c *gin.Context) {
+	h.logger.Info("GetTeams: fetching all teams")
+	teams, err := h.teamService.GetTeams()
+	if err != nil {
+		h.logger.Debugf("GetTeams: failed to fetch teams: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("GetTeams: fetched %d teams", len(teams))
+	c.JSON(http.StatusOK, teams)
+}
+
+// GetTeam godoc
+// @Summary Get a team
+// @Description Get a team by ID with its members
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param id path int true "Team ID"
+// @Success 200 {object} dto.TeamResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /teams/{id} [get]
+func (h *TeamHandler) GetTeam(c *gin.Context) {
+	teamID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("GetTeam: invalid team ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+
+	team, err := h.teamService.GetTeamById(uint(teamID))
+	if err != nil {
+		h.logger.Debugf("GetTeam: failed to get team: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, team)
+}
+
+// UpdateTeam godoc
+// @Summary Update an existing team
+// @Description Rename a team and replace its members
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param id path int true "Team ID"
+// @Param team body dto.UpdateTeamRequest true "Update team request"
+// @Success 200 {object} dto.TeamResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /teams/{id} [put]
+func (h *TeamHandler) UpdateTeam(c *gin.Context) {
+	teamID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("UpdateTeam: invalid team ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+
+	var request dto.UpdateTeamRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Debugf("UpdateTeam: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("UpdateTeam: updating team with ID: %d", teamID)
+	team, err := h.teamService.UpdateTeam(uint(teamID), request)
+	if err != nil {
+		h.logger.Debugf("UpdateTeam: failed to update team: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("UpdateTeam: team updated with ID: %d", teamID)
+	c.JSON(http.StatusOK, team)
+}
+
+// DeleteTeam godoc
+// @Summary Delete an existing team
+// @Description Delete a team. Its members and their tasks are kept
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param id path int true "Team ID"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /teams/{id} [delete]
+func (h *TeamHandler) DeleteTeam(c *gin.Context) {
+	teamID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("DeleteTeam: invalid team ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+
+	h.logger.Infof("DeleteTeam: deleting team with ID: %d", teamID)
+	if err := h.teamService.DeleteTeam(uint(teamID)); err != nil {
+		h.logger.Debugf("DeleteTeam: failed to delete team: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("DeleteTeam: team deleted with ID: %d", teamID)
+	c.JSON(http.StatusOK, gin.H{"team": nil})
+}
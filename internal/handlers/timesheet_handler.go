@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+type TimesheetHandler struct {
+	timesheetService services.TimesheetService
+	logger           *logrus.Logger
+}
+
+func NewTimesheetHandler(timesheetService services.TimesheetService, logger *logrus.Logger) *TimesheetHandler {
+	return &TimesheetHandler{
+		timesheetService: timesheetService,
+		logger:           logger,
+	}
+}
+
+// CreateTimesheet godoc
+// @Summary Create a timesheet
+// @Description Open a draft timesheet of a user for the week (Monday to Sunday) that the given day falls in
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param timesheet body dto.CreateTimesheetRequest true "Create timesheet request"
+// @Success 201 {object} dto.TimesheetResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 409 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /timesheets [post]
+func (h *TimesheetHandler) CreateTimesheet(c *gin.Context) {
+	var request dto.CreateTimesheetRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Debugf("CreateTimesheet: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("CreateTimesheet: creating timesheet for user ID: %d", request.UserID)
+	timesheet, err := h.timesheetService.CreateTimesheet(request)
+	if err != nil {
+		h.logger.Debugf("CreateTimesheet: failed to create timesheet: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("CreateTimesheet: timesheet created with ID: %d", timesheet.ID)
+	c.JSON(http.StatusCreated, timesheet)
+}
+
+// GetTimesheets godoc
+// @Summary Get timesheets
+// @Description Get timesheets, latest week first, optionally of one user or in one status
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param user_id query int false "User ID"
+// @Param status query string false "Timesheet status" Enums(draft, submitted, approved, rejected)
+// @Success 200 {array} dto.TimesheetResponse
+// @Failure 400 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /timesheets [get]
+func (h *TimesheetHandler) GetTimesheets(c *gin.Context) {
+	var request dto.GetTimesheetsRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		h.logger.Debugf("GetTimesheets: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("GetTimesheets: fetching timesheets")
+	timesheets, err := h.timesheetService.GetTimesheets(request)
+	if err != nil {
+		h.logger.Debugf("GetTimesheets: failed to fetch timesheets: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("GetTimesheets: fetched %d timesheets", len(timesheets))
+	c.JSON(http.StatusOK, timesheets)
+}
+
+// GetTimesheet godoc
+// @Summary Get a timesheet
+// @Description Get a timesheet by ID with the history of its transitions
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param id path int true "Timesheet ID"
+// @Success 200 {object} dto.TimesheetResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /timesheets/{id} [get]
+func (h *TimesheetHandler) GetTimesheet(c *gin.Context) {
+	timesheetID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("GetTimesheet: invalid timesheet ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid timesheet ID"})
+		return
+	}
+
+	timesheet, err := h.timesheetService.GetTimesheet(uint(timesheetID))
+	if err != nil {
+		h.logger.Debugf("GetTimesheet: failed to get timesheet: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, timesheet)
+}
+
+// SubmitTimesheet godoc
+// @Summary Submit a timesheet
+// @Description Submit a draft or rejected timesheet for review. The tasks of its week can no longer be started, stopped or edited
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param id path int true "Timesheet ID"
+// @Success 200 {object} dto.TimesheetResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 409 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /timesheets/{id}/submit [post]
+func (h *TimesheetHandler) SubmitTimesheet(c *gin.Context) {
+	timesheetID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("SubmitTimesheet: invalid timesheet ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid timesheet ID"})
+		return
+	}
+
+	h.logger.Infof("SubmitTimesheet: submitting timesheet with ID: %d", timesheetID)
+	timesheet, err := h.timesheetService.SubmitTimesheet(uint(timesheetID))
+	if err != nil {
+		h.logger.Debugf("SubmitTimesheet: failed to submit timesheet: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("SubmitTimesheet: timesheet submitted with ID: %d", timesheetID)
+	c.JSON(http.StatusOK, timesheet)
+}
+
+// ApproveTimesheet godoc
+// @Summary Approve a timesheet
+// @Description Approve a submitted timesheet on behalf of the reviewer named in the X-Actor-ID header. Only requests authenticated as an admin's, by the admin token as their bearer token, can approve, for reviewers who are managers or admins and not for their own timesheets
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param id path int true "Timesheet ID"
+// @Param review body dto.ReviewTimesheetRequest true "Review timesheet request"
+// @Success 200 {object} dto.TimesheetResponse
+// @Failure 400 {object} map[string]any
+// @Failure 401 {object} map[string]any
+// @Failure 403 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 409 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /timesheets/{id}/approve [post]
+func (h *TimesheetHandler) ApproveTimesheet(c *gin.Context) {
+	h.review(c, "ApproveTimesheet", h.timesheetService.ApproveTimesheet)
+}
+
+// RejectTimesheet godoc
+// @Summary Reject a timesheet
+// @Description Reject a submitted timesheet with a comment, unlocking the tasks of its week, on behalf of the reviewer named in the X-Actor-ID header. Only requests authenticated as an admin's, by the admin token as their bearer token, can reject, for reviewers who are managers or admins and not for their own timesheets
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param id path int true "Timesheet ID"
+// @Param review body dto.ReviewTimesheetRequest true "Review timesheet request"
+// @Success 200 {object} dto.TimesheetResponse
+// @Failure 400 {object} map[string]any
+// @Failure 401 {object} map[string]any
+// @Failure 403 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 409 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /timesheets/{id}/reject [post]
+func (h *TimesheetHandler) RejectTimesheet(c *gin.Context) {
+	h.review(c, "RejectTimesheet", h.timesheetService.RejectTimesheet)
+}
+
+// ReopenTimesheet godoc
+// @Summary Reopen a timesheet
+// @Description Turn an approved timesheet back into a draft, unlocking the tasks of its week, on behalf of the reviewer named in the X-Actor-ID header. Only requests authenticated as an admin's, by the admin token as their bearer token, can reopen
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param id path int true "Timesheet ID"
+// @Param review body dto.ReviewTimesheetRequest true "Review timesheet request"
+// @Success 200 {object} dto.TimesheetResponse
+// @Failure 400 {object} map[string]any
+// @Failure 401 {object} map[string]any
+// @Failure 403 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 409 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /timesheets/{id}/reopen [post]
+func (h *TimesheetHandler) ReopenTimesheet(c *gin.Context) {
+	h.review(c, "ReopenTimesheet", h.timesheetService.ReopenTimesheet)
+}
+
+// review binds the timesheet ID and the review request and passes them to transition.
+func (h *TimesheetHandler) review(c *gin.Context, name string,
+	transition func(ctx context.Context, id uint, request dto.ReviewTimesheetRequest) (*dto.TimesheetResponse, error)) {
+	timesheetID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("%s: invalid timesheet ID: %v", name, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid timesheet ID"})
+		return
+	}
+
+	var request dto.ReviewTimesheetRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Debugf("%s: invalid request: %v", name, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("%s: reviewing timesheet with ID: %d", name, timesheetID)
+	timesheet, err := transition(c.Request.Context(), uint(timesheetID), request)
+	if err != nil {
+		h.logger.Debugf("%s: failed to review timesheet: %v", name, err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("%s: timesheet with ID: %d is now %s", name, timesheetID, timesheet.Status)
+	c.JSON(http.StatusOK, timesheet)
+}
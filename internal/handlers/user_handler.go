@@ -23,12 +23,12 @@ func NewUserHandler(userService services.UserService, logger *logrus.Logger) *Us
 
 // CreateUser godoc
 // @Summary Create a new user
-// @Description Create a new user with a given passport number
+// @Description Create a new user with a given passport number. Passport numbers are returned masked, as 12** ****90, unless the request is authenticated by the admin token
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param user body dto.CreateUserRequest true "Create user request"
-// @Success 200 {object} models.User
+// @Success 200 {object} dto.UserResponse
 // @Failure 400 {object} map[string]any
 // @Failure 500 {object} map[string]any
 // @Router /users [post]
@@ -40,8 +40,8 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 		return
 	}
 
-	h.logger.Infof("CreateUser: creating user with passport number: %s", req.PassportNumber)
-	user, err := h.userService.CreateUser(req.PassportNumber)
+	h.logger.Info("CreateUser: creating user")
+	user, err := h.userService.CreateUser(c.Request.Context(), req.PassportNumber)
 	if err != nil {
 		h.logger.Debugf("CreateUser: failed to create user: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -54,14 +54,18 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 
 // UpdateUser godoc
 // @Summary Update an existing user
-// @Description Update an existing user with given details
+// @Description Update an existing user with given details. Only requests authenticated as an admin's, by the admin token as their bearer token, can change the role
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param id path int true "User ID"
 // @Param user body dto.UpdateUserRequest true "Update user request"
-// @Success 200 {object} models.User
+// @Success 200 {object} dto.UserResponse
 // @Failure 400 {object} map[string]any
+// @Failure 401 {object} map[string]any
+// @Failure 403 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 409 {object} map[string]any
 // @Failure 500 {object} map[string]any
 // @Router /users/{id} [put]
 func (h *UserHandler) UpdateUser(c *gin.Context) {
@@ -80,10 +84,10 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	}
 
 	h.logger.Infof("UpdateUser: updating user with ID: %d", userID)
-	user, err := h.userService.UpdateUser(uint(userID), userUpdateRequest)
+	user, err := h.userService.UpdateUser(c.Request.Context(), uint(userID), userUpdateRequest)
 	if err != nil {
 		h.logger.Debugf("UpdateUser: failed to update user: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -93,13 +97,15 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 
 // DeleteUser godoc
 // @Summary Delete an existing user
-// @Description Delete an existing user by ID
+// @Description Delete an existing user by ID. The user and their tasks are kept and can be restored until they are purged after the retention period. Users with a running timer cannot be deleted
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param id path int true "User ID"
 // @Success 200 {object} map[string]any
 // @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 409 {object} map[string]any
 // @Failure 500 {object} map[string]any
 // @Router /users/{id} [delete]
 func (h *UserHandler) DeleteUser(c *gin.Context) {
@@ -111,10 +117,10 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 	}
 
 	h.logger.Infof("DeleteUser: deleting user with ID: %d", userID)
-	err = h.userService.DeleteUser(uint(userID))
+	err = h.userService.DeleteUser(c.Request.Context(), uint(userID))
 	if err != nil {
 		h.logger.Debugf("DeleteUser: failed to delete user: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -122,29 +128,71 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"user": nil})
 }
 
+// RestoreUser godoc
+// @Summary Restore a deleted user
+// @Description Restore a deleted user that has not been purged yet, together with their tasks
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 409 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /users/{id}/restore [post]
+func (h *UserHandler) RestoreUser(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("RestoreUser: invalid user ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	h.logger.Infof("RestoreUser: restoring user with ID: %d", userID)
+	user, err := h.userService.RestoreUser(c.Request.Context(), uint(userID))
+	if err != nil {
+		h.logger.Debugf("RestoreUser: failed to restore user: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("RestoreUser: user restored with ID: %d", userID)
+	c.JSON(http.StatusOK, user)
+}
+
 // GetUsers godoc
 // @Summary Get all users
-// @Description Get all users with optional filters and pagination
+// @Description Get all users with optional filters and pagination. Deleted users are hidden unless requested. Users can be looked up by their exact passport_number, which is returned masked unless the request is authenticated by the admin token
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param page query int false "Page number"
 // @Param pageSize query int false "Page size"
 // @Param filters query string false "Filters in JSON format"
-// @Success 200 {array} models.User
+// @Param include_deleted query bool false "Include deleted users"
+// @Success 200 {array} dto.UserResponse
 // @Failure 500 {object} map[string]any
 // @Router /users [get]
 func (h *UserHandler) GetUsers(c *gin.Context) {
 	h.logger.Info("GetUsers: fetching all users")
+	includeDeleted, err := strconv.ParseBool(c.DefaultQuery("include_deleted", "false"))
+	if err != nil {
+		h.logger.Debugf("GetUsers: invalid include_deleted flag: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid include_deleted flag"})
+		return
+	}
+
 	filters := make(map[string]interface{})
 	for key, values := range c.Request.URL.Query() {
 		filters[key] = values[0]
 	}
+	delete(filters, "include_deleted")
 
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
 
-	users, err := h.userService.GetUsersWithFiltersAndPagination(filters, page, pageSize)
+	users, err := h.userService.GetUsersWithFiltersAndPagination(c.Request.Context(), filters, page, pageSize, includeDeleted)
 	if err != nil {
 		h.logger.Debugf("GetUsers: failed to fetch users: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+type WebhookHandler struct {
+	webhookService services.WebhookService
+	logger         *logrus.Logger
+}
+
+func NewWebhookHandler(webhookService services.WebhookService, logger *logrus.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		logger:         logger,
+	}
+}
+
+// CreateWebhook godoc
+// @Summary Create a webhook
+// @Description Subscribe a URL to events. Every event is POSTed as JSON with the headers X-Webhook-Event, X-Webhook-Delivery and X-Webhook-Signature, which holds t=<unix time>,v1=<hex HMAC-SHA256 of "<unix time>.<body>" keyed with the secret>. Failed deliveries are retried with exponential backoff. The secret is only returned here
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body dto.CreateWebhookRequest true "Create webhook request"
+// @Success 200 {object} dto.WebhookResponse
+// @Failure 400 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var request dto.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Debugf("CreateWebhook: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("CreateWebhook: creating webhook for %s", request.URL)
+	webhook, err := h.webhookService.CreateWebhook(request)
+	if err != nil {
+		h.logger.Debugf("CreateWebhook: failed to create webhook: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("CreateWebhook: webhook created with ID: %d", webhook.ID)
+	c.JSON(http.StatusOK, webhook)
+}
+
+// GetWebhooks godoc
+// @Summary Get webhooks
+// @Description Get all webhooks
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Success 200 {array} dto.WebhookResponse
+// @Failure 500 {object} map[string]any
+// @Router /webhooks [get]
+func (h *WebhookHandler) GetWebhooks(c *gin.Context) {
+	h.logger.Info("GetWebhooks: fetching webhooks")
+	webhooks, err := h.webhookService.GetWebhooks()
+	if err != nil {
+		h.logger.Debugf("GetWebhooks: failed to fetch webhooks: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("GetWebhooks: fetched %d webhooks", len(webhooks))
+	c.JSON(http.StatusOK, webhooks)
+}
+
+// GetWebhook godoc
+// @Summary Get a webhook
+// @Description Get a webhook by ID
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} dto.WebhookResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	webhookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("GetWebhook: invalid webhook ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return
+	}
+
+	webhook, err := h.webhookService.GetWebhook(uint(webhookID))
+	if err != nil {
+		h.logger.Debugf("GetWebhook: failed to get webhook: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// UpdateWebhook godoc
+// @Summary Update a webhook
+// @Description Replace the URL and events of a webhook, and pause or resume it. Pending deliveries of a paused webhook fail
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param webhook body dto.UpdateWebhookRequest true "Update webhook request"
+// @Success 200 {object} dto.WebhookResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /webhooks/{id} [put]
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	webhookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("UpdateWebhook: invalid webhook ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return
+	}
+
+	var request dto.UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Debugf("UpdateWebhook: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("UpdateWebhook: updating webhook with ID: %d", webhookID)
+	webhook, err := h.webhookService.UpdateWebhook(uint(webhookID), request)
+	if err != nil {
+		h.logger.Debugf("UpdateWebhook: failed to update webhook: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("UpdateWebhook: webhook updated with ID: %d", webhook.ID)
+	c.JSON(http.StatusOK, webhook)
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a webhook
+// @Description Delete a webhook together with its delivery log
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	webhookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("DeleteWebhook: invalid webhook ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return
+	}
+
+	h.logger.Infof("DeleteWebhook: deleting webhook with ID: %d", webhookID)
+	if err := h.webhookService.DeleteWebhook(uint(webhookID)); err != nil {
+		h.logger.Debugf("DeleteWebhook: failed to delete webhook: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("DeleteWebhook: webhook deleted with ID: %d", webhookID)
+	c.JSON(http.StatusOK, gin.H{"webhook": nil})
+}
+
+// GetWebhookDeliveries godoc
+// @Summary Get the deliveries of a webhook
+// @Description Get the latest deliveries of a webhook with their payload, attempts and the outcome of the last attempt
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param status query string false "Delivery status" Enums(pending, succeeded, failed)
+// @Param limit query int false "Number of deliveries, 50 by default"
+// @Success 200 {array} dto.WebhookDeliveryResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) GetWebhookDeliveries(c *gin.Context) {
+	webhookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("GetWebhookDeliveries: invalid webhook ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return
+	}
+
+	var request dto.GetWebhookDeliveriesRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		h.logger.Debugf("GetWebhookDeliveries: invalid request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Infof("GetWebhookDeliveries: fetching deliveries of webhook ID: %d", webhookID)
+	deliveries, err := h.webhookService.GetDeliveries(uint(webhookID), request)
+	if err != nil {
+		h.logger.Debugf("GetWebhookDeliveries: failed to fetch deliveries: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("GetWebhookDeliveries: fetched %d deliveries", len(deliveries))
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// ReplayWebhookDelivery godoc
+// @Summary Replay a webhook delivery
+// @Description Send the payload of a delivery again as a new delivery. The payload keeps its event ID so that receivers can spot duplicates
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param delivery_id path int true "Delivery ID"
+// @Success 200 {object} dto.WebhookDeliveryResponse
+// @Failure 400 {object} map[string]any
+// @Failure 404 {object} map[string]any
+// @Failure 500 {object} map[string]any
+// @Router /webhooks/{id}/deliveries/{delivery_id}/replay [post]
+func (h *WebhookHandler) ReplayWebhookDelivery(c *gin.Context) {
+	webhookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Debugf("ReplayWebhookDelivery: invalid webhook ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return
+	}
+
+	deliveryID, err := strconv.Atoi(c.Param("delivery_id"))
+	if err != nil {
+		h.logger.Debugf("ReplayWebhookDelivery: invalid delivery ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid delivery ID"})
+		return
+	}
+
+	h.logger.Infof("ReplayWebhookDelivery: replaying delivery ID %d of webhook ID: %d", deliveryID, webhookID)
+	delivery, err := h.webhookService.ReplayDelivery(uint(webhookID), uint(deliveryID))
+	if err != nil {
+		h.logger.Debugf("ReplayWebhookDelivery: failed to replay delivery: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.logger.Infof("ReplayWebhookDelivery: delivery queued with ID: %d", delivery.ID)
+	c.JSON(http.StatusOK, delivery)
+}
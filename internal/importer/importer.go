@@ -0,0 +1,324 @@
+// Package importer reads time entries from the CSV exports of other time trackers.
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	SourceToggl    = "toggl"
+	SourceClockify = "clockify"
+)
+
+var (
+	ErrUnknownFormat     = errors.New("file is not a Toggl or Clockify CSV export")
+	ErrUnknownDateFormat = errors.New("unknown date format")
+	ErrAmbiguousDates    = errors.New("dates can be read in more than one format, the date format must be given")
+)
+
+// DateFormats are the date formats an export can be written in, by the names they are given as.
+var DateFormats = map[string]string{
+	"YYYY-MM-DD": "2006-01-02",
+	"MM/DD/YYYY": "01/02/2006",
+	"DD/MM/YYYY": "02/01/2006",
+	"DD.MM.YYYY": "02.01.2006",
+}
+
+// Entry is a time entry read from one line of an export.
+type Entry struct {
+	Line        int
+	UserID      *uint
+	UserName    string
+	Email       string
+	Client      string
+	Project     string
+	Description string
+	Tags        []string
+	Billable    *bool
+	Start       time.Time
+	End         time.Time
+	// Err is set when the line cannot be read; the other fields are then incomplete.
+	Err error
+}
+
+// Reader reads entries from an export one line at a time.
+type Reader struct {
+	source     string
+	records    []csvRecord
+	location   *time.Location
+	columns    map[string]int
+	dateLayout string
+	line       int
+}
+
+// csvRecord is a line of an export, or the error it could not be read with.
+type csvRecord struct {
+	fields []string
+	err    error
+}
+
+// NewReader reads an export. Times without an offset are read in location. When source is
+// empty it is detected from the header. When dateFormat, one of DateFormats, is empty, the
+// date format is detected from the dates of the whole file: the one that reads the most of
+// them. The file is rejected with ErrAmbiguousDates if several formats read as many dates
+// but disagree on some, as 03/04/2024 does when all days are 12 or lower.
+func NewReader(r io.Reader, source, dateFormat string, location *time.Location) (*Reader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownFormat, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[name] = i
+	}
+
+	if _, ok := columns["start date"]; !ok {
+		return nil, ErrUnknownFormat
+	}
+	if source == "" {
+		source = SourceToggl
+		if _, ok := columns["duration (h)"]; ok {
+			source = SourceClockify
+		}
+	}
+
+	result := &Reader{
+		source:   source,
+		location: location,
+		columns:  columns,
+		line:     1,
+	}
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var parseErr *csv.ParseError
+		if err != nil && !errors.As(err, &parseErr) {
+			return nil, err
+		}
+		result.records = append(result.records, csvRecord{fields: fields, err: err})
+	}
+
+	if dateFormat != "" {
+		layout, ok := DateFormats[dateFormat]
+		if !ok {
+			return nil, fmt.Errorf("%w %q", ErrUnknownDateFormat, dateFormat)
+		}
+		result.dateLayout = layout
+	} else {
+		layout, err := result.detectDateLayout()
+		if err != nil {
+			return nil, err
+		}
+		result.dateLayout = layout
+	}
+
+	return result, nil
+}
+
+// detectDateLayout returns the date layout of the source that reads the most dates of the
+// file, the first of the layouts of the source if it has no dates.
+func (r *Reader) detectDateLayout() (string, error) {
+	layouts := dateLayouts[r.source]
+	var dates []string
+	for _, record := range r.records {
+		if record.err != nil {
+			continue
+		}
+		for _, name := range []string{"start date", "end date"} {
+			if date := r.field(record.fields, name); date != "" {
+				dates = append(dates, date)
+			}
+		}
+	}
+
+	best, bestCount := []string{layouts[0]}, 0
+	for _, layout := range layouts {
+		count := 0
+		for _, date := range dates {
+			if _, err := time.Parse(layout, date); err == nil {
+				count++
+			}
+		}
+		switch {
+		case count > bestCount:
+			best, bestCount = []string{layout}, count
+		case count == bestCount && count > 0:
+			best = append(best, layout)
+		}
+	}
+
+	for _, layout := range best[1:] {
+		for _, date := range dates {
+			first, firstErr := time.Parse(best[0], date)
+			other, otherErr := time.Parse(layout, date)
+			if firstErr == nil && otherErr == nil && !first.Equal(other) {
+				return "", fmt.Errorf("%w: %q", ErrAmbiguousDates, date)
+			}
+		}
+	}
+
+	return best[0], nil
+}
+
+// Source returns the tracker the export comes from.
+func (r *Reader) Source() string {
+	return r.source
+}
+
+// Read returns the next entry, or io.EOF after the last one. Lines that cannot be
+// read are returned as entries with Err set, so that a report can list them.
+func (r *Reader) Read() (Entry, error) {
+	if len(r.records) == 0 {
+		return Entry{}, io.EOF
+	}
+	next := r.records[0]
+	r.records = r.records[1:]
+	r.line++
+	if next.err != nil {
+		return Entry{Line: r.line, Err: next.err}, nil
+	}
+	record := next.fields
+
+	entry := Entry{
+		Line:        r.line,
+		UserName:    r.field(record, "user"),
+		Email:       r.field(record, "email"),
+		Client:      r.field(record, "client"),
+		Project:     r.field(record, "project"),
+		Description: r.field(record, "description"),
+	}
+	if entry.Description == "" {
+		entry.Description = r.field(record, "task")
+	}
+	for _, tag := range strings.Split(r.field(record, "tags"), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			entry.Tags = append(entry.Tags, tag)
+		}
+	}
+
+	entry.Err = r.readDetails(record, &entry)
+	return entry, nil
+}
+
+func (r *Reader) readDetails(record []string, entry *Entry) error {
+	if value := r.field(record, "user id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 0)
+		if err != nil {
+			return fmt.Errorf("invalid user ID %q", value)
+		}
+		userID := uint(id)
+		entry.UserID = &userID
+	}
+
+	if value := r.field(record, "billable"); value != "" {
+		switch strings.ToLower(value) {
+		case "yes", "true", "1":
+			entry.Billable = new(bool)
+			*entry.Billable = true
+		case "no", "false", "0":
+			entry.Billable = new(bool)
+		default:
+			return fmt.Errorf("invalid billable flag %q", value)
+		}
+	}
+
+	start, err := r.parseTime(r.field(record, "start date"), r.field(record, "start time"))
+	if err != nil {
+		return fmt.Errorf("invalid start: %v", err)
+	}
+	entry.Start = start
+
+	if r.field(record, "end date") != "" {
+		end, err := r.parseTime(r.field(record, "end date"), r.field(record, "end time"))
+		if err != nil {
+			return fmt.Errorf("invalid end: %v", err)
+		}
+		entry.End = end
+	} else {
+		duration, err := parseDuration(r.field(record, "duration"), r.field(record, "duration (h)"))
+		if err != nil {
+			return fmt.Errorf("invalid duration: %v", err)
+		}
+		entry.End = start.Add(duration)
+	}
+
+	if !entry.End.After(entry.Start) {
+		return errors.New("end must be after start")
+	}
+
+	return nil
+}
+
+func (r *Reader) field(record []string, name string) string {
+	index, ok := r.columns[name]
+	if !ok || index >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[index])
+}
+
+// dateLayouts are the layouts the dates of a source can be written in. Toggl writes ISO
+// dates, Clockify writes dates in the format of the workspace.
+var dateLayouts = map[string][]string{
+	SourceToggl:    {"2006-01-02", "01/02/2006", "02.01.2006"},
+	SourceClockify: {"01/02/2006", "2006-01-02", "02.01.2006", "02/01/2006"},
+}
+
+var timeLayouts = []string{"15:04:05", "15:04", "03:04:05 PM", "3:04:05 PM", "03:04 PM", "3:04 PM"}
+
+func (r *Reader) parseTime(date, clock string) (time.Time, error) {
+	if date == "" || clock == "" {
+		return time.Time{}, errors.New("date and time are required")
+	}
+
+	for _, timeLayout := range timeLayouts {
+		value, err := time.ParseInLocation(r.dateLayout+" "+timeLayout, date+" "+clock, r.location)
+		if err == nil {
+			return value, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognized date and time %q", date+" "+clock)
+}
+
+// parseDuration reads a duration written as HH:MM:SS, falling back to decimal hours.
+func parseDuration(clock, hours string) (time.Duration, error) {
+	if clock == "" {
+		clock = hours
+	}
+
+	parts := strings.Split(clock, ":")
+	if len(parts) == 3 {
+		var total time.Duration
+		units := []time.Duration{time.Hour, time.Minute, time.Second}
+		for i, part := range parts {
+			value, err := strconv.Atoi(part)
+			if err != nil {
+				return 0, fmt.Errorf("unrecognized duration %q", clock)
+			}
+			total += time.Duration(value) * units[i]
+		}
+		return total, nil
+	}
+
+	value, err := strconv.ParseFloat(strings.Replace(clock, ",", ".", 1), 64)
+	if err != nil {
+		return 0, fmt.Errorf("unrecognized duration %q", clock)
+	}
+
+	return time.Duration(value * float64(time.Hour)), nil
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionStart   = "start"
+	AuditActionPause   = "pause"
+	AuditActionResume  = "resume"
+	AuditActionStop    = "stop"
+	AuditActionConfirm = "confirm"
+	AuditActionRestore = "restore"
+	AuditActionPurge   = "purge"
+	AuditActionErase   = "erase"
+)
+
+const (
+	AuditEntityUser = "user"
+	AuditEntityTask = "task"
+)
+
+// AuditEntry records that ActorID did Action to an entity. Changes is a JSON object of the
+// fields that changed, each with its value before and after. ActorID is nil for changes
+// made by the application itself or by requests that did not name their actor. Entries are
+// never changed or deleted.
+type AuditEntry struct {
+	ID         uint64 `gorm:"primaryKey"`
+	ActorID    *uint
+	Action     string `gorm:"not null"`
+	EntityType string `gorm:"not null"`
+	EntityID   uint   `gorm:"not null"`
+	RequestID  *string
+	Changes    string `gorm:"not null"`
+	CreatedAt  time.Time
+}
@@ -0,0 +1,104 @@
+package models
+
+import "time"
+
+// TaskAuditState is the state of a task recorded in the audit log, with times in UTC.
+// Segments are left out: they are not loaded with every task and follow from the status.
+type TaskAuditState struct {
+	ID             uint                    `json:"id"`
+	UserID         uint                    `json:"user_id"`
+	TaskName       string                  `json:"task_name"`
+	ProjectID      *uint                   `json:"project_id,omitempty"`
+	Tags           []string                `json:"tags,omitempty"`
+	Billable       bool                    `json:"billable"`
+	InvoiceID      *uint                   `json:"invoice_id,omitempty"`
+	Status         string                  `json:"status"`
+	AutoStopped    bool                    `json:"auto_stopped"`
+	AutoStopReason string                  `json:"auto_stop_reason,omitempty"`
+	RecurrenceID   *uint                   `json:"recurrence_id,omitempty"`
+	Hours          int                     `json:"hours"`
+	Minutes        int                     `json:"minutes"`
+	StartTime      string                  `json:"start_time"`
+	EndTime        string                  `json:"end_time"`
+	FocusSession   *FocusSessionAuditState `json:"focus_session,omitempty"`
+}
+
+type FocusSessionAuditState struct {
+	WorkMinutes     int    `json:"work_minutes"`
+	BreakMinutes    int    `json:"break_minutes"`
+	Cycles          int    `json:"cycles"`
+	CompletedCycles int    `json:"completed_cycles"`
+	Phase           string `json:"phase"`
+	PhaseEndsAt     string `json:"phase_ends_at,omitempty"`
+}
+
+// NewTaskAuditState copies the state of the task as it is now, so that later changes to the
+// task do not show in it.
+func NewTaskAuditState(task *Task) *TaskAuditState {
+	state := &TaskAuditState{
+		ID:           task.ID,
+		UserID:       task.UserID,
+		TaskName:     task.TaskName,
+		ProjectID:    task.ProjectID,
+		Billable:     task.Billable,
+		InvoiceID:    task.InvoiceID,
+		Status:       task.Status,
+		AutoStopped:  task.AutoStopped,
+		RecurrenceID: task.RecurrenceID,
+		Hours:        task.Hours,
+		Minutes:      task.Minutes,
+		StartTime:    task.StartTime.UTC().Format(time.RFC3339),
+	}
+	if task.AutoStopReason != nil {
+		state.AutoStopReason = *task.AutoStopReason
+	}
+	if !task.EndTime.IsZero() {
+		state.EndTime = task.EndTime.UTC().Format(time.RFC3339)
+	}
+	for _, tag := range task.Tags {
+		state.Tags = append(state.Tags, tag.Name)
+	}
+
+	if session := task.FocusSession; session != nil {
+		state.FocusSession = &FocusSessionAuditState{
+			WorkMinutes:     session.WorkMinutes,
+			BreakMinutes:    session.BreakMinutes,
+			Cycles:          session.Cycles,
+			CompletedCycles: session.CompletedCycles,
+			Phase:           session.Phase,
+		}
+		if session.PhaseEndsAt != nil {
+			state.FocusSession.PhaseEndsAt = session.PhaseEndsAt.UTC().Format(time.RFC3339)
+		}
+	}
+
+	return state
+}
+
+// UserAuditState is the state of a user recorded in the audit log. The passport number is
+// left out: the log is kept for good and must not hold personal documents.
+type UserAuditState struct {
+	ID         uint       `json:"id"`
+	Surname    string     `json:"surname"`
+	Name       string     `json:"name"`
+	Patronymic string     `json:"patronymic"`
+	Address    string     `json:"address"`
+	Role       string     `json:"role"`
+	TimeZone   string     `json:"time_zone"`
+	WorkdayEnd *string    `json:"workday_end"`
+	DeletedAt  *time.Time `json:"deleted_at"`
+}
+
+func NewUserAuditState(user *User) *UserAuditState {
+	return &UserAuditState{
+		ID:         user.ID,
+		Surname:    user.Surname,
+		Name:       user.Name,
+		Patronymic: user.Patronymic,
+		Address:    user.Address,
+		Role:       user.Role,
+		TimeZone:   user.TimeZone,
+		WorkdayEnd: user.WorkdayEnd,
+		DeletedAt:  user.DeletedAt,
+	}
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// BudgetThresholds are the percentages of a budget whose crossing raises an alert.
+var BudgetThresholds = []int{80, 100}
+
+// Budget estimates the time to be spent on a project, or on the tasks of a name such as
+// a ticket number. Exactly one of ProjectID and TaskName is set. The time spent is the
+// recorded time of the tasks of all users in its scope.
+type Budget struct {
+	ID              uint `gorm:"primaryKey"`
+	ProjectID       *uint
+	TaskName        *string
+	EstimateMinutes int `gorm:"not null"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// CrossedThresholds returns the thresholds that the time spent on the budget passed on its way
+// from before to after minutes. A threshold is only crossed once: it is not returned again
+// while the time spent stays above it, nor when the time spent goes down.
+func (b *Budget) CrossedThresholds(before, after int) []int {
+	var crossed []int
+	for _, threshold := range BudgetThresholds {
+		limit := threshold * b.EstimateMinutes
+		if before*100 < limit && after*100 >= limit {
+			crossed = append(crossed, threshold)
+		}
+	}
+
+	return crossed
+}
+
+// BudgetAlert reports that stopping a task pushed the time spent on a budget to Threshold
+// percent of its estimate or beyond.
+type BudgetAlert struct {
+	Budget          Budget
+	Threshold       int
+	ConsumedMinutes int
+	TaskID          uint
+	UserID          uint
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// PersonalDataFields are the fields of a user that identify them, anonymised by an erasure.
+var PersonalDataFields = []string{"passport_number", "surname", "name", "patronymic", "address", "workday_end"}
+
+// Erasure proves that the personal data of a user was erased: when, at whose request, which
+// fields, and how many audit entries and webhook deliveries carrying them were redacted or
+// deleted. Fields is a comma separated list of PersonalDataFields. Erasures are kept after
+// the user is purged.
+type Erasure struct {
+	ID                       uint `gorm:"primaryKey"`
+	UserID                   uint `gorm:"not null;unique"`
+	ActorID                  *uint
+	RequestID                *string
+	Fields                   string    `gorm:"not null"`
+	AuditEntriesRedacted     int       `gorm:"not null"`
+	WebhookDeliveriesDeleted int       `gorm:"not null"`
+	ErasedAt                 time.Time `gorm:"not null"`
+}
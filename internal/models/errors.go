@@ -0,0 +1,100 @@
+package models
+
+import "errors"
+
+var (
+	ErrTaskNotRunning     = errors.New("task is not running")
+	ErrTaskNotPaused      = errors.New("task is not paused")
+	ErrTaskNotStopped     = errors.New("task must be stopped to change its time")
+	ErrTaskAlreadyStopped = errors.New("task is already stopped")
+)
+
+var (
+	ErrTaskAlreadyRunning = errors.New("user already has a running task")
+	ErrTaskOverlap        = errors.New("task overlaps other tasks of the user")
+)
+
+var (
+	ErrProjectArchived = errors.New("project is archived")
+	ErrProjectInUse    = errors.New("project has tasks, archive it instead")
+	ErrClientInUse     = errors.New("client has projects")
+)
+
+var (
+	ErrTagExists       = errors.New("tag with this name already exists")
+	ErrEmptyTagName    = errors.New("tag name must not be empty")
+	ErrInvalidTagMerge = errors.New("tag cannot be merged into itself")
+)
+
+var (
+	ErrTaskInvoiced      = errors.New("task is already invoiced")
+	ErrRateNotFound      = errors.New("no rate applies to task")
+	ErrCurrencyMismatch  = errors.New("tasks are billed in different currencies")
+	ErrNothingToInvoice  = errors.New("no unbilled tasks in period")
+	ErrInvalidRateScope  = errors.New("rate cannot be scoped to both a project and a client")
+	ErrInvalidDateFormat = errors.New("dates must be in format YYYY-MM-DD")
+	ErrInvalidPeriod     = errors.New("period end must not be before its start")
+
+	ErrInvalidRoundingScope = errors.New("rounding policy cannot be scoped to both a project and a client")
+)
+
+var (
+	ErrInvalidIDList       = errors.New("IDs must be comma separated positive integers")
+	ErrSameReportDimension = errors.New("report rows and columns must use different dimensions")
+	ErrUnknownExportColumn = errors.New("unknown export column")
+)
+
+var (
+	ErrInvalidImportFile = errors.New("invalid import file")
+	ErrInvalidTimezone   = errors.New("unknown time zone")
+)
+
+var (
+	ErrTimesheetExists        = errors.New("timesheet for this week already exists")
+	ErrTimesheetLocked        = errors.New("tasks of this week are locked by a submitted or approved timesheet")
+	ErrTimesheetHasOpenTasks  = errors.New("timesheet has tasks that are not stopped")
+	ErrInvalidTimesheetStatus = errors.New("timesheet cannot make this transition from its current status")
+	ErrReviewCommentRequired  = errors.New("rejecting a timesheet requires a comment")
+	ErrNotManager             = errors.New("only managers and admins can review timesheets")
+	ErrNotAdmin               = errors.New("only admins can reopen approved timesheets")
+	ErrOwnTimesheetReview     = errors.New("users cannot review their own timesheets")
+	ErrReviewerRequired       = errors.New("timesheet reviews must name their reviewer")
+)
+
+var ErrInvalidBudgetScope = errors.New("budget must be scoped to either a project or a task name")
+
+var (
+	ErrInvalidRecurrenceRule = errors.New("invalid recurrence rule")
+	ErrTaskNotPlanned        = errors.New("task is not planned")
+	ErrOccurrenceRecorded    = errors.New("occurrence has already been recorded")
+)
+
+var ErrUnknownWebhookEvent = errors.New("unknown webhook event")
+
+var (
+	ErrUserDeleted    = errors.New("user is deleted")
+	ErrUserNotDeleted = errors.New("user is not deleted")
+	ErrUserErased     = errors.New("personal data of user has already been erased")
+	ErrRoleChange     = errors.New("only admins can change roles")
+)
+
+var ErrInvalidCalendarToken = errors.New("calendar token is invalid or has been revoked")
+
+var (
+	ErrInvalidTaskInterval = errors.New("task end time must be after its start time")
+	ErrTaskEndRequired     = errors.New("exactly one of end_time and duration_minutes must be set")
+)
+
+// TaskConflictError reports the tasks an operation clashed with.
+type TaskConflictError struct {
+	Err   error
+	Tasks []Task
+}
+
+func (e *TaskConflictError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TaskConflictError) Unwrap() error {
+	return e.Err
+}
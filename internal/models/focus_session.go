@@ -0,0 +1,110 @@
+package models
+
+import "time"
+
+const (
+	FocusPhaseWork  = "work"
+	FocusPhaseBreak = "break"
+	FocusPhaseDone  = "done"
+)
+
+// FocusSession runs a task as a pomodoro: Cycles work intervals of WorkMinutes, each followed
+// by a break of BreakMinutes, except the last. The task is running during work intervals and
+// paused during breaks, so only work counts toward its duration. PhaseEndsAt is when the
+// current phase ends; it is nil once the session is done.
+type FocusSession struct {
+	TaskID          uint   `gorm:"primaryKey;autoIncrement:false"`
+	WorkMinutes     int    `gorm:"not null"`
+	BreakMinutes    int    `gorm:"not null"`
+	Cycles          int    `gorm:"not null"`
+	CompletedCycles int    `gorm:"not null"`
+	Phase           string `gorm:"not null"`
+	PhaseEndsAt     *time.Time
+	CreatedAt       time.Time `gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime"`
+}
+
+// WorkDuration returns the length of a work interval.
+func (s *FocusSession) WorkDuration() time.Duration {
+	return time.Duration(s.WorkMinutes) * time.Minute
+}
+
+// BreakDuration returns the length of a break.
+func (s *FocusSession) BreakDuration() time.Duration {
+	return time.Duration(s.BreakMinutes) * time.Minute
+}
+
+// Actions that the end of a focus session phase takes on its task.
+const (
+	FocusActionPause  = "pause"
+	FocusActionResume = "resume"
+	FocusActionStop   = "stop"
+	FocusActionEnd    = "end"
+)
+
+// FocusStep is what the end of the current phase of a focus session does: the action taken
+// on the task, the time it takes effect at, and the session as it is afterwards.
+type FocusStep struct {
+	Action  string
+	At      time.Time
+	Session FocusSession
+}
+
+// EndPhase returns the step that ends the current phase of the session of a task in
+// taskStatus. A work interval of a running task is followed by a break, or by a stop once
+// it was the last cycle. A break of a paused task is followed by the next work interval if
+// resumable tells that work can go on when the break is over, and by a stop at the start
+// of the break otherwise. A task in any other state, as when it was paused or stopped by
+// hand, only ends the session.
+func (s *FocusSession) EndPhase(taskStatus string, resumable func(breakEnd time.Time) (bool, error)) (FocusStep, error) {
+	endedAt := *s.PhaseEndsAt
+	next := *s
+	next.Phase = FocusPhaseDone
+	next.PhaseEndsAt = nil
+
+	switch {
+	case s.Phase == FocusPhaseWork && taskStatus == TaskStatusRunning:
+		next.CompletedCycles++
+		if next.CompletedCycles >= s.Cycles {
+			return FocusStep{Action: FocusActionStop, At: endedAt, Session: next}, nil
+		}
+
+		breakEndsAt := endedAt.Add(s.BreakDuration())
+		next.Phase = FocusPhaseBreak
+		next.PhaseEndsAt = &breakEndsAt
+		return FocusStep{Action: FocusActionPause, At: endedAt, Session: next}, nil
+	case s.Phase == FocusPhaseBreak && taskStatus == TaskStatusPaused:
+		ok, err := resumable(endedAt)
+		if err != nil {
+			return FocusStep{}, err
+		}
+		if !ok {
+			return FocusStep{Action: FocusActionStop, At: endedAt.Add(-s.BreakDuration()), Session: next}, nil
+		}
+
+		workEndsAt := endedAt.Add(s.WorkDuration())
+		next.Phase = FocusPhaseWork
+		next.PhaseEndsAt = &workEndsAt
+		return FocusStep{Action: FocusActionResume, At: endedAt, Session: next}, nil
+	default:
+		return FocusStep{Action: FocusActionEnd, At: endedAt, Session: next}, nil
+	}
+}
+
+// ResumableAfterBreak reports whether the task of a focus session can go back to work when
+// the break ending at breakEnd is over. It cannot if its project has been archived, or if the
+// user is tracking other work or has tracked some past breakEnd, which the work would overlap.
+// otherTasks are the other tasks of the user; those paused or planned are not work in progress.
+func ResumableAfterBreak(breakEnd time.Time, otherTasks []Task, projectArchived bool) bool {
+	if projectArchived {
+		return false
+	}
+
+	for _, task := range otherTasks {
+		if task.Status == TaskStatusRunning || task.Status == TaskStatusStopped && task.EndTime.After(breakEnd) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type Invoice struct {
+	ID          uint   `gorm:"primaryKey"`
+	Sequence    int    `gorm:"unique; not null"`
+	Number      string `gorm:"unique; not null"`
+	ClientID    uint   `gorm:"not null"`
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Currency    string        `gorm:"not null"`
+	TotalCents  int64         `gorm:"not null"`
+	Lines       []InvoiceLine `gorm:"foreignKey:InvoiceID"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// InvoiceLine freezes a billed task together with the rate that was applied to it,
+// so later changes to the task or the rates do not alter issued invoices. Minutes is
+// the recorded duration, RoundedMinutes the one billed after rounding.
+type InvoiceLine struct {
+	ID              uint   `gorm:"primaryKey"`
+	InvoiceID       uint   `gorm:"not null"`
+	TaskID          uint   `gorm:"unique; not null"`
+	Description     string `gorm:"not null"`
+	Minutes         int    `gorm:"not null"`
+	RoundedMinutes  int    `gorm:"not null"`
+	HourlyRateCents int64  `gorm:"not null"`
+	AmountCents     int64  `gorm:"not null"`
+	CreatedAt       time.Time
+}
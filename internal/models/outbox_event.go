@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+const (
+	EventTaskCreated   = "task.created"
+	EventTaskUpdated   = "task.updated"
+	EventTaskConfirmed = "task.confirmed"
+	EventTaskStarted   = "task.started"
+	EventTaskPaused    = "task.paused"
+	EventTaskResumed   = "task.resumed"
+	EventTaskStopped   = "task.stopped"
+	EventUserCreated   = "user.created"
+	EventUserUpdated   = "user.updated"
+	EventUserDeleted   = "user.deleted"
+	EventUserRestored  = "user.restored"
+	EventUserErased    = "user.erased"
+	EventUserPurged    = "user.purged"
+)
+
+// OutboxEvent is a domain event, stored in the same transaction as the change it reports so
+// that it is published if and only if the change is committed. Payload is the JSON of a
+// TaskEventData or UserEventData. Events are published at least once, so subscribers should
+// use ID to recognise events they have already handled.
+type OutboxEvent struct {
+	ID            uint64 `gorm:"primaryKey"`
+	Event         string `gorm:"not null"`
+	AggregateID   uint   `gorm:"not null"`
+	Payload       string `gorm:"not null"`
+	Attempts      int    `gorm:"not null"`
+	NextAttemptAt *time.Time
+	LastError     *string
+	PublishedAt   *time.Time
+	CreatedAt     time.Time
+}
+
+// TaskEventData is the payload of the events of a task: the task as it was committed.
+type TaskEventData struct {
+	TaskID      uint       `json:"task_id"`
+	UserID      uint       `json:"user_id"`
+	TaskName    string     `json:"task_name"`
+	ProjectID   *uint      `json:"project_id,omitempty"`
+	Status      string     `json:"status"`
+	Billable    bool       `json:"billable"`
+	AutoStopped bool       `json:"auto_stopped"`
+	Minutes     int        `json:"minutes"`
+	StartTime   time.Time  `json:"start_time"`
+	EndTime     *time.Time `json:"end_time,omitempty"`
+}
+
+// UserEventData is the payload of the events of a user. It only carries the ID, so that no
+// personal data is copied into the outbox.
+type UserEventData struct {
+	UserID uint `json:"user_id"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// Project groups tasks for reporting. Archived projects keep their history
+// but cannot be used for new timers.
+type Project struct {
+	ID        uint `gorm:"primaryKey"`
+	ClientID  *uint
+	Client    *Client
+	Name      string `gorm:"not null"`
+	Archived  bool   `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Rate is an hourly rate that applies from EffectiveFrom on to the tasks matching
+// its scope. A rate may be scoped to a user, a project, a client or a combination;
+// a rate without any scope is the default rate.
+type Rate struct {
+	ID              uint `gorm:"primaryKey"`
+	UserID          *uint
+	ProjectID       *uint
+	ClientID        *uint
+	HourlyRateCents int64     `gorm:"not null"`
+	Currency        string    `gorm:"not null"`
+	EffectiveFrom   time.Time `gorm:"not null"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+const (
+	// RecurrenceModePlanned creates planned tasks ahead of their occurrences; they are only
+	// recorded once confirmed. RecurrenceModeActual records the tasks once they have happened.
+	RecurrenceModePlanned = "planned"
+	RecurrenceModeActual  = "actual"
+)
+
+// Recurrence creates tasks from a template on the schedule of an RRULE-style Rule. The first
+// occurrence is on StartDate at StartTime, as HH:MM, both in the time zone of the user of the
+// template, so that occurrences keep their local time across daylight saving changes.
+type Recurrence struct {
+	ID         uint          `gorm:"primaryKey"`
+	TemplateID uint          `gorm:"not null"`
+	Template   *TaskTemplate `gorm:"foreignKey:TemplateID"`
+	Rule       string        `gorm:"not null"`
+	StartDate  time.Time     `gorm:"type:date;not null"`
+	StartTime  string        `gorm:"not null"`
+	Mode       string        `gorm:"not null"`
+	// GeneratedUntil is the start of the last occurrence that has been handled, whether
+	// a task was created for it or not. Later occurrences are still to be created.
+	GeneratedUntil *time.Time
+	Skips          []RecurrenceSkip `gorm:"foreignKey:RecurrenceID"`
+	CreatedAt      time.Time        `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time        `gorm:"autoUpdateTime"`
+}
+
+// RecurrenceSkip cancels the occurrence of a recurrence on Date, a day in the time zone of its user.
+type RecurrenceSkip struct {
+	ID           uint      `gorm:"primaryKey"`
+	RecurrenceID uint      `gorm:"not null"`
+	Date         time.Time `gorm:"type:date;not null"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+}
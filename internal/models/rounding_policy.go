@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+const (
+	RoundingModeUp      = "up"
+	RoundingModeDown    = "down"
+	RoundingModeNearest = "nearest"
+)
+
+// RoundingPolicy rounds reported durations to multiples of IncrementMinutes. A policy
+// is scoped to a project or a client; a policy without a scope applies to everything else.
+// Tasks keep their recorded times, only reports and invoices use the rounded durations.
+type RoundingPolicy struct {
+	ID               uint `gorm:"primaryKey"`
+	ProjectID        *uint
+	ClientID         *uint
+	IncrementMinutes int    `gorm:"not null"`
+	Mode             string `gorm:"not null"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type Tag struct {
+	ID        uint   `gorm:"primaryKey"`
+	Name      string `gorm:"unique; not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -2,14 +2,39 @@ package models
 
 import "time"
 
+const (
+	TaskStatusRunning = "running"
+	TaskStatusPaused  = "paused"
+	TaskStatusStopped = "stopped"
+	// TaskStatusPlanned marks tasks created ahead of time by a recurrence. They have no
+	// segments and count for nothing until they are confirmed, which records their interval.
+	TaskStatusPlanned = "planned"
+)
+
 type Task struct {
-	ID        uint      `gorm:"primaryKey"`
-	UserID    uint      `gorm:"not null"`
-	TaskName  string    `gorm:"not null"`
-	Hours     int       `gorm:"not null"`
-	Minutes   int       `gorm:"not null"`
-	StartTime time.Time `gorm:"not null"`
-	EndTime   time.Time `gorm:"not null"`
-	CreatedAt time.Time `gorm:"autoCreateTime"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null"`
+	TaskName  string `gorm:"not null"`
+	ProjectID *uint
+	Billable  bool `gorm:"not null"`
+	InvoiceID *uint
+	// ImportKey identifies a task imported from another tracker, so that importing
+	// the same entry again is detected.
+	ImportKey *string
+	// RecurrenceID is the recurrence that created the task, if any.
+	RecurrenceID *uint
+	Status       string `gorm:"not null"`
+	// AutoStopped marks tasks stopped by the sweeper rather than by the user,
+	// AutoStopReason tells why, so that the stop time can be reviewed.
+	AutoStopped    bool `gorm:"not null"`
+	AutoStopReason *string
+	Hours          int           `gorm:"not null"`
+	Minutes        int           `gorm:"not null"`
+	StartTime      time.Time     `gorm:"not null"`
+	EndTime        time.Time     `gorm:"not null"`
+	Segments       []TaskSegment `gorm:"foreignKey:TaskID"`
+	Tags           []Tag         `gorm:"many2many:task_tags"`
+	FocusSession   *FocusSession `gorm:"foreignKey:TaskID"`
+	CreatedAt      time.Time     `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time     `gorm:"autoUpdateTime"`
 }
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// TaskEventsChannel is the Postgres channel notified of every stored TaskEvent.
+const TaskEventsChannel = "task_events"
+
+// TaskEvent is a stored event of the live task stream, kept for a while so that clients
+// can catch up after reconnecting. It is stored in the transaction of the change it reports,
+// under the ID of the OutboxEvent stored with it. Payload is the JSON of its TaskEventData.
+type TaskEvent struct {
+	ID        uint64 `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null"`
+	Event     string `gorm:"not null"`
+	Payload   string `gorm:"not null"`
+	CreatedAt time.Time
+}
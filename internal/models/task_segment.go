@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// TaskSegment is a single uninterrupted interval of work on a task.
+// EndTime is nil while the segment is still open.
+type TaskSegment struct {
+	ID        uint      `gorm:"primaryKey"`
+	TaskID    uint      `gorm:"not null"`
+	StartTime time.Time `gorm:"not null"`
+	EndTime   *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// TaskTemplate describes a task that is tracked again and again, such as a daily standup,
+// so that its timer can be started in one call and recurrences can create its entries.
+type TaskTemplate struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null"`
+	TaskName  string `gorm:"not null"`
+	ProjectID *uint
+	Billable  bool `gorm:"not null"`
+	// DurationMinutes is the length of the entries created by recurrences of the template.
+	DurationMinutes int       `gorm:"not null"`
+	Tags            []Tag     `gorm:"many2many:task_template_tags"`
+	CreatedAt       time.Time `gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type Team struct {
+	ID        uint   `gorm:"primaryKey"`
+	Name      string `gorm:"unique;not null"`
+	Members   []User `gorm:"many2many:team_members"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
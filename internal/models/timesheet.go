@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+const (
+	TimesheetStatusDraft     = "draft"
+	TimesheetStatusSubmitted = "submitted"
+	TimesheetStatusApproved  = "approved"
+	TimesheetStatusRejected  = "rejected"
+)
+
+// Timesheet collects the tasks a user started in the week beginning on WeekStart, a Monday,
+// in the time zone of the user. While it is submitted or approved, the tasks of the week cannot be changed.
+type Timesheet struct {
+	ID          uint                  `gorm:"primaryKey"`
+	UserID      uint                  `gorm:"not null"`
+	WeekStart   time.Time             `gorm:"type:date;not null"`
+	Status      string                `gorm:"not null"`
+	Transitions []TimesheetTransition `gorm:"foreignKey:TimesheetID"`
+	CreatedAt   time.Time             `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time             `gorm:"autoUpdateTime"`
+}
+
+// TimesheetTransition records who moved a timesheet from one status to another and why.
+// FromStatus is nil for the transition that created the timesheet.
+type TimesheetTransition struct {
+	ID          uint `gorm:"primaryKey"`
+	TimesheetID uint `gorm:"not null"`
+	FromStatus  *string
+	ToStatus    string `gorm:"not null"`
+	ActorID     *uint
+	Comment     string
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
+// Locked reports whether the tasks of the week are protected from changes.
+func (t *Timesheet) Locked() bool {
+	return t.Status == TimesheetStatusSubmitted || t.Status == TimesheetStatusApproved
+}
+
+// TimesheetWeek returns the Monday of the week that the date of t falls in.
+func TimesheetWeek(t time.Time) time.Time {
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, time.UTC)
+}
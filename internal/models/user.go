@@ -2,13 +2,45 @@ package models
 
 import "time"
 
+const (
+	UserRoleUser    = "user"
+	UserRoleManager = "manager"
+	UserRoleAdmin   = "admin"
+)
+
 type User struct {
-	ID             uint   `gorm:"primaryKey"`
-	PassportNumber string `gorm:"unique; not null"`
-	Surname        string `gorm:"not null"`
-	Name           string `gorm:"not null"`
-	Patronymic     string
-	Address        string `gorm:"not null"`
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	ID uint `gorm:"primaryKey"`
+	// PassportNumber is the passport number in plain text, which is never stored and is only set
+	// once opened by UserRepository.OpenPassport. The database keeps it sealed in
+	// PassportCiphertext under the data key PassportDataKey, itself wrapped by the master key
+	// PassportKeyID, and looks it up by its hash PassportHash under the key PassportHashKeyID.
+	PassportNumber     string `gorm:"-"`
+	PassportHash       string `gorm:"unique; not null"`
+	PassportHashKeyID  string `gorm:"not null"`
+	PassportCiphertext []byte `gorm:"not null"`
+	PassportDataKey    []byte `gorm:"not null"`
+	PassportKeyID      string `gorm:"not null"`
+	Surname            string `gorm:"not null"`
+	Name               string `gorm:"not null"`
+	Patronymic         string
+	Address            string `gorm:"not null"`
+	// Role decides who may review timesheets: managers approve and reject them, admins also reopen them.
+	Role string `gorm:"not null;default:user"`
+	// TimeZone is the IANA name of the zone the user works in. Dates in queries and reports
+	// of the user are days of this zone.
+	TimeZone string `gorm:"not null;default:UTC"`
+	// WorkdayEnd is the time of day, as HH:MM in TimeZone, after which timers left running are stopped.
+	WorkdayEnd *string
+	// CalendarTokenHash is the SHA-256 of the token that opens the calendar feed of the user,
+	// nil until a token is generated. The token itself is not stored.
+	CalendarTokenHash *string
+	// DeletedAt is when the user was deleted, nil for active users. Deleted users keep their
+	// tasks, are left out of lists and cannot start timers until they are restored; they are
+	// purged for good once the retention period has passed.
+	DeletedAt *time.Time
+	// ErasedAt is when the personal data of the user was erased, nil if it has not been.
+	// Erased users keep their tasks, so that the time they tracked can still be accounted for.
+	ErasedAt  *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// WebhookEvents are the events webhooks can subscribe to.
+var WebhookEvents = []string{EventTaskCreated, EventTaskUpdated, EventTaskConfirmed,
+	EventTaskStarted, EventTaskPaused, EventTaskResumed, EventTaskStopped,
+	EventUserCreated, EventUserUpdated, EventUserDeleted, EventUserRestored,
+	EventUserErased, EventUserPurged}
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// Webhook subscribes URL to Events, a comma separated list of WebhookEvents. Deliveries are
+// signed with Secret.
+type Webhook struct {
+	ID        uint   `gorm:"primaryKey"`
+	URL       string `gorm:"not null"`
+	Secret    string `gorm:"not null"`
+	Events    string `gorm:"not null"`
+	Active    bool   `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// WebhookDelivery is a payload sent, or to be sent, to a webhook. A pending delivery is
+// attempted at NextAttemptAt; it fails for good after the last attempt allowed. ReplayOf
+// is the delivery a replay was made of.
+type WebhookDelivery struct {
+	ID             uint     `gorm:"primaryKey"`
+	WebhookID      uint     `gorm:"not null"`
+	Webhook        *Webhook `gorm:"foreignKey:WebhookID"`
+	EventID        string   `gorm:"not null"`
+	Event          string   `gorm:"not null"`
+	Payload        string   `gorm:"not null"`
+	Status         string   `gorm:"not null"`
+	Attempts       int      `gorm:"not null"`
+	NextAttemptAt  *time.Time
+	LastStatusCode *int
+	LastError      *string
+	DeliveredAt    *time.Time
+	ReplayOf       *uint
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
@@ -0,0 +1,44 @@
+// Package notify delivers alerts raised while time is tracked, such as budgets running out.
+package notify
+
+import (
+	"fmt"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+// Notifier delivers alerts. It is called after the change that raised an alert has been
+// committed, on the goroutine of the request, so implementations should not block for long.
+type Notifier interface {
+	NotifyBudgetAlert(alert models.BudgetAlert) error
+}
+
+// LogNotifier writes alerts to the log. It is the notifier used unless another one is configured.
+type LogNotifier struct {
+	logger *logrus.Logger
+}
+
+func NewLogNotifier(logger *logrus.Logger) *LogNotifier {
+	return &LogNotifier{
+		logger: logger,
+	}
+}
+
+func (n *LogNotifier) NotifyBudgetAlert(alert models.BudgetAlert) error {
+	n.logger.Warnf("NotifyBudgetAlert: budget %d of %s reached %d%% of its estimate, %d of %d minutes spent, when task ID %d of user ID %d was stopped",
+		alert.Budget.ID, budgetScope(alert.Budget), alert.Threshold, alert.ConsumedMinutes, alert.Budget.EstimateMinutes,
+		alert.TaskID, alert.UserID)
+	return nil
+}
+
+func budgetScope(budget models.Budget) string {
+	if budget.ProjectID != nil {
+		return fmt.Sprintf("project %d", *budget.ProjectID)
+	}
+	if budget.TaskName != nil {
+		return fmt.Sprintf("task name %q", *budget.TaskName)
+	}
+
+	return "unknown scope"
+}
@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"encoding/json"
+
+	"github.com/Dor1ma/Time-Tracker/internal/audit"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// addAuditEntry records a change in the audit log in the transaction that makes it, so that
+// the change is committed if and only if its entry is. before and after are snapshots of the
+// entity, nil where it did not exist; only the fields that differ are kept. The actor and
+// request are taken from the context of tx.
+func addAuditEntry(tx *gorm.DB, action, entityType string, entityID uint, before, after any) error {
+	changes, err := audit.Diff(before, after)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	actor := audit.FromContext(tx.Statement.Context)
+	entry := &models.AuditEntry{
+		ActorID:    actor.UserID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Changes:    string(data),
+	}
+	if actor.RequestID != "" {
+		entry.RequestID = &actor.RequestID
+	}
+
+	return tx.Create(entry).Error
+}
+
+// addTaskAuditEntry records a change to a task; before is nil for a new task.
+func addTaskAuditEntry(tx *gorm.DB, action string, before *models.TaskAuditState, task *models.Task) error {
+	return addAuditEntry(tx, action, models.AuditEntityTask, task.ID, before, models.NewTaskAuditState(task))
+}
+
+// addUserAuditEntry records a change to a user; before is nil for a new user.
+func addUserAuditEntry(tx *gorm.DB, action string, before *models.UserAuditState, user *models.User) error {
+	return addAuditEntry(tx, action, models.AuditEntityUser, user.ID, before, models.NewUserAuditState(user))
+}
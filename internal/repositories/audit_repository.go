@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+)
+
+type AuditRepository interface {
+	GetEntries(filter AuditFilter, limit int) ([]models.AuditEntry, error)
+	GetEntriesInBatches(filter AuditFilter, batchSize int, visit func(entries []models.AuditEntry) error) error
+	GetUserEntriesInBatches(userID uint, batchSize int, visit func(entries []models.AuditEntry) error) error
+}
+
+// AuditFilter narrows down the audit entries returned. Zero fields do not filter; From is
+// inclusive and To exclusive. BeforeID pages back through the entries, newest first.
+type AuditFilter struct {
+	EntityType string
+	EntityID   *uint
+	ActorID    *uint
+	Action     string
+	From       time.Time
+	To         time.Time
+	BeforeID   uint64
+}
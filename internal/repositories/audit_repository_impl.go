@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/audit"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type AuditRepositoryImpl struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewAuditRepositoryImpl(db *gorm.DB, logger *logrus.Logger) *AuditRepositoryImpl {
+	return &AuditRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetEntries returns up to limit entries matching the filter, newest first.
+func (r *AuditRepositoryImpl) GetEntries(filter AuditFilter, limit int) ([]models.AuditEntry, error) {
+	query := r.auditQuery(filter)
+	if filter.BeforeID > 0 {
+		query = query.Where("id < ?", filter.BeforeID)
+	}
+
+	var entries []models.AuditEntry
+	if err := query.Order("id DESC").Limit(limit).Find(&entries).Error; err != nil {
+		r.logger.Errorf("GetEntries: failed to fetch audit entries from database: %v", err)
+		return nil, err
+	}
+
+	r.logger.Infof("GetEntries: fetched %d audit entries from database", len(entries))
+	return entries, nil
+}
+
+// GetEntriesInBatches calls visit with the entries matching the filter, oldest first, in
+// batches of batchSize, stopping at the first error visit returns.
+func (r *AuditRepositoryImpl) GetEntriesInBatches(filter AuditFilter, batchSize int, visit func(entries []models.AuditEntry) error) error {
+	var afterID uint64
+	for {
+		query := r.auditQuery(filter).Where("id > ?", afterID)
+		if filter.BeforeID > 0 {
+			query = query.Where("id < ?", filter.BeforeID)
+		}
+
+		var entries []models.AuditEntry
+		if err := query.Order("id").Limit(batchSize).Find(&entries).Error; err != nil {
+			r.logger.Errorf("GetEntriesInBatches: failed to fetch audit entries from database: %v", err)
+			return err
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+		if err := visit(entries); err != nil {
+			return err
+		}
+		if len(entries) < batchSize {
+			return nil
+		}
+		afterID = entries[len(entries)-1].ID
+	}
+}
+
+// GetUserEntriesInBatches calls visit with the entries concerning the user, oldest first, in
+// batches of batchSize: those about the user and their tasks, and those made by the user.
+func (r *AuditRepositoryImpl) GetUserEntriesInBatches(userID uint, batchSize int, visit func(entries []models.AuditEntry) error) error {
+	var entries []models.AuditEntry
+	err := r.db.Where("(entity_type = ? AND entity_id = ?) OR actor_id = ? OR (entity_type = ? AND entity_id IN (?))",
+		models.AuditEntityUser, userID, userID,
+		models.AuditEntityTask, r.db.Model(&models.Task{}).Select("id").Where("user_id = ?", userID)).
+		Order("id").
+		FindInBatches(&entries, batchSize, func(tx *gorm.DB, batch int) error {
+			return visit(entries)
+		}).Error
+	if err != nil {
+		r.logger.Errorf("GetUserEntriesInBatches: failed to fetch audit entries of user with ID %d from database: %v", userID, err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *AuditRepositoryImpl) auditQuery(filter AuditFilter) *gorm.DB {
+	query := r.db.Model(&models.AuditEntry{})
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != nil {
+		query = query.Where("entity_id = ?", *filter.EntityID)
+	}
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+
+	return query
+}
+
+// redactUserAuditEntries replaces the values of the named fields in the changes recorded
+// about the user with audit.Erased and returns the number of entries redacted. The audit log
+// only allows this within a transaction that has enabled redaction.
+func redactUserAuditEntries(tx *gorm.DB, userID uint, fields []string) (int, error) {
+	if err := tx.Exec("SET LOCAL audit.redaction = 'on'").Error; err != nil {
+		return 0, err
+	}
+
+	var entries []models.AuditEntry
+	err := tx.Where("entity_type = ? AND entity_id = ?", models.AuditEntityUser, userID).Order("id").Find(&entries).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var redacted int
+	for _, entry := range entries {
+		changes, ok, err := audit.Redact(entry.Changes, fields)
+		if err != nil {
+			return redacted, err
+		}
+		if !ok {
+			continue
+		}
+		if err := tx.Model(&entry).Update("changes", changes).Error; err != nil {
+			return redacted, err
+		}
+		redacted++
+	}
+
+	return redacted, nil
+}
@@ -0,0 +1,11 @@
+package repositories
+
+import "github.com/Dor1ma/Time-Tracker/internal/models"
+
+type BudgetRepository interface {
+	Set(budget *models.Budget) error
+	GetById(id uint) (*models.Budget, error)
+	GetAll() ([]models.Budget, error)
+	Delete(id uint) error
+	GetConsumedMinutes(budget *models.Budget) (int, error)
+}
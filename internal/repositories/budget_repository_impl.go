@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BudgetRepositoryImpl struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewBudgetRepositoryImpl(db *gorm.DB, logger *logrus.Logger) *BudgetRepositoryImpl {
+	return &BudgetRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Set stores the budget, or changes the estimate of the budget that already has its scope,
+// so that the budget of a scope keeps its ID.
+func (r *BudgetRepositoryImpl) Set(budget *models.Budget) error {
+	r.logger.Infof("Set: setting budget in database to %d minutes", budget.EstimateMinutes)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("LOCK TABLE budgets IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+			return err
+		}
+
+		var existing models.Budget
+		err := budgetScope(tx, budget).First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(budget).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		existing.EstimateMinutes = budget.EstimateMinutes
+		if err := tx.Save(&existing).Error; err != nil {
+			return err
+		}
+
+		*budget = existing
+		return nil
+	})
+	if err != nil {
+		r.logger.Errorf("Set: failed to set budget in database: %v", err)
+		return err
+	}
+
+	r.logger.Infof("Set: budget set in database successfully with ID %d", budget.ID)
+	return nil
+}
+
+func (r *BudgetRepositoryImpl) GetById(id uint) (*models.Budget, error) {
+	var budget models.Budget
+	if err := r.db.First(&budget, id).Error; err != nil {
+		r.logger.Errorf("GetById: failed to get budget from database with ID %d: %v", id, err)
+		return nil, err
+	}
+
+	r.logger.Infof("GetById: successfully retrieved budget from database with ID %d", id)
+	return &budget, nil
+}
+
+func (r *BudgetRepositoryImpl) GetAll() ([]models.Budget, error) {
+	var budgets []models.Budget
+	result := r.db.Order("id").Find(&budgets)
+	if result.Error != nil {
+		r.logger.Errorf("GetAll: failed to fetch budgets from database: %v", result.Error)
+		return nil, result.Error
+	}
+
+	r.logger.Infof("GetAll: successfully fetched %d budgets from database", len(budgets))
+	return budgets, nil
+}
+
+func (r *BudgetRepositoryImpl) Delete(id uint) error {
+	r.logger.Infof("Delete: deleting budget from database with ID %d", id)
+	result := r.db.Delete(&models.Budget{}, id)
+	if result.Error != nil {
+		r.logger.Errorf("Delete: failed to delete budget from database with ID %d: %v", id, result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	r.logger.Infof("Delete: budget with ID %d deleted from database successfully", id)
+	return nil
+}
+
+// GetConsumedMinutes sums the recorded minutes of the tasks in the scope of the budget.
+// Running tasks count with the segments closed so far.
+func (r *BudgetRepositoryImpl) GetConsumedMinutes(budget *models.Budget) (int, error) {
+	consumed, err := consumedMinutes(r.db, budget)
+	if err != nil {
+		r.logger.Errorf("GetConsumedMinutes: failed to sum minutes of budget with ID %d: %v", budget.ID, err)
+		return 0, err
+	}
+
+	r.logger.Infof("GetConsumedMinutes: budget with ID %d has %d minutes consumed", budget.ID, consumed)
+	return consumed, nil
+}
+
+func budgetScope(tx *gorm.DB, budget *models.Budget) *gorm.DB {
+	if budget.ProjectID != nil {
+		return tx.Where("project_id = ?", *budget.ProjectID)
+	}
+
+	return tx.Where("task_name = ?", *budget.TaskName)
+}
+
+func consumedMinutes(tx *gorm.DB, budget *models.Budget) (int, error) {
+	var consumed int
+	err := budgetScope(tx.Model(&models.Task{}), budget).
+		Select("COALESCE(SUM(minutes), 0)").
+		Scan(&consumed).Error
+
+	return consumed, err
+}
+
+// crossedBudgetThresholds locks the budgets of the project and of the name of the task and
+// reports the thresholds that the addedMinutes, just recorded on the task, pushed them over.
+// The lock keeps concurrent stops from reporting the same threshold twice.
+func crossedBudgetThresholds(tx *gorm.DB, task *models.Task, addedMinutes int) ([]models.BudgetAlert, error) {
+	if addedMinutes <= 0 {
+		return nil, nil
+	}
+
+	query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("task_name = ?", task.TaskName)
+	if task.ProjectID != nil {
+		query = query.Or("project_id = ?", *task.ProjectID)
+	}
+
+	var budgets []models.Budget
+	if err := query.Order("id").Find(&budgets).Error; err != nil {
+		return nil, err
+	}
+
+	var alerts []models.BudgetAlert
+	for _, budget := range budgets {
+		consumed, err := consumedMinutes(tx, &budget)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, threshold := range budget.CrossedThresholds(consumed-addedMinutes, consumed) {
+			alerts = append(alerts, models.BudgetAlert{
+				Budget:          budget,
+				Threshold:       threshold,
+				ConsumedMinutes: consumed,
+				TaskID:          task.ID,
+				UserID:          task.UserID,
+			})
+		}
+	}
+
+	return alerts, nil
+}
@@ -0,0 +1,11 @@
+package repositories
+
+import "github.com/Dor1ma/Time-Tracker/internal/models"
+
+type ClientRepository interface {
+	Create(client *models.Client) error
+	GetById(id uint) (*models.Client, error)
+	GetAll() ([]models.Client, error)
+	Update(client *models.Client) error
+	Delete(id uint) error
+}
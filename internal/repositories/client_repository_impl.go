@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ClientRepositoryImpl struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewClientRepositoryImpl(db *gorm.DB, logger *logrus.Logger) *ClientRepositoryImpl {
+	return &ClientRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *ClientRepositoryImpl) Create(client *models.Client) error {
+	r.logger.Infof("Create: creating client in database with name %s", client.Name)
+	if err := r.db.Create(client).Error; err != nil {
+		r.logger.Errorf("Create: failed to create client in database: %v", err)
+		return err
+	}
+
+	r.logger.Infof("Create: client created in database successfully with ID %d", client.ID)
+	return nil
+}
+
+func (r *ClientRepositoryImpl) GetById(id uint) (*models.Client, error) {
+	var client models.Client
+	result := r.db.First(&client, id)
+	if result.Error != nil {
+		r.logger.Errorf("GetById: failed to get client from database with ID %d: %v", id, result.Error)
+		return nil, result.Error
+	}
+
+	r.logger.Infof("GetById: successfully retrieved client from database with ID %d", id)
+	return &client, nil
+}
+
+func (r *ClientRepositoryImpl) GetAll() ([]models.Client, error) {
+	var clients []models.Client
+	result := r.db.Order("name").Find(&clients)
+	if result.Error != nil {
+		r.logger.Errorf("GetAll: failed to fetch all clients from database: %v", result.Error)
+		return nil, result.Error
+	}
+
+	r.logger.Infof("GetAll: successfully fetched %d clients from database", len(clients))
+	return clients, nil
+}
+
+func (r *ClientRepositoryImpl) Update(client *models.Client) error {
+	r.logger.Infof("Update: updating client in database with ID %d", client.ID)
+	if err := r.db.Save(client).Error; err != nil {
+		r.logger.Errorf("Update: failed to update client in database with ID %d: %v", client.ID, err)
+		return err
+	}
+
+	r.logger.Infof("Update: client with ID %d updated successfully in database", client.ID)
+	return nil
+}
+
+func (r *ClientRepositoryImpl) Delete(id uint) error {
+	r.logger.Infof("Delete: deleting client from database with ID %d", id)
+	var projectCount int64
+	if err := r.db.Model(&models.Project{}).Where("client_id = ?", id).Count(&projectCount).Error; err != nil {
+		r.logger.Errorf("Delete: failed to count projects of client with ID %d: %v", id, err)
+		return err
+	}
+	if projectCount > 0 {
+		r.logger.Debugf("Delete: client with ID %d has %d projects", id, projectCount)
+		return models.ErrClientInUse
+	}
+
+	if err := r.db.Delete(&models.Client{}, id).Error; err != nil {
+		r.logger.Errorf("Delete: failed to delete client from database with ID %d: %v", id, err)
+		return err
+	}
+
+	r.logger.Infof("Delete: client with ID %d deleted from database successfully", id)
+	return nil
+}
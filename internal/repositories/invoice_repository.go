@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"time"
+)
+
+type InvoiceRepository interface {
+	GetUnbilledTasks(clientID uint, periodStart, periodEnd time.Time) ([]models.Task, error)
+	Create(invoice *models.Invoice) error
+	GetById(id uint) (*models.Invoice, error)
+	GetAll(clientID *uint) ([]models.Invoice, error)
+}
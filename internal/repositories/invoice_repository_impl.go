@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	invoiceNumberFormat = "INV-%06d"
+
+	// invoiceSequenceLock is the advisory lock key that serialises invoice numbering.
+	invoiceSequenceLock = 6001
+)
+
+type InvoiceRepositoryImpl struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewInvoiceRepositoryImpl(db *gorm.DB, logger *logrus.Logger) *InvoiceRepositoryImpl {
+	return &InvoiceRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetUnbilledTasks returns the stopped billable tasks of the client's projects that
+// started within [periodStart, periodEnd) and are not on an invoice yet.
+func (r *InvoiceRepositoryImpl) GetUnbilledTasks(clientID uint, periodStart, periodEnd time.Time) ([]models.Task, error) {
+	var tasks []models.Task
+	r.logger.Infof("GetUnbilledTasks: fetching unbilled tasks for client ID: %d", clientID)
+	clientProjects := r.db.Model(&models.Project{}).Select("id").Where("client_id = ?", clientID)
+	err := r.db.
+		Where("project_id IN (?)", clientProjects).
+		Where("status = ? AND billable AND invoice_id IS NULL", models.TaskStatusStopped).
+		Where("start_time >= ? AND start_time < ?", periodStart, periodEnd).
+		Order("start_time").
+		Find(&tasks).Error
+	if err != nil {
+		r.logger.Errorf("GetUnbilledTasks: failed to fetch unbilled tasks: %v", err)
+		return nil, err
+	}
+
+	r.logger.Infof("GetUnbilledTasks: fetched %d unbilled tasks for client ID: %d", len(tasks), clientID)
+	return tasks, nil
+}
+
+// Create numbers the invoice, stores it with its lines and marks the billed tasks.
+// It fails with ErrTaskInvoiced if any of the tasks was billed in the meantime.
+func (r *InvoiceRepositoryImpl) Create(invoice *models.Invoice) error {
+	r.logger.Infof("Create: creating invoice in database for client ID %d with %d lines", invoice.ClientID, len(invoice.Lines))
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", invoiceSequenceLock).Error; err != nil {
+			return err
+		}
+
+		var lastSequence int
+		if err := tx.Model(&models.Invoice{}).Select("COALESCE(MAX(sequence), 0)").Scan(&lastSequence).Error; err != nil {
+			return err
+		}
+		invoice.Sequence = lastSequence + 1
+		invoice.Number = fmt.Sprintf(invoiceNumberFormat, invoice.Sequence)
+
+		if err := tx.Create(invoice).Error; err != nil {
+			return err
+		}
+
+		taskIDs := make([]uint, len(invoice.Lines))
+		for i, line := range invoice.Lines {
+			taskIDs[i] = line.TaskID
+		}
+
+		result := tx.Model(&models.Task{}).
+			Where("id IN ? AND invoice_id IS NULL", taskIDs).
+			Update("invoice_id", invoice.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(taskIDs)) {
+			return models.ErrTaskInvoiced
+		}
+
+		return nil
+	})
+	if err != nil {
+		r.logger.Errorf("Create: failed to create invoice in database: %v", err)
+		return err
+	}
+
+	r.logger.Infof("Create: invoice %s created in database successfully with ID %d", invoice.Number, invoice.ID)
+	return nil
+}
+
+func (r *InvoiceRepositoryImpl) GetById(id uint) (*models.Invoice, error) {
+	var invoice models.Invoice
+	result := r.db.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).First(&invoice, id)
+	if result.Error != nil {
+		r.logger.Errorf("GetById: failed to get invoice from database with ID %d: %v", id, result.Error)
+		return nil, result.Error
+	}
+
+	r.logger.Infof("GetById: successfully retrieved invoice from database with ID %d", id)
+	return &invoice, nil
+}
+
+func (r *InvoiceRepositoryImpl) GetAll(clientID *uint) ([]models.Invoice, error) {
+	var invoices []models.Invoice
+	query := r.db.Model(&models.Invoice{})
+	if clientID != nil {
+		query = query.Where("client_id = ?", *clientID)
+	}
+
+	result := query.Order("sequence DESC").Find(&invoices)
+	if result.Error != nil {
+		r.logger.Errorf("GetAll: failed to fetch invoices from database: %v", result.Error)
+		return nil, result.Error
+	}
+
+	r.logger.Infof("GetAll: successfully fetched %d invoices from database", len(invoices))
+	return invoices, nil
+}
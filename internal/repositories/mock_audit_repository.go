@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: F:\Time-Tracker\internal\repositories\audit_repository.go
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	reflect "reflect"
+
+	models "github.com/Dor1ma/Time-Tracker/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAuditRepository is a mock of AuditRepository interface.
+type MockAuditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditRepositoryMockRecorder
+}
+
+// MockAuditRepositoryMockRecorder is the mock recorder for MockAuditRepository.
+type MockAuditRepositoryMockRecorder struct {
+	mock *MockAuditRepository
+}
+
+// NewMockAuditRepository creates a new mock instance.
+func NewMockAuditRepository(ctrl *gomock.Controller) *MockAuditRepository {
+	mock := &MockAuditRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditRepository) EXPECT() *MockAuditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetEntries mocks base method.
+func (m *MockAuditRepository) GetEntries(filter AuditFilter, limit int) ([]models.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntries", filter, limit)
+	ret0, _ := ret[0].([]models.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntries indicates an expected call of GetEntries.
+func (mr *MockAuditRepositoryMockRecorder) GetEntries(filter, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntries", reflect.TypeOf((*MockAuditRepository)(nil).GetEntries), filter, limit)
+}
+
+// GetEntriesInBatches mocks base method.
+func (m *MockAuditRepository) GetEntriesInBatches(filter AuditFilter, batchSize int, visit func([]models.AuditEntry) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntriesInBatches", filter, batchSize, visit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetEntriesInBatches indicates an expected call of GetEntriesInBatches.
+func (mr *MockAuditRepositoryMockRecorder) GetEntriesInBatches(filter, batchSize, visit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntriesInBatches", reflect.TypeOf((*MockAuditRepository)(nil).GetEntriesInBatches), filter, batchSize, visit)
+}
+
+// GetUserEntriesInBatches mocks base method.
+func (m *MockAuditRepository) GetUserEntriesInBatches(userID uint, batchSize int, visit func([]models.AuditEntry) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserEntriesInBatches", userID, batchSize, visit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetUserEntriesInBatches indicates an expected call of GetUserEntriesInBatches.
+func (mr *MockAuditRepositoryMockRecorder) GetUserEntriesInBatches(userID, batchSize, visit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserEntriesInBatches", reflect.TypeOf((*MockAuditRepository)(nil).GetUserEntriesInBatches), userID, batchSize, visit)
+}
@@ -0,0 +1,107 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: F:\Time-Tracker\internal\repositories\client_repository.go
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	reflect "reflect"
+
+	models "github.com/Dor1ma/Time-Tracker/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockClientRepository is a mock of ClientRepository interface.
+type MockClientRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientRepositoryMockRecorder
+}
+
+// MockClientRepositoryMockRecorder is the mock recorder for MockClientRepository.
+type MockClientRepositoryMockRecorder struct {
+	mock *MockClientRepository
+}
+
+// NewMockClientRepository creates a new mock instance.
+func NewMockClientRepository(ctrl *gomock.Controller) *MockClientRepository {
+	mock := &MockClientRepository{ctrl: ctrl}
+	mock.recorder = &MockClientRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClientRepository) EXPECT() *MockClientRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockClientRepository) Create(client *models.Client) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", client)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockClientRepositoryMockRecorder) Create(client interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockClientRepository)(nil).Create), client)
+}
+
+// Delete mocks base method.
+func (m *MockClientRepository) Delete(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockClientRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockClientRepository)(nil).Delete), id)
+}
+
+// GetAll mocks base method.
+func (m *MockClientRepository) GetAll() ([]models.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]models.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockClientRepositoryMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockClientRepository)(nil).GetAll))
+}
+
+// GetById mocks base method.
+func (m *MockClientRepository) GetById(id uint) (*models.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", id)
+	ret0, _ := ret[0].(*models.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockClientRepositoryMockRecorder) GetById(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockClientRepository)(nil).GetById), id)
+}
+
+// Update mocks base method.
+func (m *MockClientRepository) Update(client *models.Client) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", client)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockClientRepositoryMockRecorder) Update(client interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockClientRepository)(nil).Update), client)
+}
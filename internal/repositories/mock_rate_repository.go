@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: F:\Time-Tracker\internal\repositories\rate_repository.go
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	reflect "reflect"
+
+	models "github.com/Dor1ma/Time-Tracker/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRateRepository is a mock of RateRepository interface.
+type MockRateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateRepositoryMockRecorder
+}
+
+// MockRateRepositoryMockRecorder is the mock recorder for MockRateRepository.
+type MockRateRepositoryMockRecorder struct {
+	mock *MockRateRepository
+}
+
+// NewMockRateRepository creates a new mock instance.
+func NewMockRateRepository(ctrl *gomock.Controller) *MockRateRepository {
+	mock := &MockRateRepository{ctrl: ctrl}
+	mock.recorder = &MockRateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateRepository) EXPECT() *MockRateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRateRepository) Create(rate *models.Rate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", rate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRateRepositoryMockRecorder) Create(rate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRateRepository)(nil).Create), rate)
+}
+
+// Delete mocks base method.
+func (m *MockRateRepository) Delete(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRateRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRateRepository)(nil).Delete), id)
+}
+
+// GetAll mocks base method.
+func (m *MockRateRepository) GetAll(userID, projectID, clientID *uint) ([]models.Rate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userID, projectID, clientID)
+	ret0, _ := ret[0].([]models.Rate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockRateRepositoryMockRecorder) GetAll(userID, projectID, clientID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRateRepository)(nil).GetAll), userID, projectID, clientID)
+}
+
+// GetForClient mocks base method.
+func (m *MockRateRepository) GetForClient(clientID uint) ([]models.Rate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForClient", clientID)
+	ret0, _ := ret[0].([]models.Rate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForClient indicates an expected call of GetForClient.
+func (mr *MockRateRepositoryMockRecorder) GetForClient(clientID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForClient", reflect.TypeOf((*MockRateRepository)(nil).GetForClient), clientID)
+}
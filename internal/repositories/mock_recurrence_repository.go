@@ -0,0 +1,137 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: F:\Time-Tracker\internal\repositories\recurrence_repository.go
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/Dor1ma/Time-Tracker/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRecurrenceRepository is a mock of RecurrenceRepository interface.
+type MockRecurrenceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecurrenceRepositoryMockRecorder
+}
+
+// MockRecurrenceRepositoryMockRecorder is the mock recorder for MockRecurrenceRepository.
+type MockRecurrenceRepositoryMockRecorder struct {
+	mock *MockRecurrenceRepository
+}
+
+// NewMockRecurrenceRepository creates a new mock instance.
+func NewMockRecurrenceRepository(ctrl *gomock.Controller) *MockRecurrenceRepository {
+	mock := &MockRecurrenceRepository{ctrl: ctrl}
+	mock.recorder = &MockRecurrenceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecurrenceRepository) EXPECT() *MockRecurrenceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddSkip mocks base method.
+func (m *MockRecurrenceRepository) AddSkip(skip *models.RecurrenceSkip, from, to time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSkip", skip, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSkip indicates an expected call of AddSkip.
+func (mr *MockRecurrenceRepositoryMockRecorder) AddSkip(skip, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSkip", reflect.TypeOf((*MockRecurrenceRepository)(nil).AddSkip), skip, from, to)
+}
+
+// Create mocks base method.
+func (m *MockRecurrenceRepository) Create(recurrence *models.Recurrence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", recurrence)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRecurrenceRepositoryMockRecorder) Create(recurrence interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRecurrenceRepository)(nil).Create), recurrence)
+}
+
+// CreateOccurrence mocks base method.
+func (m *MockRecurrenceRepository) CreateOccurrence(ctx context.Context, recurrenceID uint, task *models.Task, planned bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOccurrence", ctx, recurrenceID, task, planned)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOccurrence indicates an expected call of CreateOccurrence.
+func (mr *MockRecurrenceRepositoryMockRecorder) CreateOccurrence(ctx, recurrenceID, task, planned interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOccurrence", reflect.TypeOf((*MockRecurrenceRepository)(nil).CreateOccurrence), ctx, recurrenceID, task, planned)
+}
+
+// Delete mocks base method.
+func (m *MockRecurrenceRepository) Delete(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRecurrenceRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRecurrenceRepository)(nil).Delete), id)
+}
+
+// GetAll mocks base method.
+func (m *MockRecurrenceRepository) GetAll(templateID *uint) ([]models.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", templateID)
+	ret0, _ := ret[0].([]models.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockRecurrenceRepositoryMockRecorder) GetAll(templateID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRecurrenceRepository)(nil).GetAll), templateID)
+}
+
+// GetById mocks base method.
+func (m *MockRecurrenceRepository) GetById(id uint) (*models.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", id)
+	ret0, _ := ret[0].(*models.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockRecurrenceRepositoryMockRecorder) GetById(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockRecurrenceRepository)(nil).GetById), id)
+}
+
+// PassOccurrence mocks base method.
+func (m *MockRecurrenceRepository) PassOccurrence(recurrenceID uint, occurrence time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PassOccurrence", recurrenceID, occurrence)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PassOccurrence indicates an expected call of PassOccurrence.
+func (mr *MockRecurrenceRepositoryMockRecorder) PassOccurrence(recurrenceID, occurrence interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PassOccurrence", reflect.TypeOf((*MockRecurrenceRepository)(nil).PassOccurrence), recurrenceID, occurrence)
+}
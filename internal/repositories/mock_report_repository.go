@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: F:\Time-Tracker\internal\repositories\report_repository.go
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReportRepository is a mock of ReportRepository interface.
+type MockReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportRepositoryMockRecorder
+}
+
+// MockReportRepositoryMockRecorder is the mock recorder for MockReportRepository.
+type MockReportRepositoryMockRecorder struct {
+	mock *MockReportRepository
+}
+
+// NewMockReportRepository creates a new mock instance.
+func NewMockReportRepository(ctrl *gomock.Controller) *MockReportRepository {
+	mock := &MockReportRepository{ctrl: ctrl}
+	mock.recorder = &MockReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportRepository) EXPECT() *MockReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountPivotRows mocks base method.
+func (m *MockReportRepository) CountPivotRows(filter PivotFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPivotRows", filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPivotRows indicates an expected call of CountPivotRows.
+func (mr *MockReportRepositoryMockRecorder) CountPivotRows(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPivotRows", reflect.TypeOf((*MockReportRepository)(nil).CountPivotRows), filter)
+}
+
+// GetPivotCells mocks base method.
+func (m *MockReportRepository) GetPivotCells(filter PivotFilter, visit func(PivotCell) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPivotCells", filter, visit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetPivotCells indicates an expected call of GetPivotCells.
+func (mr *MockReportRepositoryMockRecorder) GetPivotCells(filter, visit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPivotCells", reflect.TypeOf((*MockReportRepository)(nil).GetPivotCells), filter, visit)
+}
+
+// GetPivotColumns mocks base method.
+func (m *MockReportRepository) GetPivotColumns(filter PivotFilter) ([]SummaryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPivotColumns", filter)
+	ret0, _ := ret[0].([]SummaryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPivotColumns indicates an expected call of GetPivotColumns.
+func (mr *MockReportRepositoryMockRecorder) GetPivotColumns(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPivotColumns", reflect.TypeOf((*MockReportRepository)(nil).GetPivotColumns), filter)
+}
+
+// GetUserSummary mocks base method.
+func (m *MockReportRepository) GetUserSummary(filter SummaryFilter) ([]SummaryTaskRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSummary", filter)
+	ret0, _ := ret[0].([]SummaryTaskRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSummary indicates an expected call of GetUserSummary.
+func (mr *MockReportRepositoryMockRecorder) GetUserSummary(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSummary", reflect.TypeOf((*MockReportRepository)(nil).GetUserSummary), filter)
+}
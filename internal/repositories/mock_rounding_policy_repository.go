@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: F:\Time-Tracker\internal\repositories\rounding_policy_repository.go
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	reflect "reflect"
+
+	models "github.com/Dor1ma/Time-Tracker/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRoundingPolicyRepository is a mock of RoundingPolicyRepository interface.
+type MockRoundingPolicyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoundingPolicyRepositoryMockRecorder
+}
+
+// MockRoundingPolicyRepositoryMockRecorder is the mock recorder for MockRoundingPolicyRepository.
+type MockRoundingPolicyRepositoryMockRecorder struct {
+	mock *MockRoundingPolicyRepository
+}
+
+// NewMockRoundingPolicyRepository creates a new mock instance.
+func NewMockRoundingPolicyRepository(ctrl *gomock.Controller) *MockRoundingPolicyRepository {
+	mock := &MockRoundingPolicyRepository{ctrl: ctrl}
+	mock.recorder = &MockRoundingPolicyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRoundingPolicyRepository) EXPECT() *MockRoundingPolicyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockRoundingPolicyRepository) Delete(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRoundingPolicyRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRoundingPolicyRepository)(nil).Delete), id)
+}
+
+// GetAll mocks base method.
+func (m *MockRoundingPolicyRepository) GetAll() ([]models.RoundingPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]models.RoundingPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockRoundingPolicyRepositoryMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRoundingPolicyRepository)(nil).GetAll))
+}
+
+// Set mocks base method.
+func (m *MockRoundingPolicyRepository) Set(policy *models.RoundingPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockRoundingPolicyRepositoryMockRecorder) Set(policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockRoundingPolicyRepository)(nil).Set), policy)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: F:\Time-Tracker\internal\repositories\task_event_repository.go
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/Dor1ma/Time-Tracker/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTaskEventRepository is a mock of TaskEventRepository interface.
+type MockTaskEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskEventRepositoryMockRecorder
+}
+
+// MockTaskEventRepositoryMockRecorder is the mock recorder for MockTaskEventRepository.
+type MockTaskEventRepositoryMockRecorder struct {
+	mock *MockTaskEventRepository
+}
+
+// NewMockTaskEventRepository creates a new mock instance.
+func NewMockTaskEventRepository(ctrl *gomock.Controller) *MockTaskEventRepository {
+	mock := &MockTaskEventRepository{ctrl: ctrl}
+	mock.recorder = &MockTaskEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskEventRepository) EXPECT() *MockTaskEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteBefore mocks base method.
+func (m *MockTaskEventRepository) DeleteBefore(before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBefore", before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBefore indicates an expected call of DeleteBefore.
+func (mr *MockTaskEventRepositoryMockRecorder) DeleteBefore(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBefore", reflect.TypeOf((*MockTaskEventRepository)(nil).DeleteBefore), before)
+}
+
+// GetAfter mocks base method.
+func (m *MockTaskEventRepository) GetAfter(afterID uint64, userIDs []uint, limit int) ([]models.TaskEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAfter", afterID, userIDs, limit)
+	ret0, _ := ret[0].([]models.TaskEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAfter indicates an expected call of GetAfter.
+func (mr *MockTaskEventRepositoryMockRecorder) GetAfter(afterID, userIDs, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAfter", reflect.TypeOf((*MockTaskEventRepository)(nil).GetAfter), afterID, userIDs, limit)
+}
+
+// GetSince mocks base method.
+func (m *MockTaskEventRepository) GetSince(since time.Time, afterID uint64, limit int) ([]models.TaskEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSince", since, afterID, limit)
+	ret0, _ := ret[0].([]models.TaskEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSince indicates an expected call of GetSince.
+func (mr *MockTaskEventRepositoryMockRecorder) GetSince(since, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSince", reflect.TypeOf((*MockTaskEventRepository)(nil).GetSince), since, afterID, limit)
+}
@@ -5,6 +5,7 @@
 package repositories
 
 import (
+	context "context"
 	reflect "reflect"
 	time "time"
 
@@ -35,47 +36,238 @@ func (m *MockTaskRepository) EXPECT() *MockTaskRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AdvanceFocusSession mocks base method.
+func (m *MockTaskRepository) AdvanceFocusSession(ctx context.Context, taskID uint, now time.Time) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdvanceFocusSession", ctx, taskID, now)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdvanceFocusSession indicates an expected call of AdvanceFocusSession.
+func (mr *MockTaskRepositoryMockRecorder) AdvanceFocusSession(ctx, taskID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvanceFocusSession", reflect.TypeOf((*MockTaskRepository)(nil).AdvanceFocusSession), ctx, taskID, now)
+}
+
+// AutoStopTask mocks base method.
+func (m *MockTaskRepository) AutoStopTask(ctx context.Context, taskID uint, stopAt time.Time, reason string) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AutoStopTask", ctx, taskID, stopAt, reason)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AutoStopTask indicates an expected call of AutoStopTask.
+func (mr *MockTaskRepositoryMockRecorder) AutoStopTask(ctx, taskID, stopAt, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutoStopTask", reflect.TypeOf((*MockTaskRepository)(nil).AutoStopTask), ctx, taskID, stopAt, reason)
+}
+
+// ConfirmPlannedTask mocks base method.
+func (m *MockTaskRepository) ConfirmPlannedTask(ctx context.Context, taskID uint) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmPlannedTask", ctx, taskID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmPlannedTask indicates an expected call of ConfirmPlannedTask.
+func (mr *MockTaskRepositoryMockRecorder) ConfirmPlannedTask(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPlannedTask", reflect.TypeOf((*MockTaskRepository)(nil).ConfirmPlannedTask), ctx, taskID)
+}
+
+// CreateTask mocks base method.
+func (m *MockTaskRepository) CreateTask(ctx context.Context, task *models.Task) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTask", ctx, task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTask indicates an expected call of CreateTask.
+func (mr *MockTaskRepositoryMockRecorder) CreateTask(ctx, task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTaskRepository)(nil).CreateTask), ctx, task)
+}
+
+// GetDueFocusSessions mocks base method.
+func (m *MockTaskRepository) GetDueFocusSessions(now time.Time) ([]models.FocusSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueFocusSessions", now)
+	ret0, _ := ret[0].([]models.FocusSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueFocusSessions indicates an expected call of GetDueFocusSessions.
+func (mr *MockTaskRepositoryMockRecorder) GetDueFocusSessions(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueFocusSessions", reflect.TypeOf((*MockTaskRepository)(nil).GetDueFocusSessions), now)
+}
+
+// GetImportedKeys mocks base method.
+func (m *MockTaskRepository) GetImportedKeys(keys []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportedKeys", keys)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImportedKeys indicates an expected call of GetImportedKeys.
+func (mr *MockTaskRepositoryMockRecorder) GetImportedKeys(keys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportedKeys", reflect.TypeOf((*MockTaskRepository)(nil).GetImportedKeys), keys)
+}
+
+// GetRunningTask mocks base method.
+func (m *MockTaskRepository) GetRunningTask(userID uint) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRunningTask", userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRunningTask indicates an expected call of GetRunningTask.
+func (mr *MockTaskRepositoryMockRecorder) GetRunningTask(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunningTask", reflect.TypeOf((*MockTaskRepository)(nil).GetRunningTask), userID)
+}
+
+// GetRunningTasks mocks base method.
+func (m *MockTaskRepository) GetRunningTasks() ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRunningTasks")
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRunningTasks indicates an expected call of GetRunningTasks.
+func (mr *MockTaskRepositoryMockRecorder) GetRunningTasks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunningTasks", reflect.TypeOf((*MockTaskRepository)(nil).GetRunningTasks))
+}
+
+// GetTask mocks base method.
+func (m *MockTaskRepository) GetTask(taskID uint) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTask", taskID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTask indicates an expected call of GetTask.
+func (mr *MockTaskRepositoryMockRecorder) GetTask(taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockTaskRepository)(nil).GetTask), taskID)
+}
+
 // GetUserTasks mocks base method.
-func (m *MockTaskRepository) GetUserTasks(userID uint, startDate, endDate time.Time) ([]models.Task, error) {
+func (m *MockTaskRepository) GetUserTasks(filter TaskFilter) ([]models.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserTasks", userID, startDate, endDate)
+	ret := m.ctrl.Call(m, "GetUserTasks", filter)
 	ret0, _ := ret[0].([]models.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserTasks indicates an expected call of GetUserTasks.
-func (mr *MockTaskRepositoryMockRecorder) GetUserTasks(userID, startDate, endDate interface{}) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) GetUserTasks(filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTasks", reflect.TypeOf((*MockTaskRepository)(nil).GetUserTasks), userID, startDate, endDate)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTasks", reflect.TypeOf((*MockTaskRepository)(nil).GetUserTasks), filter)
 }
 
-// StartTask mocks base method.
-func (m *MockTaskRepository) StartTask(userID uint, taskName string) (*models.Task, error) {
+// GetUserTasksInBatches mocks base method.
+func (m *MockTaskRepository) GetUserTasksInBatches(filter TaskFilter, batchSize int, visit func([]models.Task) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTasksInBatches", filter, batchSize, visit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetUserTasksInBatches indicates an expected call of GetUserTasksInBatches.
+func (mr *MockTaskRepositoryMockRecorder) GetUserTasksInBatches(filter, batchSize, visit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTasksInBatches", reflect.TypeOf((*MockTaskRepository)(nil).GetUserTasksInBatches), filter, batchSize, visit)
+}
+
+// PauseTask mocks base method.
+func (m *MockTaskRepository) PauseTask(ctx context.Context, taskID uint) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseTask", ctx, taskID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PauseTask indicates an expected call of PauseTask.
+func (mr *MockTaskRepositoryMockRecorder) PauseTask(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseTask", reflect.TypeOf((*MockTaskRepository)(nil).PauseTask), ctx, taskID)
+}
+
+// ResumeTask mocks base method.
+func (m *MockTaskRepository) ResumeTask(ctx context.Context, taskID uint, stopRunning bool) (*models.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StartTask", userID, taskName)
+	ret := m.ctrl.Call(m, "ResumeTask", ctx, taskID, stopRunning)
 	ret0, _ := ret[0].(*models.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// ResumeTask indicates an expected call of ResumeTask.
+func (mr *MockTaskRepositoryMockRecorder) ResumeTask(ctx, taskID, stopRunning interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeTask", reflect.TypeOf((*MockTaskRepository)(nil).ResumeTask), ctx, taskID, stopRunning)
+}
+
+// StartTask mocks base method.
+func (m *MockTaskRepository) StartTask(ctx context.Context, task *models.Task, stopRunning bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTask", ctx, task, stopRunning)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
 // StartTask indicates an expected call of StartTask.
-func (mr *MockTaskRepositoryMockRecorder) StartTask(userID, taskName interface{}) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) StartTask(ctx, task, stopRunning interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTask", reflect.TypeOf((*MockTaskRepository)(nil).StartTask), userID, taskName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTask", reflect.TypeOf((*MockTaskRepository)(nil).StartTask), ctx, task, stopRunning)
 }
 
 // StopTask mocks base method.
-func (m *MockTaskRepository) StopTask(taskID uint) (*models.Task, error) {
+func (m *MockTaskRepository) StopTask(ctx context.Context, taskID uint) (*models.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StopTask", taskID)
+	ret := m.ctrl.Call(m, "StopTask", ctx, taskID)
 	ret0, _ := ret[0].(*models.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // StopTask indicates an expected call of StopTask.
-func (mr *MockTaskRepositoryMockRecorder) StopTask(taskID interface{}) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) StopTask(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTask", reflect.TypeOf((*MockTaskRepository)(nil).StopTask), ctx, taskID)
+}
+
+// UpdateTask mocks base method.
+func (m *MockTaskRepository) UpdateTask(ctx context.Context, task *models.Task, intervalChanged bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTask", ctx, task, intervalChanged)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTask indicates an expected call of UpdateTask.
+func (mr *MockTaskRepositoryMockRecorder) UpdateTask(ctx, task, intervalChanged interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTask", reflect.TypeOf((*MockTaskRepository)(nil).StopTask), taskID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskRepository)(nil).UpdateTask), ctx, task, intervalChanged)
 }
@@ -0,0 +1,107 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: F:\Time-Tracker\internal\repositories\task_template_repository.go
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	reflect "reflect"
+
+	models "github.com/Dor1ma/Time-Tracker/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTaskTemplateRepository is a mock of TaskTemplateRepository interface.
+type MockTaskTemplateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskTemplateRepositoryMockRecorder
+}
+
+// MockTaskTemplateRepositoryMockRecorder is the mock recorder for MockTaskTemplateRepository.
+type MockTaskTemplateRepositoryMockRecorder struct {
+	mock *MockTaskTemplateRepository
+}
+
+// NewMockTaskTemplateRepository creates a new mock instance.
+func NewMockTaskTemplateRepository(ctrl *gomock.Controller) *MockTaskTemplateRepository {
+	mock := &MockTaskTemplateRepository{ctrl: ctrl}
+	mock.recorder = &MockTaskTemplateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskTemplateRepository) EXPECT() *MockTaskTemplateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTaskTemplateRepository) Create(template *models.TaskTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTaskTemplateRepositoryMockRecorder) Create(template interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTaskTemplateRepository)(nil).Create), template)
+}
+
+// Delete mocks base method.
+func (m *MockTaskTemplateRepository) Delete(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTaskTemplateRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTaskTemplateRepository)(nil).Delete), id)
+}
+
+// GetAll mocks base method.
+func (m *MockTaskTemplateRepository) GetAll(userID *uint) ([]models.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userID)
+	ret0, _ := ret[0].([]models.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTaskTemplateRepositoryMockRecorder) GetAll(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTaskTemplateRepository)(nil).GetAll), userID)
+}
+
+// GetById mocks base method.
+func (m *MockTaskTemplateRepository) GetById(id uint) (*models.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", id)
+	ret0, _ := ret[0].(*models.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockTaskTemplateRepositoryMockRecorder) GetById(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTaskTemplateRepository)(nil).GetById), id)
+}
+
+// Update mocks base method.
+func (m *MockTaskTemplateRepository) Update(template *models.TaskTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTaskTemplateRepositoryMockRecorder) Update(template interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTaskTemplateRepository)(nil).Update), template)
+}
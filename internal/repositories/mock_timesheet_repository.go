@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: F:\Time-Tracker\internal\repositories\timesheet_repository.go
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	reflect "reflect"
+
+	models "github.com/Dor1ma/Time-Tracker/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTimesheetRepository is a mock of TimesheetRepository interface.
+type MockTimesheetRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimesheetRepositoryMockRecorder
+}
+
+// MockTimesheetRepositoryMockRecorder is the mock recorder for MockTimesheetRepository.
+type MockTimesheetRepositoryMockRecorder struct {
+	mock *MockTimesheetRepository
+}
+
+// NewMockTimesheetRepository creates a new mock instance.
+func NewMockTimesheetRepository(ctrl *gomock.Controller) *MockTimesheetRepository {
+	mock := &MockTimesheetRepository{ctrl: ctrl}
+	mock.recorder = &MockTimesheetRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTimesheetRepository) EXPECT() *MockTimesheetRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTimesheetRepository) Create(timesheet *models.Timesheet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", timesheet)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTimesheetRepositoryMockRecorder) Create(timesheet interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTimesheetRepository)(nil).Create), timesheet)
+}
+
+// GetAll mocks base method.
+func (m *MockTimesheetRepository) GetAll(userID *uint, status string) ([]models.Timesheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userID, status)
+	ret0, _ := ret[0].([]models.Timesheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTimesheetRepositoryMockRecorder) GetAll(userID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTimesheetRepository)(nil).GetAll), userID, status)
+}
+
+// GetById mocks base method.
+func (m *MockTimesheetRepository) GetById(id uint) (*models.Timesheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", id)
+	ret0, _ := ret[0].(*models.Timesheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockTimesheetRepositoryMockRecorder) GetById(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTimesheetRepository)(nil).GetById), id)
+}
+
+// Transition mocks base method.
+func (m *MockTimesheetRepository) Transition(id uint, from []string, to string, actorID uint, comment string) (*models.Timesheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transition", id, from, to, actorID, comment)
+	ret0, _ := ret[0].(*models.Timesheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transition indicates an expected call of Transition.
+func (mr *MockTimesheetRepositoryMockRecorder) Transition(id, from, to, actorID, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transition", reflect.TypeOf((*MockTimesheetRepository)(nil).Transition), id, from, to, actorID, comment)
+}
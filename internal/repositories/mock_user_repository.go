@@ -5,7 +5,9 @@
 package repositories
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/Dor1ma/Time-Tracker/internal/models"
 	gomock "github.com/golang/mock/gomock"
@@ -35,31 +37,46 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockUserRepository) Create(user *models.User) error {
+func (m *MockUserRepository) Create(ctx context.Context, user *models.User) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", user)
+	ret := m.ctrl.Call(m, "Create", ctx, user)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockUserRepositoryMockRecorder) Create(user interface{}) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) Create(ctx, user interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserRepository)(nil).Create), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserRepository)(nil).Create), ctx, user)
 }
 
 // Delete mocks base method.
-func (m *MockUserRepository) Delete(id uint) error {
+func (m *MockUserRepository) Delete(ctx context.Context, id uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockUserRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepository)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepository)(nil).Delete), ctx, id)
+}
+
+// Erase mocks base method.
+func (m *MockUserRepository) Erase(ctx context.Context, id uint, erasure *models.Erasure) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Erase", ctx, id, erasure)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Erase indicates an expected call of Erase.
+func (mr *MockUserRepositoryMockRecorder) Erase(ctx, id, erasure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Erase", reflect.TypeOf((*MockUserRepository)(nil).Erase), ctx, id, erasure)
 }
 
 // GetAll mocks base method.
@@ -78,18 +95,18 @@ func (mr *MockUserRepositoryMockRecorder) GetAll() *gomock.Call {
 }
 
 // GetAllWithFiltersAndPagination mocks base method.
-func (m *MockUserRepository) GetAllWithFiltersAndPagination(filters map[string]interface{}, page, pageSize int) ([]models.User, error) {
+func (m *MockUserRepository) GetAllWithFiltersAndPagination(filters map[string]interface{}, page, pageSize int, includeDeleted bool) ([]models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllWithFiltersAndPagination", filters, page, pageSize)
+	ret := m.ctrl.Call(m, "GetAllWithFiltersAndPagination", filters, page, pageSize, includeDeleted)
 	ret0, _ := ret[0].([]models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllWithFiltersAndPagination indicates an expected call of GetAllWithFiltersAndPagination.
-func (mr *MockUserRepositoryMockRecorder) GetAllWithFiltersAndPagination(filters, page, pageSize interface{}) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) GetAllWithFiltersAndPagination(filters, page, pageSize, includeDeleted interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllWithFiltersAndPagination", reflect.TypeOf((*MockUserRepository)(nil).GetAllWithFiltersAndPagination), filters, page, pageSize)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllWithFiltersAndPagination", reflect.TypeOf((*MockUserRepository)(nil).GetAllWithFiltersAndPagination), filters, page, pageSize, includeDeleted)
 }
 
 // GetById mocks base method.
@@ -107,16 +124,104 @@ func (mr *MockUserRepositoryMockRecorder) GetById(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockUserRepository)(nil).GetById), id)
 }
 
+// GetErasure mocks base method.
+func (m *MockUserRepository) GetErasure(userID uint) (*models.Erasure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetErasure", userID)
+	ret0, _ := ret[0].(*models.Erasure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetErasure indicates an expected call of GetErasure.
+func (mr *MockUserRepositoryMockRecorder) GetErasure(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetErasure", reflect.TypeOf((*MockUserRepository)(nil).GetErasure), userID)
+}
+
+// OpenPassport mocks base method.
+func (m *MockUserRepository) OpenPassport(user *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenPassport", user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OpenPassport indicates an expected call of OpenPassport.
+func (mr *MockUserRepositoryMockRecorder) OpenPassport(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenPassport", reflect.TypeOf((*MockUserRepository)(nil).OpenPassport), user)
+}
+
+// Purge mocks base method.
+func (m *MockUserRepository) Purge(ctx context.Context, deletedBefore time.Time) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, deletedBefore)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockUserRepositoryMockRecorder) Purge(ctx, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockUserRepository)(nil).Purge), ctx, deletedBefore)
+}
+
+// Restore mocks base method.
+func (m *MockUserRepository) Restore(ctx context.Context, id uint) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockUserRepositoryMockRecorder) Restore(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockUserRepository)(nil).Restore), ctx, id)
+}
+
+// SealPassports mocks base method.
+func (m *MockUserRepository) SealPassports(limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SealPassports", limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SealPassports indicates an expected call of SealPassports.
+func (mr *MockUserRepositoryMockRecorder) SealPassports(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SealPassports", reflect.TypeOf((*MockUserRepository)(nil).SealPassports), limit)
+}
+
+// SetCalendarTokenHash mocks base method.
+func (m *MockUserRepository) SetCalendarTokenHash(id uint, hash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCalendarTokenHash", id, hash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCalendarTokenHash indicates an expected call of SetCalendarTokenHash.
+func (mr *MockUserRepositoryMockRecorder) SetCalendarTokenHash(id, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCalendarTokenHash", reflect.TypeOf((*MockUserRepository)(nil).SetCalendarTokenHash), id, hash)
+}
+
 // Update mocks base method.
-func (m *MockUserRepository) Update(user *models.User) error {
+func (m *MockUserRepository) Update(ctx context.Context, user *models.User) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", user)
+	ret := m.ctrl.Call(m, "Update", ctx, user)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockUserRepositoryMockRecorder) Update(user interface{}) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) Update(ctx, user interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserRepository)(nil).Update), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserRepository)(nil).Update), ctx, user)
 }
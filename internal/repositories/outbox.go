@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"encoding/json"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// addOutboxEvent stores a domain event in the transaction of the change it reports.
+func addOutboxEvent(tx *gorm.DB, event string, aggregateID uint, data any) (*models.OutboxEvent, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	outboxEvent := &models.OutboxEvent{Event: event, AggregateID: aggregateID, Payload: string(payload)}
+	if err := tx.Create(outboxEvent).Error; err != nil {
+		return nil, err
+	}
+
+	return outboxEvent, nil
+}
+
+// addTaskEvent stores the event of a task in the outbox and, under the same ID, in the
+// task stream, both in the transaction of the change it reports.
+func addTaskEvent(tx *gorm.DB, event string, task *models.Task) error {
+	data := models.TaskEventData{
+		TaskID:      task.ID,
+		UserID:      task.UserID,
+		TaskName:    task.TaskName,
+		ProjectID:   task.ProjectID,
+		Status:      task.Status,
+		Billable:    task.Billable,
+		AutoStopped: task.AutoStopped,
+		Minutes:     task.Minutes,
+		StartTime:   task.StartTime,
+	}
+	if task.Status == models.TaskStatusStopped {
+		data.EndTime = &task.EndTime
+	}
+
+	outboxEvent, err := addOutboxEvent(tx, event, task.ID, data)
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&models.TaskEvent{
+		ID:        outboxEvent.ID,
+		UserID:    task.UserID,
+		Event:     event,
+		Payload:   outboxEvent.Payload,
+		CreatedAt: outboxEvent.CreatedAt,
+	}).Error
+}
+
+func addUserEvent(tx *gorm.DB, event string, userID uint) error {
+	_, err := addOutboxEvent(tx, event, userID, models.UserEventData{UserID: userID})
+	return err
+}
@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+)
+
+type OutboxRepository interface {
+	PublishPending(now time.Time, limit int, publish func(event *models.OutboxEvent)) (int, error)
+	DeletePublishedBefore(before time.Time) (int64, error)
+}
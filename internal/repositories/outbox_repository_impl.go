@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxRepositoryImpl struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewOutboxRepositoryImpl(db *gorm.DB, logger *logrus.Logger) *OutboxRepositoryImpl {
+	return &OutboxRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// PublishPending locks up to limit unpublished events due by now, oldest first, hands each
+// to publish and saves what publish recorded in it: PublishedAt once it is published, or the
+// attempt that failed. The lock keeps other instances from publishing the same events
+// meanwhile. It returns the number of events handed to publish.
+func (r *OutboxRepositoryImpl) PublishPending(now time.Time, limit int, publish func(event *models.OutboxEvent)) (int, error) {
+	var events []models.OutboxEvent
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("published_at IS NULL AND (next_attempt_at IS NULL OR next_attempt_at <= ?)", now).
+			Order("id").
+			Limit(limit).
+			Find(&events).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range events {
+			publish(&events[i])
+			if err := tx.Save(&events[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.Errorf("PublishPending: failed to publish outbox events: %v", err)
+		return 0, err
+	}
+
+	return len(events), nil
+}
+
+func (r *OutboxRepositoryImpl) DeletePublishedBefore(before time.Time) (int64, error) {
+	result := r.db.Where("published_at < ?", before).Delete(&models.OutboxEvent{})
+	if result.Error != nil {
+		r.logger.Errorf("DeletePublishedBefore: failed to delete outbox events published before %s: %v",
+			before.Format(time.RFC3339), result.Error)
+		return 0, result.Error
+	}
+
+	r.logger.Infof("DeletePublishedBefore: deleted %d outbox events published before %s", result.RowsAffected, before.Format(time.RFC3339))
+	return result.RowsAffected, nil
+}
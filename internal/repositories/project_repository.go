@@ -0,0 +1,11 @@
+package repositories
+
+import "github.com/Dor1ma/Time-Tracker/internal/models"
+
+type ProjectRepository interface {
+	Create(project *models.Project) error
+	GetById(id uint) (*models.Project, error)
+	GetAll(clientID *uint, includeArchived bool) ([]models.Project, error)
+	Update(project *models.Project) error
+	Delete(id uint) error
+}
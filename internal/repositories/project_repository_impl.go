@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ProjectRepositoryImpl struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewProjectRepositoryImpl(db *gorm.DB, logger *logrus.Logger) *ProjectRepositoryImpl {
+	return &ProjectRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *ProjectRepositoryImpl) Create(project *models.Project) error {
+	r.logger.Infof("Create: creating project in database with name %s", project.Name)
+	if err := r.db.Omit("Client").Create(project).Error; err != nil {
+		r.logger.Errorf("Create: failed to create project in database: %v", err)
+		return err
+	}
+
+	r.logger.Infof("Create: project created in database successfully with ID %d", project.ID)
+	return nil
+}
+
+func (r *ProjectRepositoryImpl) GetById(id uint) (*models.Project, error) {
+	var project models.Project
+	result := r.db.Preload("Client").First(&project, id)
+	if result.Error != nil {
+		r.logger.Errorf("GetById: failed to get project from database with ID %d: %v", id, result.Error)
+		return nil, result.Error
+	}
+
+	r.logger.Infof("GetById: successfully retrieved project from database with ID %d", id)
+	return &project, nil
+}
+
+func (r *ProjectRepositoryImpl) GetAll(clientID *uint, includeArchived bool) ([]models.Project, error) {
+	var projects []models.Project
+	query := r.db.Preload("Client")
+	if clientID != nil {
+		query = query.Where("client_id = ?", *clientID)
+	}
+	if !includeArchived {
+		query = query.Where("archived = ?", false)
+	}
+
+	result := query.Order("name").Find(&projects)
+	if result.Error != nil {
+		r.logger.Errorf("GetAll: failed to fetch projects from database: %v", result.Error)
+		return nil, result.Error
+	}
+
+	r.logger.Infof("GetAll: successfully fetched %d projects from database", len(projects))
+	return projects, nil
+}
+
+func (r *ProjectRepositoryImpl) Update(project *models.Project) error {
+	r.logger.Infof("Update: updating project in database with ID %d", project.ID)
+	if err := r.db.Omit("Client").Save(project).Error; err != nil {
+		r.logger.Errorf("Update: failed to update project in database with ID %d: %v", project.ID, err)
+		return err
+	}
+
+	r.logger.Infof("Update: project with ID %d updated successfully in database", project.ID)
+	return nil
+}
+
+func (r *ProjectRepositoryImpl) Delete(id uint) error {
+	r.logger.Infof("Delete: deleting project from database with ID %d", id)
+	var taskCount int64
+	if err := r.db.Model(&models.Task{}).Where("project_id = ?", id).Count(&taskCount).Error; err != nil {
+		r.logger.Errorf("Delete: failed to count tasks of project with ID %d: %v", id, err)
+		return err
+	}
+	if taskCount > 0 {
+		r.logger.Debugf("Delete: project with ID %d has %d tasks", id, taskCount)
+		return models.ErrProjectInUse
+	}
+
+	if err := r.db.Delete(&models.Project{}, id).Error; err != nil {
+		r.logger.Errorf("Delete: failed to delete project from database with ID %d: %v", id, err)
+		return err
+	}
+
+	r.logger.Infof("Delete: project with ID %d deleted from database successfully", id)
+	return nil
+}
@@ -0,0 +1,10 @@
+package repositories
+
+import "github.com/Dor1ma/Time-Tracker/internal/models"
+
+type RateRepository interface {
+	Create(rate *models.Rate) error
+	GetAll(userID, projectID, clientID *uint) ([]models.Rate, error)
+	GetForClient(clientID uint) ([]models.Rate, error)
+	Delete(id uint) error
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type RateRepositoryImpl struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewRateRepositoryImpl(db *gorm.DB, logger *logrus.Logger) *RateRepositoryImpl {
+	return &RateRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *RateRepositoryImpl) Create(rate *models.Rate) error {
+	r.logger.Infof("Create: creating rate in database effective from %s", rate.EffectiveFrom.Format("2006-01-02"))
+	if err := r.db.Create(rate).Error; err != nil {
+		r.logger.Errorf("Create: failed to create rate in database: %v", err)
+		return err
+	}
+
+	r.logger.Infof("Create: rate created in database successfully with ID %d", rate.ID)
+	return nil
+}
+
+func (r *RateRepositoryImpl) GetAll(userID, projectID, clientID *uint) ([]models.Rate, error) {
+	var rates []models.Rate
+	query := r.db.Model(&models.Rate{})
+	if userID != nil {
+		query = query.Where("user_id = ?", *userID)
+	}
+	if projectID != nil {
+		query = query.Where("project_id = ?", *projectID)
+	}
+	if clientID != nil {
+		query = query.Where("client_id = ?", *clientID)
+	}
+
+	result := query.Order("effective_from DESC, id DESC").Find(&rates)
+	if result.Error != nil {
+		r.logger.Errorf("GetAll: failed to fetch rates from database: %v", result.Error)
+		return nil, result.Error
+	}
+
+	r.logger.Infof("GetAll: successfully fetched %d rates from database", len(rates))
+	return rates, nil
+}
+
+// GetForClient returns every rate that can apply to tasks of the client: rates of the
+// client itself, of its projects, and rates that are not bound to a project or client.
+func (r *RateRepositoryImpl) GetForClient(clientID uint) ([]models.Rate, error) {
+	var rates []models.Rate
+	clientProjects := r.db.Model(&models.Project{}).Select("id").Where("client_id = ?", clientID)
+	result := r.db.
+		Where("client_id = ?", clientID).
+		Or("project_id IN (?)", clientProjects).
+		Or("project_id IS NULL AND client_id IS NULL").
+		Order("effective_from DESC, id DESC").
+		Find(&rates)
+	if result.Error != nil {
+		r.logger.Errorf("GetForClient: failed to fetch rates for client with ID %d: %v", clientID, result.Error)
+		return nil, result.Error
+	}
+
+	r.logger.Infof("GetForClient: successfully fetched %d rates for client with ID %d", len(rates), clientID)
+	return rates, nil
+}
+
+func (r *RateRepositoryImpl) Delete(id uint) error {
+	r.logger.Infof("Delete: deleting rate from database with ID %d", id)
+	if err := r.db.Delete(&models.Rate{}, id).Error; err != nil {
+		r.logger.Errorf("Delete: failed to delete rate from database with ID %d: %v", id, err)
+		return err
+	}
+
+	r.logger.Infof("Delete: rate with ID %d deleted from database successfully", id)
+	return nil
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+)
+
+type RecurrenceRepository interface {
+	Create(recurrence *models.Recurrence) error
+	GetById(id uint) (*models.Recurrence, error)
+	GetAll(templateID *uint) ([]models.Recurrence, error)
+	Delete(id uint) error
+	AddSkip(skip *models.RecurrenceSkip, from, to time.Time) error
+	CreateOccurrence(ctx context.Context, recurrenceID uint, task *models.Task, planned bool) error
+	PassOccurrence(recurrenceID uint, occurrence time.Time) error
+}
@@ -0,0 +1,209 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RecurrenceRepositoryImpl struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewRecurrenceRepositoryImpl(db *gorm.DB, logger *logrus.Logger) *RecurrenceRepositoryImpl {
+	return &RecurrenceRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *RecurrenceRepositoryImpl) Create(recurrence *models.Recurrence) error {
+	r.logger.Infof("Create: creating recurrence in database for template ID %d: %s", recurrence.TemplateID, recurrence.Rule)
+	if err := r.db.Omit(clause.Associations).Create(recurrence).Error; err != nil {
+		r.logger.Errorf("Create: failed to create recurrence in database: %v", err)
+		return err
+	}
+
+	r.logger.Infof("Create: recurrence created in database successfully with ID %d", recurrence.ID)
+	return nil
+}
+
+func (r *RecurrenceRepositoryImpl) GetById(id uint) (*models.Recurrence, error) {
+	var recurrence models.Recurrence
+	if err := r.recurrencesQuery().First(&recurrence, id).Error; err != nil {
+		r.logger.Errorf("GetById: failed to get recurrence from database with ID %d: %v", id, err)
+		return nil, err
+	}
+
+	r.logger.Infof("GetById: successfully retrieved recurrence from database with ID %d", id)
+	return &recurrence, nil
+}
+
+func (r *RecurrenceRepositoryImpl) GetAll(templateID *uint) ([]models.Recurrence, error) {
+	var recurrences []models.Recurrence
+	query := r.recurrencesQuery()
+	if templateID != nil {
+		query = query.Where("template_id = ?", *templateID)
+	}
+
+	if err := query.Order("id").Find(&recurrences).Error; err != nil {
+		r.logger.Errorf("GetAll: failed to fetch recurrences from database: %v", err)
+		return nil, err
+	}
+
+	r.logger.Infof("GetAll: successfully fetched %d recurrences from database", len(recurrences))
+	return recurrences, nil
+}
+
+// Delete removes the recurrence together with the planned tasks it created that have not
+// been confirmed. Recorded tasks are kept.
+func (r *RecurrenceRepositoryImpl) Delete(id uint) error {
+	r.logger.Infof("Delete: deleting recurrence from database with ID %d", id)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("recurrence_id = ? AND status = ?", id, models.TaskStatusPlanned).
+			Delete(&models.Task{}).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Delete(&models.Recurrence{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		r.logger.Errorf("Delete: failed to delete recurrence from database with ID %d: %v", id, err)
+		return err
+	}
+
+	r.logger.Infof("Delete: recurrence with ID %d deleted from database successfully", id)
+	return nil
+}
+
+// AddSkip records the skipped date and removes the planned task of the occurrence, which starts
+// between from and to. It fails with ErrOccurrenceRecorded if that task has been recorded already.
+func (r *RecurrenceRepositoryImpl) AddSkip(skip *models.RecurrenceSkip, from, to time.Time) error {
+	r.logger.Infof("AddSkip: skipping occurrence of recurrence ID %d on %s", skip.RecurrenceID, skip.Date.Format(time.DateOnly))
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var recurrence models.Recurrence
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&recurrence, skip.RecurrenceID).Error; err != nil {
+			return err
+		}
+
+		occurrence := tx.Model(&models.Task{}).
+			Where("recurrence_id = ? AND start_time >= ? AND start_time < ?", skip.RecurrenceID, from, to)
+
+		var recorded int64
+		if err := occurrence.Where("status <> ?", models.TaskStatusPlanned).Count(&recorded).Error; err != nil {
+			return err
+		}
+		if recorded > 0 {
+			return models.ErrOccurrenceRecorded
+		}
+
+		err := tx.Where("recurrence_id = ? AND status = ? AND start_time >= ? AND start_time < ?",
+			skip.RecurrenceID, models.TaskStatusPlanned, from, to).
+			Delete(&models.Task{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(skip).Error
+	})
+	if err != nil {
+		r.logger.Errorf("AddSkip: failed to skip occurrence of recurrence ID %d: %v", skip.RecurrenceID, err)
+		return err
+	}
+
+	r.logger.Infof("AddSkip: occurrence of recurrence ID %d on %s skipped", skip.RecurrenceID, skip.Date.Format(time.DateOnly))
+	return nil
+}
+
+// CreateOccurrence stores the task of the occurrence starting at task.StartTime, either as
+// a planned task or as a recorded one, and marks the occurrence as handled. It fails with
+// ErrOccurrenceRecorded if the occurrence has been handled already.
+func (r *RecurrenceRepositoryImpl) CreateOccurrence(ctx context.Context, recurrenceID uint, task *models.Task, planned bool) error {
+	r.logger.Infof("CreateOccurrence: creating task of recurrence ID %d at %s", recurrenceID, task.StartTime.Format(time.RFC3339))
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockOccurrence(tx, recurrenceID, task.StartTime); err != nil {
+			return err
+		}
+
+		task.RecurrenceID = &recurrenceID
+		if planned {
+			if err := createPlannedTask(tx, task); err != nil {
+				return err
+			}
+		} else if err := createRecordedTask(tx, task); err != nil {
+			return err
+		}
+
+		return passOccurrence(tx, recurrenceID, task.StartTime)
+	})
+	if err != nil {
+		r.logger.Errorf("CreateOccurrence: failed to create task of recurrence ID %d: %v", recurrenceID, err)
+		return err
+	}
+
+	r.logger.Infof("CreateOccurrence: task of recurrence ID %d created with ID %d", recurrenceID, task.ID)
+	return nil
+}
+
+// PassOccurrence marks the occurrence as handled without creating its task, as when it is
+// skipped or its task cannot be created.
+func (r *RecurrenceRepositoryImpl) PassOccurrence(recurrenceID uint, occurrence time.Time) error {
+	r.logger.Infof("PassOccurrence: passing occurrence of recurrence ID %d at %s", recurrenceID, occurrence.Format(time.RFC3339))
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockOccurrence(tx, recurrenceID, occurrence); err != nil {
+			return err
+		}
+
+		return passOccurrence(tx, recurrenceID, occurrence)
+	})
+	if err != nil {
+		r.logger.Errorf("PassOccurrence: failed to pass occurrence of recurrence ID %d: %v", recurrenceID, err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *RecurrenceRepositoryImpl) recurrencesQuery() *gorm.DB {
+	return r.db.Preload("Template").
+		Preload("Template.Tags", func(db *gorm.DB) *gorm.DB {
+			return db.Order("name")
+		}).
+		Preload("Skips", func(db *gorm.DB) *gorm.DB {
+			return db.Order("date")
+		})
+}
+
+// lockOccurrence locks the recurrence and reports ErrOccurrenceRecorded if the occurrence
+// has been handled already, so that no occurrence creates two tasks.
+func lockOccurrence(tx *gorm.DB, recurrenceID uint, occurrence time.Time) error {
+	var recurrence models.Recurrence
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&recurrence, recurrenceID).Error; err != nil {
+		return err
+	}
+	if recurrence.GeneratedUntil != nil && !occurrence.After(*recurrence.GeneratedUntil) {
+		return models.ErrOccurrenceRecorded
+	}
+
+	return nil
+}
+
+func passOccurrence(tx *gorm.DB, recurrenceID uint, occurrence time.Time) error {
+	return tx.Model(&models.Recurrence{}).
+		Where("id = ?", recurrenceID).
+		Update("generated_until", occurrence).Error
+}
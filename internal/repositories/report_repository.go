@@ -0,0 +1,70 @@
+package repositories
+
+import "time"
+
+const (
+	ReportDimensionUser     = "user"
+	ReportDimensionDay      = "day"
+	ReportDimensionWeek     = "week"
+	ReportDimensionMonth    = "month"
+	ReportDimensionTaskName = "task_name"
+	ReportDimensionProject  = "project"
+	ReportDimensionClient   = "client"
+)
+
+// SummaryFilter selects the segments started within [From, To) of a user's tasks.
+// From and To are dates, taken as midnight in the time zone of the user.
+type SummaryFilter struct {
+	UserID  uint
+	From    time.Time
+	To      time.Time
+	GroupBy string
+}
+
+// SummaryRow is the tracked time of one group. GroupID is set when grouping by user, project or client.
+type SummaryRow struct {
+	GroupID   *uint
+	GroupKey  string
+	TaskCount int64
+	Seconds   int64
+}
+
+// SummaryTaskRow is the tracked time of one task within a group of a summary,
+// so that the time of every task can be rounded before the group is summed up.
+type SummaryTaskRow struct {
+	GroupID   *uint
+	GroupKey  string
+	TaskID    uint
+	ProjectID *uint
+	ClientID  *uint
+	Seconds   int64
+}
+
+// PivotFilter selects the segments started within [From, To) of the tasks of UserIDs,
+// or of all users when UserIDs is nil. From and To are dates, taken as midnight in the
+// time zone of each user. Offset and Limit page the rows of the pivot.
+type PivotFilter struct {
+	UserIDs []uint
+	From    time.Time
+	To      time.Time
+	Rows    string
+	Columns string
+	Offset  int
+	Limit   int
+}
+
+// PivotCell is the tracked time at the crossing of a pivot row and column.
+type PivotCell struct {
+	RowID     *uint
+	RowKey    string
+	ColumnID  *uint
+	ColumnKey string
+	Seconds   int64
+}
+
+type ReportRepository interface {
+	GetUserSummary(filter SummaryFilter) ([]SummaryTaskRow, error)
+	CountPivotRows(filter PivotFilter) (int64, error)
+	GetPivotColumns(filter PivotFilter) ([]SummaryRow, error)
+	GetPivotCells(filter PivotFilter, visit func(cell PivotCell) error) error
+}
@@ -0,0 +1,190 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// reportDimension holds the SQL expressions identifying a group of segments. Dimensions
+// without an entity behind them have a NULL id; time buckets are keyed by the date they start on
+// in the time zone of the user, so a bucket spans 23 or 25 hours across daylight saving changes.
+type reportDimension struct {
+	id  string
+	key string
+}
+
+var reportDimensions = map[string]reportDimension{
+	ReportDimensionUser:     {"t.user_id", "CONCAT_WS(' ', u.surname, u.name)"},
+	ReportDimensionDay:      {"NULL::integer", "to_char(date_trunc('day', s.start_time AT TIME ZONE u.time_zone), 'YYYY-MM-DD')"},
+	ReportDimensionWeek:     {"NULL::integer", "to_char(date_trunc('week', s.start_time AT TIME ZONE u.time_zone), 'YYYY-MM-DD')"},
+	ReportDimensionMonth:    {"NULL::integer", "to_char(date_trunc('month', s.start_time AT TIME ZONE u.time_zone), 'YYYY-MM')"},
+	ReportDimensionTaskName: {"NULL::integer", "t.task_name"},
+	ReportDimensionProject:  {"t.project_id", "COALESCE(p.name, '')"},
+	ReportDimensionClient:   {"p.client_id", "COALESCE(c.name, '')"},
+}
+
+const reportSegmentsFrom = `FROM task_segments s
+	JOIN tasks t ON t.id = s.task_id
+	JOIN users u ON u.id = t.user_id
+	LEFT JOIN projects p ON p.id = t.project_id
+	LEFT JOIN clients c ON c.id = p.client_id`
+
+// reportPeriod selects the segments started between two dates, taken as midnight in the time zone of the user.
+const reportPeriod = `s.start_time >= (?::date)::timestamp AT TIME ZONE u.time_zone
+	AND s.start_time < (?::date)::timestamp AT TIME ZONE u.time_zone`
+
+type ReportRepositoryImpl struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewReportRepositoryImpl(db *gorm.DB, logger *logrus.Logger) *ReportRepositoryImpl {
+	return &ReportRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetUserSummary sums the segments of the user's tasks per group and task, ordered by group.
+// Open segments count up to now.
+func (r *ReportRepositoryImpl) GetUserSummary(filter SummaryFilter) ([]SummaryTaskRow, error) {
+	r.logger.Infof("GetUserSummary: summarizing tasks of user ID %d by %s from database", filter.UserID, filter.GroupBy)
+	dimension, ok := reportDimensions[filter.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("unknown report dimension %q", filter.GroupBy)
+	}
+
+	query := fmt.Sprintf(`SELECT %s AS group_id, %s AS group_key, t.id AS task_id, t.project_id, p.client_id,
+		ROUND(SUM(EXTRACT(EPOCH FROM COALESCE(s.end_time, ?) - s.start_time)))::bigint AS seconds
+	%s
+	WHERE t.user_id = ? AND %s
+	GROUP BY group_id, group_key, t.id, t.project_id, p.client_id
+	ORDER BY group_key, group_id, t.id`, dimension.id, dimension.key, reportSegmentsFrom, reportPeriod)
+
+	var rows []SummaryTaskRow
+	args := []interface{}{time.Now(), filter.UserID, reportDate(filter.From), reportDate(filter.To)}
+	if err := r.db.Raw(query, args...).Scan(&rows).Error; err != nil {
+		r.logger.Errorf("GetUserSummary: failed to summarize tasks in database: %v", err)
+		return nil, err
+	}
+
+	r.logger.Infof("GetUserSummary: summarized tasks of user ID %d into %d rows", filter.UserID, len(rows))
+	return rows, nil
+}
+
+func (r *ReportRepositoryImpl) CountPivotRows(filter PivotFilter) (int64, error) {
+	base, args, err := pivotBase(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	query := base + ` SELECT COUNT(*) FROM (SELECT DISTINCT row_id, row_key FROM base) AS pivot_rows`
+	if err := r.db.Raw(query, args...).Scan(&count).Error; err != nil {
+		r.logger.Errorf("CountPivotRows: failed to count pivot rows in database: %v", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetPivotColumns returns the columns of the pivot with their totals over all rows, not only the paged ones.
+func (r *ReportRepositoryImpl) GetPivotColumns(filter PivotFilter) ([]SummaryRow, error) {
+	base, args, err := pivotBase(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	query := base + ` SELECT column_id AS group_id, column_key AS group_key,
+		COUNT(DISTINCT task_id) AS task_count, ROUND(SUM(seconds))::bigint AS seconds
+	FROM base
+	GROUP BY column_id, column_key
+	ORDER BY column_key, column_id`
+
+	var columns []SummaryRow
+	if err := r.db.Raw(query, args...).Scan(&columns).Error; err != nil {
+		r.logger.Errorf("GetPivotColumns: failed to fetch pivot columns from database: %v", err)
+		return nil, err
+	}
+
+	r.logger.Infof("GetPivotColumns: fetched %d pivot columns from database", len(columns))
+	return columns, nil
+}
+
+// GetPivotCells passes the non-empty cells of the paged rows to visit ordered by row, then column.
+// Cells are read through a cursor, so a page of hundreds of users is never held twice in memory.
+func (r *ReportRepositoryImpl) GetPivotCells(filter PivotFilter, visit func(cell PivotCell) error) error {
+	base, args, err := pivotBase(filter)
+	if err != nil {
+		return err
+	}
+
+	query := base + `, page AS (
+		SELECT row_id, row_key FROM base
+		GROUP BY row_id, row_key
+		ORDER BY row_key, row_id
+		LIMIT ? OFFSET ?
+	)
+	SELECT b.row_id, b.row_key, b.column_id, b.column_key, ROUND(SUM(b.seconds))::bigint AS seconds
+	FROM base b
+	JOIN page ON page.row_key = b.row_key AND page.row_id IS NOT DISTINCT FROM b.row_id
+	GROUP BY b.row_id, b.row_key, b.column_id, b.column_key
+	ORDER BY b.row_key, b.row_id, b.column_key, b.column_id`
+
+	rows, err := r.db.Raw(query, append(args, filter.Limit, filter.Offset)...).Rows()
+	if err != nil {
+		r.logger.Errorf("GetPivotCells: failed to fetch pivot cells from database: %v", err)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cell PivotCell
+		if err := r.db.ScanRows(rows, &cell); err != nil {
+			r.logger.Errorf("GetPivotCells: failed to scan pivot cell: %v", err)
+			return err
+		}
+		if err := visit(cell); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// pivotBase builds the common table expression listing every selected segment with
+// its row and column of the pivot, and the arguments it needs.
+func pivotBase(filter PivotFilter) (string, []interface{}, error) {
+	rowDimension, ok := reportDimensions[filter.Rows]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown report dimension %q", filter.Rows)
+	}
+	columnDimension, ok := reportDimensions[filter.Columns]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown report dimension %q", filter.Columns)
+	}
+
+	where := reportPeriod
+	args := []interface{}{time.Now(), reportDate(filter.From), reportDate(filter.To)}
+	if filter.UserIDs != nil {
+		where += " AND t.user_id IN ?"
+		args = append(args, filter.UserIDs)
+	}
+
+	base := fmt.Sprintf(`WITH base AS (
+		SELECT %s AS row_id, %s AS row_key, %s AS column_id, %s AS column_key, t.id AS task_id,
+			EXTRACT(EPOCH FROM COALESCE(s.end_time, ?) - s.start_time) AS seconds
+		%s
+		WHERE %s
+	)`, rowDimension.id, rowDimension.key, columnDimension.id, columnDimension.key, reportSegmentsFrom, where)
+
+	return base, args, nil
+}
+
+// reportDate renders the calendar date of t for reportPeriod.
+func reportDate(t time.Time) string {
+	return t.Format(time.DateOnly)
+}
@@ -0,0 +1,9 @@
+package repositories
+
+import "github.com/Dor1ma/Time-Tracker/internal/models"
+
+type RoundingPolicyRepository interface {
+	Set(policy *models.RoundingPolicy) error
+	GetAll() ([]models.RoundingPolicy, error)
+	Delete(id uint) error
+}
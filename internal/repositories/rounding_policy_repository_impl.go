@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type RoundingPolicyRepositoryImpl struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewRoundingPolicyRepositoryImpl(db *gorm.DB, logger *logrus.Logger) *RoundingPolicyRepositoryImpl {
+	return &RoundingPolicyRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Set stores the policy in place of the one with the same scope, if any.
+func (r *RoundingPolicyRepositoryImpl) Set(policy *models.RoundingPolicy) error {
+	r.logger.Infof("Set: setting rounding policy in database to %d minutes %s", policy.IncrementMinutes, policy.Mode)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("LOCK TABLE rounding_policies IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+			return err
+		}
+
+		var scope *gorm.DB
+		switch {
+		case policy.ProjectID != nil:
+			scope = tx.Where("project_id = ?", *policy.ProjectID)
+		case policy.ClientID != nil:
+			scope = tx.Where("client_id = ?", *policy.ClientID)
+		default:
+			scope = tx.Where("project_id IS NULL AND client_id IS NULL")
+		}
+		if err := scope.Delete(&models.RoundingPolicy{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(policy).Error
+	})
+	if err != nil {
+		r.logger.Errorf("Set: failed to set rounding policy in database: %v", err)
+		return err
+	}
+
+	r.logger.Infof("Set: rounding policy set in database successfully with ID %d", policy.ID)
+	return nil
+}
+
+func (r *RoundingPolicyRepositoryImpl) GetAll() ([]models.RoundingPolicy, error) {
+	var policies []models.RoundingPolicy
+	result := r.db.Order("id").Find(&policies)
+	if result.Error != nil {
+		r.logger.Errorf("GetAll: failed to fetch rounding policies from database: %v", result.Error)
+		return nil, result.Error
+	}
+
+	r.logger.Infof("GetAll: successfully fetched %d rounding policies from database", len(policies))
+	return policies, nil
+}
+
+func (r *RoundingPolicyRepositoryImpl) Delete(id uint) error {
+	r.logger.Infof("Delete: deleting rounding policy from database with ID %d", id)
+	result := r.db.Delete(&models.RoundingPolicy{}, id)
+	if result.Error != nil {
+		r.logger.Errorf("Delete: failed to delete rounding policy from database with ID %d: %v", id, result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	r.logger.Infof("Delete: rounding policy with ID %d deleted from database successfully", id)
+	return nil
+}
@@ -0,0 +1,13 @@
+package repositories
+
+import "github.com/Dor1ma/Time-Tracker/internal/models"
+
+type TagRepository interface {
+	Create(tag *models.Tag) error
+	GetById(id uint) (*models.Tag, error)
+	GetByName(name string) (*models.Tag, error)
+	GetAll() ([]models.Tag, error)
+	Update(tag *models.Tag) error
+	Delete(id uint) error
+	Merge(sourceID uint, targetID uint) error
+}
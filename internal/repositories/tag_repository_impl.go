@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type TagRepositoryImpl struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewTagRepositoryImpl(db *gorm.DB, logger *logrus.Logger) *TagRepositoryImpl {
+	return &TagRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *TagRepositoryImpl) Create(tag *models.Tag) error {
+	r.logger.Infof("Create: creating tag in database with name %s", tag.Name)
+	if err := r.db.Create(tag).Error; err != nil {
+		r.logger.Errorf("Create: failed to create tag in database: %v", err)
+		return err
+	}
+
+	r.logger.Infof("Create: tag created in database successfully with ID %d", tag.ID)
+	return nil
+}
+
+func (r *TagRepositoryImpl) GetById(id uint) (*models.Tag, error) {
+	var tag models.Tag
+	result := r.db.First(&tag, id)
+	if result.Error != nil {
+		r.logger.Errorf("GetById: failed to get tag from database with ID %d: %v", id, result.Error)
+		return nil, result.Error
+	}
+
+	r.logger.Infof("GetById: successfully retrieved tag from database with ID %d", id)
+	return &tag, nil
+}
+
+func (r *TagRepositoryImpl) GetByName(name string) (*models.Tag, error) {
+	var tag models.Tag
+	result := r.db.Where("name = ?", name).First(&tag)
+	if result.Error != nil {
+		r.logger.Debugf("GetByName: failed to get tag from database with name %s: %v", name, result.Error)
+		return nil, result.Error
+	}
+
+	return &tag, nil
+}
+
+func (r *TagRepositoryImpl) GetAll() ([]models.Tag, error) {
+	var tags []models.Tag
+	result := r.db.Order("name").Find(&tags)
+	if result.Error != nil {
+		r.logger.Errorf("GetAll: failed to fetch all tags from database: %v", result.Error)
+		return nil, result.Error
+	}
+
+	r.logger.Infof("GetAll: successfully fetched %d tags from database", len(tags))
+	return tags, nil
+}
+
+func (r *TagRepositoryImpl) Update(tag *models.Tag) error {
+	r.logger.Infof("Update: updating tag in database with ID %d", tag.ID)
+	if err := r.db.Save(tag).Error; err != nil {
+		r.logger.Errorf("Update: failed to update tag in database with ID %d: %v", tag.ID, err)
+		return err
+	}
+
+	r.logger.Infof("Update: tag with ID %d updated successfully in database", tag.ID)
+	return nil
+}
+
+func (r *TagRepositoryImpl) Delete(id uint) error {
+	r.logger.Infof("Delete: deleting tag from database with ID %d", id)
+	if err := r.db.Delete(&models.Tag{}, id).Error; err != nil {
+		r.logger.Errorf("Delete: failed to delete tag from database with ID %d: %v", id, err)
+		return err
+	}
+
+	r.logger.Infof("Delete: tag with ID %d deleted from database successfully", id)
+	return nil
+}
+
+// Merge moves every task of the source tag to the target tag and removes the source tag.
+func (r *TagRepositoryImpl) Merge(sourceID uint, targetID uint) error {
+	r.logger.Infof("Merge: merging tag with ID %d into tag with ID %d", sourceID, targetID)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var tags []models.Tag
+		if err := tx.Find(&tags, []uint{sourceID, targetID}).Error; err != nil {
+			return err
+		}
+		if len(tags) != 2 {
+			return gorm.ErrRecordNotFound
+		}
+
+		err := tx.Exec(`INSERT INTO task_tags (task_id, tag_id)
+			SELECT task_id, ? FROM task_tags WHERE tag_id = ?
+			ON CONFLICT DO NOTHING`, targetID, sourceID).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Delete(&models.Tag{}, sourceID).Error
+	})
+	if err != nil {
+		r.logger.Errorf("Merge: failed to merge tag with ID %d into tag with ID %d: %v", sourceID, targetID, err)
+		return err
+	}
+
+	r.logger.Infof("Merge: tag with ID %d merged into tag with ID %d", sourceID, targetID)
+	return nil
+}
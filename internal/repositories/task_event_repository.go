@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+)
+
+type TaskEventRepository interface {
+	GetAfter(afterID uint64, userIDs []uint, limit int) ([]models.TaskEvent, error)
+	GetSince(since time.Time, afterID uint64, limit int) ([]models.TaskEvent, error)
+	DeleteBefore(before time.Time) (int64, error)
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type TaskEventRepositoryImpl struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewTaskEventRepositoryImpl(db *gorm.DB, logger *logrus.Logger) *TaskEventRepositoryImpl {
+	return &TaskEventRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetAfter returns up to limit events with an ID above afterID, oldest first, of the users
+// or, if userIDs is empty, of everyone.
+func (r *TaskEventRepositoryImpl) GetAfter(afterID uint64, userIDs []uint, limit int) ([]models.TaskEvent, error) {
+	query := r.db.Where("id > ?", afterID)
+	if len(userIDs) > 0 {
+		query = query.Where("user_id IN ?", userIDs)
+	}
+
+	var events []models.TaskEvent
+	if err := query.Order("id").Limit(limit).Find(&events).Error; err != nil {
+		r.logger.Errorf("GetAfter: failed to get task events after ID %d from database: %v", afterID, err)
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// GetSince returns up to limit events stored since the given time with an ID above afterID,
+// in ID order.
+func (r *TaskEventRepositoryImpl) GetSince(since time.Time, afterID uint64, limit int) ([]models.TaskEvent, error) {
+	var events []models.TaskEvent
+	err := r.db.Where("created_at >= ? AND id > ?", since, afterID).
+		Order("id").
+		Limit(limit).
+		Find(&events).Error
+	if err != nil {
+		r.logger.Errorf("GetSince: failed to get task events since %s from database: %v", since.Format(time.RFC3339), err)
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *TaskEventRepositoryImpl) DeleteBefore(before time.Time) (int64, error) {
+	result := r.db.Where("created_at < ?", before).Delete(&models.TaskEvent{})
+	if result.Error != nil {
+		r.logger.Errorf("DeleteBefore: failed to delete task events before %s from database: %v",
+			before.Format(time.RFC3339), result.Error)
+		return 0, result.Error
+	}
+
+	r.logger.Infof("DeleteBefore: deleted %d task events before %s", result.RowsAffected, before.Format(time.RFC3339))
+	return result.RowsAffected, nil
+}
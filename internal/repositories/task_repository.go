@@ -1,12 +1,40 @@
 package repositories
 
 import (
-	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"context"
 	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
 )
 
 type TaskRepository interface {
-	StartTask(userID uint, taskName string) (*models.Task, error)
-	StopTask(taskID uint) (*models.Task, error)
-	GetUserTasks(userID uint, startDate, endDate time.Time) ([]models.Task, error)
+	StartTask(ctx context.Context, task *models.Task, stopRunning bool) error
+	PauseTask(ctx context.Context, taskID uint) (*models.Task, error)
+	ResumeTask(ctx context.Context, taskID uint, stopRunning bool) (*models.Task, error)
+	StopTask(ctx context.Context, taskID uint) (*models.Task, error)
+	CreateTask(ctx context.Context, task *models.Task) error
+	ConfirmPlannedTask(ctx context.Context, taskID uint) (*models.Task, error)
+	UpdateTask(ctx context.Context, task *models.Task, intervalChanged bool) error
+	GetTask(taskID uint) (*models.Task, error)
+	GetRunningTask(userID uint) (*models.Task, error)
+	GetUserTasks(filter TaskFilter) ([]models.Task, error)
+	GetUserTasksInBatches(filter TaskFilter, batchSize int, visit func(tasks []models.Task) error) error
+	GetImportedKeys(keys []string) ([]string, error)
+	GetRunningTasks() ([]models.Task, error)
+	AutoStopTask(ctx context.Context, taskID uint, stopAt time.Time, reason string) (*models.Task, error)
+	GetDueFocusSessions(now time.Time) ([]models.FocusSession, error)
+	AdvanceFocusSession(ctx context.Context, taskID uint, now time.Time) (*models.Task, error)
+}
+
+// TaskFilter narrows down the tasks returned by GetUserTasks.
+type TaskFilter struct {
+	UserID       uint
+	StartDate    time.Time
+	EndDate      time.Time
+	ProjectID    *uint
+	ClientID     *uint
+	Tags         []string
+	MatchAllTags bool
+	AutoStopped  *bool
+	WithSegments bool
 }
@@ -1,74 +1,958 @@
 package repositories
 
 import (
+	"context"
+	"errors"
 	"time"
 
 	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/notify"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TaskRepositoryImpl struct {
-	db     *gorm.DB
-	logger *logrus.Logger
+	db       *gorm.DB
+	notifier notify.Notifier
+	logger   *logrus.Logger
 }
 
-func NewTaskRepositoryImpl(db *gorm.DB, logger *logrus.Logger) *TaskRepositoryImpl {
+// NewTaskRepositoryImpl returns a task repository that sends the budget alerts raised
+// by stopping tasks to notifier.
+func NewTaskRepositoryImpl(db *gorm.DB, notifier notify.Notifier, logger *logrus.Logger) *TaskRepositoryImpl {
 	return &TaskRepositoryImpl{
-		db:     db,
-		logger: logger,
+		db:       db,
+		notifier: notifier,
+		logger:   logger,
 	}
 }
 
-func (r *TaskRepositoryImpl) StartTask(userID uint, taskName string) (*models.Task, error) {
-	task := &models.Task{
-		UserID:    userID,
-		TaskName:  taskName,
-		StartTime: time.Now(),
-	}
+func (r *TaskRepositoryImpl) StartTask(ctx context.Context, task *models.Task, stopRunning bool) error {
+	r.logger.Infof("StartTask: start adding task to database for user ID: %d, task name: %s", task.UserID, task.TaskName)
+	var alerts []models.BudgetAlert
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkUserActive(tx, task.UserID); err != nil {
+			return err
+		}
+		if err := checkProjectActive(tx, task.ProjectID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := checkWeekUnlocked(tx, task.UserID, now); err != nil {
+			return err
+		}
+		var err error
+		if alerts, err = releaseRunningTask(tx, task.UserID, 0, stopRunning, now); err != nil {
+			return err
+		}
+		if err := resolveTags(tx, task.Tags); err != nil {
+			return err
+		}
+
+		task.Status = models.TaskStatusRunning
+		task.StartTime = now
+		task.Segments = []models.TaskSegment{{StartTime: now}}
+		if task.FocusSession != nil {
+			workEndsAt := now.Add(task.FocusSession.WorkDuration())
+			task.FocusSession.Phase = models.FocusPhaseWork
+			task.FocusSession.PhaseEndsAt = &workEndsAt
+		}
+		if err := tx.Create(task).Error; err != nil {
+			return err
+		}
+		if err := addTaskAuditEntry(tx, models.AuditActionStart, nil, task); err != nil {
+			return err
+		}
 
-	r.logger.Infof("StartTask: start adding task to database for user ID: %d, task name: %s", userID, taskName)
-	if err := r.db.Create(task).Error; err != nil {
+		return addTaskEvent(tx, models.EventTaskStarted, task)
+	})
+	if err != nil {
 		r.logger.Debugf("StartTask: failed to add task to database: %v", err)
-		return nil, err
+		return err
 	}
 
+	r.notifyBudgetAlerts(alerts)
+
 	r.logger.Infof("StartTask: successfully added task to database with ID: %d", task.ID)
-	return task, nil
+	return nil
 }
 
-func (r *TaskRepositoryImpl) StopTask(taskID uint) (*models.Task, error) {
+func (r *TaskRepositoryImpl) PauseTask(ctx context.Context, taskID uint) (*models.Task, error) {
 	var task models.Task
-	if err := r.db.First(&task, taskID).Error; err != nil {
-		r.logger.Debugf("StopTask: failed to find task with ID %d: %v in database", taskID, err)
+	r.logger.Infof("PauseTask: pausing task with ID: %d", taskID)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockTask(tx, &task, taskID); err != nil {
+			return err
+		}
+		if task.Status != models.TaskStatusRunning {
+			return models.ErrTaskNotRunning
+		}
+		if err := checkWeekUnlocked(tx, task.UserID, task.StartTime); err != nil {
+			return err
+		}
+		before, err := taskAuditState(tx, &task)
+		if err != nil {
+			return err
+		}
+
+		if err := closeOpenSegment(tx, task.ID, time.Now()); err != nil {
+			return err
+		}
+		if err := endFocusSession(tx, &task); err != nil {
+			return err
+		}
+
+		task.Status = models.TaskStatusPaused
+		if err := applySegmentDuration(tx, &task); err != nil {
+			return err
+		}
+		if err := tx.Save(&task).Error; err != nil {
+			return err
+		}
+		if err := addTaskAuditEntry(tx, models.AuditActionPause, before, &task); err != nil {
+			return err
+		}
+
+		return addTaskEvent(tx, models.EventTaskPaused, &task)
+	})
+	if err != nil {
+		r.logger.Errorf("PauseTask: failed to pause task with ID %d: %v", taskID, err)
 		return nil, err
 	}
 
-	task.EndTime = time.Now()
-	duration := task.EndTime.Sub(task.StartTime)
-	task.Hours = int(duration.Hours())
-	task.Minutes = int(duration.Minutes())
+	r.loadTags(&task)
+	r.logger.Infof("PauseTask: successfully paused task with ID: %d", task.ID)
+	return &task, nil
+}
+
+func (r *TaskRepositoryImpl) ResumeTask(ctx context.Context, taskID uint, stopRunning bool) (*models.Task, error) {
+	var task models.Task
+	r.logger.Infof("ResumeTask: resuming task with ID: %d", taskID)
+	var alerts []models.BudgetAlert
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockTask(tx, &task, taskID); err != nil {
+			return err
+		}
+		if task.Status != models.TaskStatusPaused {
+			return models.ErrTaskNotPaused
+		}
+		if err := checkUserActive(tx, task.UserID); err != nil {
+			return err
+		}
+		if err := checkWeekUnlocked(tx, task.UserID, task.StartTime); err != nil {
+			return err
+		}
+		if err := checkProjectActive(tx, task.ProjectID); err != nil {
+			return err
+		}
+		before, err := taskAuditState(tx, &task)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if alerts, err = releaseRunningTask(tx, task.UserID, task.ID, stopRunning, now); err != nil {
+			return err
+		}
 
-	r.logger.Infof("StopTask: stopping task with ID: %d", task.ID)
-	if err := r.db.Save(&task).Error; err != nil {
-		r.logger.Errorf("StopTask: failed to stop task: %v", err)
+		segment := models.TaskSegment{TaskID: task.ID, StartTime: now}
+		if err := tx.Create(&segment).Error; err != nil {
+			return err
+		}
+		if err := resumeFocusSession(tx, &task, now); err != nil {
+			return err
+		}
+
+		task.Status = models.TaskStatusRunning
+		if err := tx.Save(&task).Error; err != nil {
+			return err
+		}
+		if err := addTaskAuditEntry(tx, models.AuditActionResume, before, &task); err != nil {
+			return err
+		}
+
+		return addTaskEvent(tx, models.EventTaskResumed, &task)
+	})
+	if err != nil {
+		r.logger.Errorf("ResumeTask: failed to resume task with ID %d: %v", taskID, err)
 		return nil, err
 	}
 
+	r.notifyBudgetAlerts(alerts)
+
+	r.loadTags(&task)
+	r.logger.Infof("ResumeTask: successfully resumed task with ID: %d", task.ID)
+	return &task, nil
+}
+
+func (r *TaskRepositoryImpl) StopTask(ctx context.Context, taskID uint) (*models.Task, error) {
+	var task models.Task
+	r.logger.Infof("StopTask: stopping task with ID: %d", taskID)
+	var alerts []models.BudgetAlert
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockTask(tx, &task, taskID); err != nil {
+			return err
+		}
+		if task.Status == models.TaskStatusStopped {
+			return models.ErrTaskAlreadyStopped
+		}
+		if task.Status == models.TaskStatusPlanned {
+			return models.ErrTaskNotRunning
+		}
+		if err := checkWeekUnlocked(tx, task.UserID, task.StartTime); err != nil {
+			return err
+		}
+
+		before, err := taskAuditState(tx, &task)
+		if err != nil {
+			return err
+		}
+		alerts, err = stopLockedTask(tx, &task, before, time.Now(), nil)
+		return err
+	})
+	if err != nil {
+		r.logger.Errorf("StopTask: failed to stop task with ID %d: %v", taskID, err)
+		return nil, err
+	}
+
+	r.notifyBudgetAlerts(alerts)
+
+	r.loadTags(&task)
 	r.logger.Infof("StopTask: successfully stopped task with ID: %d", task.ID)
 	return &task, nil
 }
 
-func (r *TaskRepositoryImpl) GetUserTasks(userID uint, startDate time.Time, endDate time.Time) ([]models.Task, error) {
+// AutoStopTask stops a task left running, closing its open segment at stopAt rather than now,
+// and marks it as auto-stopped with the given reason. It fails with ErrTaskNotRunning if the
+// task was paused or stopped meanwhile, or if its open segment started after stopAt.
+func (r *TaskRepositoryImpl) AutoStopTask(ctx context.Context, taskID uint, stopAt time.Time, reason string) (*models.Task, error) {
+	var task models.Task
+	r.logger.Infof("AutoStopTask: stopping task with ID: %d at %s", taskID, stopAt.Format(time.RFC3339))
+	var alerts []models.BudgetAlert
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockTask(tx, &task, taskID); err != nil {
+			return err
+		}
+		if task.Status != models.TaskStatusRunning {
+			return models.ErrTaskNotRunning
+		}
+
+		var open int64
+		err := tx.Model(&models.TaskSegment{}).
+			Where("task_id = ? AND end_time IS NULL AND start_time < ?", task.ID, stopAt).
+			Count(&open).Error
+		if err != nil {
+			return err
+		}
+		if open == 0 {
+			return models.ErrTaskNotRunning
+		}
+		if err := checkWeekUnlocked(tx, task.UserID, task.StartTime); err != nil {
+			return err
+		}
+
+		before, err := taskAuditState(tx, &task)
+		if err != nil {
+			return err
+		}
+		alerts, err = stopLockedTask(tx, &task, before, stopAt, &reason)
+		return err
+	})
+	if err != nil {
+		r.logger.Errorf("AutoStopTask: failed to stop task with ID %d: %v", taskID, err)
+		return nil, err
+	}
+
+	r.notifyBudgetAlerts(alerts)
+
+	r.loadTags(&task)
+	r.logger.Infof("AutoStopTask: successfully stopped task with ID: %d", task.ID)
+	return &task, nil
+}
+
+func (r *TaskRepositoryImpl) CreateTask(ctx context.Context, task *models.Task) error {
+	r.logger.Infof("CreateTask: adding manual task to database for user ID: %d, task name: %s", task.UserID, task.TaskName)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return createRecordedTask(tx, task)
+	})
+	if err != nil {
+		r.logger.Errorf("CreateTask: failed to add task to database: %v", err)
+		return err
+	}
+
+	r.logger.Infof("CreateTask: successfully added task to database with ID: %d", task.ID)
+	return nil
+}
+
+func (r *TaskRepositoryImpl) UpdateTask(ctx context.Context, task *models.Task, intervalChanged bool) error {
+	r.logger.Infof("UpdateTask: updating task in database with ID: %d", task.ID)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var stored models.Task
+		if err := lockTask(tx, &stored, task.ID); err != nil {
+			return err
+		}
+		if stored.InvoiceID != nil {
+			return models.ErrTaskInvoiced
+		}
+		if err := tx.Model(&stored).Order("name").Association("Tags").Find(&stored.Tags); err != nil {
+			return err
+		}
+		before, err := taskAuditState(tx, &stored)
+		if err != nil {
+			return err
+		}
+		if err := checkWeekUnlocked(tx, stored.UserID, stored.StartTime); err != nil {
+			return err
+		}
+		if intervalChanged {
+			if err := checkWeekUnlocked(tx, task.UserID, task.StartTime); err != nil {
+				return err
+			}
+		}
+		if !sameProject(stored.ProjectID, task.ProjectID) {
+			if err := checkProjectActive(tx, task.ProjectID); err != nil {
+				return err
+			}
+		}
+
+		if task.Tags != nil {
+			if err := resolveTags(tx, task.Tags); err != nil {
+				return err
+			}
+			if err := tx.Model(task).Association("Tags").Replace(task.Tags); err != nil {
+				return err
+			}
+		}
+
+		if intervalChanged {
+			if err := checkOverlap(tx, task); err != nil {
+				return err
+			}
+
+			if err := tx.Where("task_id = ?", task.ID).Delete(&models.TaskSegment{}).Error; err != nil {
+				return err
+			}
+
+			endTime := task.EndTime
+			segment := models.TaskSegment{TaskID: task.ID, StartTime: task.StartTime, EndTime: &endTime}
+			if err := tx.Create(&segment).Error; err != nil {
+				return err
+			}
+
+			task.Segments = []models.TaskSegment{segment}
+			applyDuration(task, task.EndTime.Sub(task.StartTime))
+		}
+
+		if err := tx.Omit(clause.Associations).Save(task).Error; err != nil {
+			return err
+		}
+		if err := addTaskAuditEntry(tx, models.AuditActionUpdate, before, task); err != nil {
+			return err
+		}
+		return addTaskEvent(tx, models.EventTaskUpdated, task)
+	})
+	if err != nil {
+		r.logger.Errorf("UpdateTask: failed to update task with ID %d: %v", task.ID, err)
+		return err
+	}
+
+	r.logger.Infof("UpdateTask: task with ID %d updated successfully in database", task.ID)
+	return nil
+}
+
+// ConfirmPlannedTask records the planned interval of a planned task, which makes it
+// a stopped task like one logged by hand.
+func (r *TaskRepositoryImpl) ConfirmPlannedTask(ctx context.Context, taskID uint) (*models.Task, error) {
+	var task models.Task
+	r.logger.Infof("ConfirmPlannedTask: confirming planned task with ID: %d", taskID)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockTask(tx, &task, taskID); err != nil {
+			return err
+		}
+		if task.Status != models.TaskStatusPlanned {
+			return models.ErrTaskNotPlanned
+		}
+		before, err := taskAuditState(tx, &task)
+		if err != nil {
+			return err
+		}
+		if err := checkWeekUnlocked(tx, task.UserID, task.StartTime); err != nil {
+			return err
+		}
+		if err := checkProjectActive(tx, task.ProjectID); err != nil {
+			return err
+		}
+		if err := checkOverlap(tx, &task); err != nil {
+			return err
+		}
+
+		endTime := task.EndTime
+		segment := models.TaskSegment{TaskID: task.ID, StartTime: task.StartTime, EndTime: &endTime}
+		if err := tx.Create(&segment).Error; err != nil {
+			return err
+		}
+
+		task.Status = models.TaskStatusStopped
+		task.Segments = []models.TaskSegment{segment}
+		applyDuration(&task, task.EndTime.Sub(task.StartTime))
+		if err := tx.Omit(clause.Associations).Save(&task).Error; err != nil {
+			return err
+		}
+		if err := addTaskAuditEntry(tx, models.AuditActionConfirm, before, &task); err != nil {
+			return err
+		}
+		return addTaskEvent(tx, models.EventTaskConfirmed, &task)
+	})
+	if err != nil {
+		r.logger.Errorf("ConfirmPlannedTask: failed to confirm task with ID %d: %v", taskID, err)
+		return nil, err
+	}
+
+	r.loadTags(&task)
+	r.logger.Infof("ConfirmPlannedTask: successfully confirmed task with ID: %d", task.ID)
+	return &task, nil
+}
+
+// GetDueFocusSessions returns the focus sessions in progress whose current phase has ended by now.
+func (r *TaskRepositoryImpl) GetDueFocusSessions(now time.Time) ([]models.FocusSession, error) {
+	var sessions []models.FocusSession
+	err := r.db.Where("phase <> ? AND phase_ends_at <= ?", models.FocusPhaseDone, now).
+		Order("phase_ends_at").
+		Find(&sessions).Error
+	if err != nil {
+		r.logger.Errorf("GetDueFocusSessions: failed to fetch focus sessions from database: %v", err)
+		return nil, err
+	}
+
+	r.logger.Infof("GetDueFocusSessions: found %d focus sessions due", len(sessions))
+	return sessions, nil
+}
+
+// AdvanceFocusSession moves the focus session of the task through the phases that have ended
+// by now. Work intervals are closed and opened at the times the phases ended rather than now,
+// and the task is stopped at the end of the last one. A break ends the session instead of
+// resuming the task if the user has tracked other work since, or the project has been archived.
+func (r *TaskRepositoryImpl) AdvanceFocusSession(ctx context.Context, taskID uint, now time.Time) (*models.Task, error) {
+	var task models.Task
+	r.logger.Infof("AdvanceFocusSession: advancing focus session of task with ID: %d", taskID)
+	var alerts []models.BudgetAlert
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockTask(tx, &task, taskID); err != nil {
+			return err
+		}
+		if err := loadFocusSession(tx, &task); err != nil {
+			return err
+		}
+		if task.FocusSession == nil {
+			return gorm.ErrRecordNotFound
+		}
+
+		session := task.FocusSession
+		for session.Phase != models.FocusPhaseDone && !session.PhaseEndsAt.After(now) {
+			phaseAlerts, err := advanceFocusPhase(tx, &task)
+			if err != nil {
+				return err
+			}
+			alerts = append(alerts, phaseAlerts...)
+		}
+
+		return nil
+	})
+	if err != nil {
+		r.logger.Errorf("AdvanceFocusSession: failed to advance focus session of task with ID %d: %v", taskID, err)
+		return nil, err
+	}
+
+	r.notifyBudgetAlerts(alerts)
+
+	r.loadTags(&task)
+	r.logger.Infof("AdvanceFocusSession: focus session of task with ID %d is in phase %s", task.ID, task.FocusSession.Phase)
+	return &task, nil
+}
+
+func (r *TaskRepositoryImpl) GetTask(taskID uint) (*models.Task, error) {
+	var task models.Task
+	if err := r.db.Preload("Tags").Preload("FocusSession").First(&task, taskID).Error; err != nil {
+		r.logger.Errorf("GetTask: failed to get task from database with ID %d: %v", taskID, err)
+		return nil, err
+	}
+
+	r.logger.Infof("GetTask: successfully retrieved task from database with ID %d", taskID)
+	return &task, nil
+}
+
+func (r *TaskRepositoryImpl) GetRunningTask(userID uint) (*models.Task, error) {
+	var task models.Task
+	r.logger.Infof("GetRunningTask: fetching running task for user ID from database: %d", userID)
+	err := r.db.Preload("Tags").Preload("FocusSession").
+		Where("user_id = ? AND status = ?", userID, models.TaskStatusRunning).
+		First(&task).Error
+	if err != nil {
+		r.logger.Debugf("GetRunningTask: failed to fetch running task for user ID %d: %v", userID, err)
+		return nil, err
+	}
+
+	r.logger.Infof("GetRunningTask: found running task with ID: %d for user ID: %d", task.ID, userID)
+	return &task, nil
+}
+
+// GetRunningTasks returns the running tasks of all users with their open segment,
+// so that the sweeper can tell for how long they have been running.
+func (r *TaskRepositoryImpl) GetRunningTasks() ([]models.Task, error) {
 	var tasks []models.Task
-	r.logger.Infof("GetUserTasks: fetching tasks for user ID from database: %d", userID)
-	err := r.db.Where("user_id = ? AND start_time >= ? AND end_time <= ?", userID, startDate, endDate).
-		Order("hours DESC, minutes DESC").Find(&tasks).Error
+	r.logger.Infof("GetRunningTasks: fetching running tasks from database")
+	err := r.db.Preload("Segments", "end_time IS NULL").
+		Where("status = ?", models.TaskStatusRunning).
+		Order("id").
+		Find(&tasks).Error
+	if err != nil {
+		r.logger.Errorf("GetRunningTasks: failed to fetch running tasks from database: %v", err)
+		return nil, err
+	}
+
+	r.logger.Infof("GetRunningTasks: found %d running tasks", len(tasks))
+	return tasks, nil
+}
+
+func (r *TaskRepositoryImpl) GetUserTasks(filter TaskFilter) ([]models.Task, error) {
+	var tasks []models.Task
+	r.logger.Infof("GetUserTasks: fetching tasks for user ID from database: %d", filter.UserID)
+	err := r.userTasksQuery(filter).Order("hours DESC, minutes DESC").Find(&tasks).Error
 	if err != nil {
 		r.logger.Errorf("GetUserTasks: failed to fetch tasks from database: %v", err)
 		return nil, err
 	}
 
-	r.logger.Infof("GetUserTasks: successfully fetched %d tasks from database for user ID: %d", len(tasks), userID)
+	r.logger.Infof("GetUserTasks: successfully fetched %d tasks from database for user ID: %d", len(tasks), filter.UserID)
 	return tasks, nil
 }
+
+// GetUserTasksInBatches passes the tasks matching filter to visit in batches of batchSize,
+// ordered by ID, so that callers can process any number of tasks in bounded memory.
+func (r *TaskRepositoryImpl) GetUserTasksInBatches(filter TaskFilter, batchSize int, visit func(tasks []models.Task) error) error {
+	r.logger.Infof("GetUserTasksInBatches: fetching tasks in batches of %d for user ID from database: %d", batchSize, filter.UserID)
+	var tasks []models.Task
+	var count int
+	err := r.userTasksQuery(filter).FindInBatches(&tasks, batchSize, func(tx *gorm.DB, batch int) error {
+		count += len(tasks)
+		return visit(tasks)
+	}).Error
+	if err != nil {
+		r.logger.Errorf("GetUserTasksInBatches: failed to fetch tasks from database: %v", err)
+		return err
+	}
+
+	r.logger.Infof("GetUserTasksInBatches: successfully fetched %d tasks from database for user ID: %d", count, filter.UserID)
+	return nil
+}
+
+// GetImportedKeys returns those of keys that already belong to imported tasks.
+func (r *TaskRepositoryImpl) GetImportedKeys(keys []string) ([]string, error) {
+	var imported []string
+	if len(keys) == 0 {
+		return imported, nil
+	}
+
+	err := r.db.Model(&models.Task{}).Where("import_key IN ?", keys).Pluck("import_key", &imported).Error
+	if err != nil {
+		r.logger.Errorf("GetImportedKeys: failed to fetch import keys from database: %v", err)
+		return nil, err
+	}
+
+	return imported, nil
+}
+
+func (r *TaskRepositoryImpl) userTasksQuery(filter TaskFilter) *gorm.DB {
+	query := r.db.Where("user_id = ? AND start_time >= ? AND end_time <= ?", filter.UserID, filter.StartDate, filter.EndDate)
+	if filter.ProjectID != nil {
+		query = query.Where("project_id = ?", *filter.ProjectID)
+	}
+	if filter.ClientID != nil {
+		query = query.Where("project_id IN (?)", r.db.Model(&models.Project{}).Select("id").Where("client_id = ?", *filter.ClientID))
+	}
+	if len(filter.Tags) > 0 {
+		taggedTasks := r.db.Table("task_tags").
+			Select("task_tags.task_id").
+			Joins("JOIN tags ON tags.id = task_tags.tag_id").
+			Where("tags.name IN ?", filter.Tags)
+		if filter.MatchAllTags {
+			taggedTasks = taggedTasks.Group("task_tags.task_id").
+				Having("COUNT(DISTINCT tags.id) = ?", len(filter.Tags))
+		}
+		query = query.Where("id IN (?)", taggedTasks)
+	}
+	if filter.AutoStopped != nil {
+		query = query.Where("auto_stopped = ?", *filter.AutoStopped)
+	}
+	query = query.Preload("Tags", func(db *gorm.DB) *gorm.DB {
+		return db.Order("name")
+	}).Preload("FocusSession")
+	if filter.WithSegments {
+		query = query.Preload("Segments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("start_time")
+		})
+	}
+
+	return query
+}
+
+func (r *TaskRepositoryImpl) loadTags(task *models.Task) {
+	if err := r.db.Model(task).Order("name").Association("Tags").Find(&task.Tags); err != nil {
+		r.logger.Debugf("loadTags: failed to load tags of task with ID %d: %v", task.ID, err)
+	}
+}
+
+// lockTask loads the task and holds a row lock on it until the transaction ends,
+// so concurrent pause/resume/stop calls cannot interleave.
+func lockTask(tx *gorm.DB, task *models.Task, taskID uint) error {
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(task, taskID).Error
+}
+
+// taskAuditState returns the state of the locked task for the audit log, loading its focus
+// session first so that changes to it are recorded.
+func taskAuditState(tx *gorm.DB, task *models.Task) (*models.TaskAuditState, error) {
+	if err := loadFocusSession(tx, task); err != nil {
+		return nil, err
+	}
+
+	return models.NewTaskAuditState(task), nil
+}
+
+// resolveTags fills in the IDs of the tags by name, creating the tags that do not exist yet.
+func resolveTags(tx *gorm.DB, tags []models.Tag) error {
+	for i := range tags {
+		if err := tx.Where(models.Tag{Name: tags[i].Name}).FirstOrCreate(&tags[i]).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createRecordedTask stores a finished task with a single segment covering its interval.
+func createRecordedTask(tx *gorm.DB, task *models.Task) error {
+	if err := checkProjectActive(tx, task.ProjectID); err != nil {
+		return err
+	}
+	if err := checkWeekUnlocked(tx, task.UserID, task.StartTime); err != nil {
+		return err
+	}
+	if err := checkOverlap(tx, task); err != nil {
+		return err
+	}
+	if err := resolveTags(tx, task.Tags); err != nil {
+		return err
+	}
+
+	endTime := task.EndTime
+	task.Status = models.TaskStatusStopped
+	task.Segments = []models.TaskSegment{{StartTime: task.StartTime, EndTime: &endTime}}
+	applyDuration(task, task.EndTime.Sub(task.StartTime))
+	if err := tx.Create(task).Error; err != nil {
+		return err
+	}
+	if err := addTaskAuditEntry(tx, models.AuditActionCreate, nil, task); err != nil {
+		return err
+	}
+	return addTaskEvent(tx, models.EventTaskCreated, task)
+}
+
+// createPlannedTask stores a task that records no time yet: it has no segments until confirmed.
+func createPlannedTask(tx *gorm.DB, task *models.Task) error {
+	if err := checkProjectActive(tx, task.ProjectID); err != nil {
+		return err
+	}
+	if err := resolveTags(tx, task.Tags); err != nil {
+		return err
+	}
+
+	task.Status = models.TaskStatusPlanned
+	if err := tx.Create(task).Error; err != nil {
+		return err
+	}
+	if err := addTaskAuditEntry(tx, models.AuditActionCreate, nil, task); err != nil {
+		return err
+	}
+	return addTaskEvent(tx, models.EventTaskCreated, task)
+}
+
+// checkProjectActive rejects archived projects. Tasks without a project are always allowed.
+func checkProjectActive(tx *gorm.DB, projectID *uint) error {
+	if projectID == nil {
+		return nil
+	}
+
+	var project models.Project
+	if err := tx.First(&project, *projectID).Error; err != nil {
+		return err
+	}
+	if project.Archived {
+		return models.ErrProjectArchived
+	}
+
+	return nil
+}
+
+// checkUserActive rejects deleted users. The user is locked against being deleted until the
+// transaction ends, so a timer cannot be started while its user is being deleted.
+func checkUserActive(tx *gorm.DB, userID uint) error {
+	var user models.User
+	if err := tx.Clauses(clause.Locking{Strength: "SHARE"}).Select("id", "deleted_at").First(&user, userID).Error; err != nil {
+		return err
+	}
+	if user.DeletedAt != nil {
+		return models.ErrUserDeleted
+	}
+
+	return nil
+}
+
+func sameProject(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+// releaseRunningTask makes sure the user has no running task other than exceptID.
+// The running task is stopped when stopRunning is set, otherwise a conflict is reported.
+func releaseRunningTask(tx *gorm.DB, userID uint, exceptID uint, stopRunning bool, now time.Time) ([]models.BudgetAlert, error) {
+	var running models.Task
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND status = ? AND id <> ?", userID, models.TaskStatusRunning, exceptID).
+		First(&running).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !stopRunning {
+		return nil, &models.TaskConflictError{Err: models.ErrTaskAlreadyRunning, Tasks: []models.Task{running}}
+	}
+
+	before, err := taskAuditState(tx, &running)
+	if err != nil {
+		return nil, err
+	}
+	return stopLockedTask(tx, &running, before, now, nil)
+}
+
+// stopLockedTask closes the open segment of the task, records that it stopped from the state
+// before in the audit log and the outbox and reports the budget thresholds that the time
+// recorded by the stop crossed. The task is marked as auto-stopped if autoStopReason is given.
+func stopLockedTask(tx *gorm.DB, task *models.Task, before *models.TaskAuditState, now time.Time,
+	autoStopReason *string) ([]models.BudgetAlert, error) {
+	if err := closeOpenSegment(tx, task.ID, now); err != nil {
+		return nil, err
+	}
+	if err := endFocusSession(tx, task); err != nil {
+		return nil, err
+	}
+
+	recordedMinutes := task.Minutes
+	task.Status = models.TaskStatusStopped
+	task.EndTime = now
+	if autoStopReason != nil {
+		task.AutoStopped = true
+		task.AutoStopReason = autoStopReason
+	}
+	if err := applySegmentDuration(tx, task); err != nil {
+		return nil, err
+	}
+	if err := tx.Save(task).Error; err != nil {
+		return nil, err
+	}
+	if err := addTaskAuditEntry(tx, models.AuditActionStop, before, task); err != nil {
+		return nil, err
+	}
+	if err := addTaskEvent(tx, models.EventTaskStopped, task); err != nil {
+		return nil, err
+	}
+
+	return crossedBudgetThresholds(tx, task, task.Minutes-recordedMinutes)
+}
+
+// notifyBudgetAlerts hands the alerts raised by a committed stop to the notifier. A failed
+// notification is only logged, as the task has been stopped regardless.
+func (r *TaskRepositoryImpl) notifyBudgetAlerts(alerts []models.BudgetAlert) {
+	for _, alert := range alerts {
+		if err := r.notifier.NotifyBudgetAlert(alert); err != nil {
+			r.logger.Errorf("notifyBudgetAlerts: failed to notify about budget with ID %d: %v", alert.Budget.ID, err)
+		}
+	}
+}
+
+// loadFocusSession loads the focus session of the task, if it has one and it is not loaded yet.
+func loadFocusSession(tx *gorm.DB, task *models.Task) error {
+	if task.FocusSession != nil {
+		return nil
+	}
+
+	var sessions []models.FocusSession
+	if err := tx.Where("task_id = ?", task.ID).Limit(1).Find(&sessions).Error; err != nil {
+		return err
+	}
+	if len(sessions) > 0 {
+		task.FocusSession = &sessions[0]
+	}
+
+	return nil
+}
+
+// endFocusSession ends the focus session of the task, if one is in progress, as when the
+// user pauses or stops the task by hand. The task is then an ordinary timer.
+func endFocusSession(tx *gorm.DB, task *models.Task) error {
+	if err := loadFocusSession(tx, task); err != nil {
+		return err
+	}
+	if task.FocusSession == nil || task.FocusSession.Phase == models.FocusPhaseDone {
+		return nil
+	}
+
+	task.FocusSession.Phase = models.FocusPhaseDone
+	task.FocusSession.PhaseEndsAt = nil
+	return tx.Save(task.FocusSession).Error
+}
+
+// resumeFocusSession starts the next work interval at now if the task is resumed by hand
+// during a break.
+func resumeFocusSession(tx *gorm.DB, task *models.Task, now time.Time) error {
+	if err := loadFocusSession(tx, task); err != nil {
+		return err
+	}
+	if task.FocusSession == nil || task.FocusSession.Phase != models.FocusPhaseBreak {
+		return nil
+	}
+
+	workEndsAt := now.Add(task.FocusSession.WorkDuration())
+	task.FocusSession.Phase = models.FocusPhaseWork
+	task.FocusSession.PhaseEndsAt = &workEndsAt
+	return tx.Save(task.FocusSession).Error
+}
+
+// advanceFocusPhase ends the current phase of the focus session of the locked task at the
+// time it was due and starts the next one.
+func advanceFocusPhase(tx *gorm.DB, task *models.Task) ([]models.BudgetAlert, error) {
+	before := models.NewTaskAuditState(task)
+	step, err := task.FocusSession.EndPhase(task.Status, func(breakEnd time.Time) (bool, error) {
+		return focusSessionResumable(tx, task, breakEnd)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch step.Action {
+	case models.FocusActionStop:
+		task.FocusSession.CompletedCycles = step.Session.CompletedCycles
+		return stopLockedTask(tx, task, before, step.At, nil)
+	case models.FocusActionEnd:
+		return nil, endFocusSession(tx, task)
+	}
+
+	action, event := models.AuditActionPause, models.EventTaskPaused
+	if step.Action == models.FocusActionPause {
+		if err := closeOpenSegment(tx, task.ID, step.At); err != nil {
+			return nil, err
+		}
+		task.Status = models.TaskStatusPaused
+		if err := applySegmentDuration(tx, task); err != nil {
+			return nil, err
+		}
+	} else {
+		segment := models.TaskSegment{TaskID: task.ID, StartTime: step.At}
+		if err := tx.Create(&segment).Error; err != nil {
+			return nil, err
+		}
+		task.Status = models.TaskStatusRunning
+		action, event = models.AuditActionResume, models.EventTaskResumed
+	}
+	if err := tx.Save(task).Error; err != nil {
+		return nil, err
+	}
+	*task.FocusSession = step.Session
+	if err := tx.Save(task.FocusSession).Error; err != nil {
+		return nil, err
+	}
+	if err := addTaskAuditEntry(tx, action, before, task); err != nil {
+		return nil, err
+	}
+
+	return nil, addTaskEvent(tx, event, task)
+}
+
+// focusSessionResumable reports whether the task can go back to work when the break ending
+// at breakEnd is over, as models.ResumableAfterBreak decides from the other work of the user
+// that could overlap it and the project of the task.
+func focusSessionResumable(tx *gorm.DB, task *models.Task, breakEnd time.Time) (bool, error) {
+	var others []models.Task
+	err := tx.Select("id", "status", "end_time").
+		Where("user_id = ? AND id <> ? AND (status = ? OR (status = ? AND end_time >= ?))",
+			task.UserID, task.ID, models.TaskStatusRunning, models.TaskStatusStopped, breakEnd).
+		Find(&others).Error
+	if err != nil {
+		return false, err
+	}
+
+	err = checkProjectActive(tx, task.ProjectID)
+	if err != nil && !errors.Is(err, models.ErrProjectArchived) {
+		return false, err
+	}
+
+	return models.ResumableAfterBreak(breakEnd, others, err != nil), nil
+}
+
+func closeOpenSegment(tx *gorm.DB, taskID uint, endTime time.Time) error {
+	return tx.Model(&models.TaskSegment{}).
+		Where("task_id = ? AND end_time IS NULL", taskID).
+		Update("end_time", endTime).Error
+}
+
+// checkOverlap reports the other tasks of the user that intersect the interval of task.
+// Tasks that are still running or paused are treated as open-ended, planned tasks are ignored.
+// The per-user advisory lock keeps concurrent writers from slipping an overlap in between.
+func checkOverlap(tx *gorm.DB, task *models.Task) error {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", task.UserID).Error; err != nil {
+		return err
+	}
+
+	var clashing []models.Task
+	err := tx.Where("user_id = ? AND id <> ? AND status <> ? AND start_time < ? AND (status <> ? OR end_time > ?)",
+		task.UserID, task.ID, models.TaskStatusPlanned, task.EndTime, models.TaskStatusStopped, task.StartTime).
+		Order("start_time").
+		Find(&clashing).Error
+	if err != nil {
+		return err
+	}
+
+	if len(clashing) > 0 {
+		return &models.TaskConflictError{Err: models.ErrTaskOverlap, Tasks: clashing}
+	}
+
+	return nil
+}
+
+// applySegmentDuration recalculates Hours and Minutes of the task as the sum of its closed segments.
+func applySegmentDuration(tx *gorm.DB, task *models.Task) error {
+	var segments []models.TaskSegment
+	if err := tx.Where("task_id = ? AND end_time IS NOT NULL", task.ID).Find(&segments).Error; err != nil {
+		return err
+	}
+
+	var duration time.Duration
+	for _, segment := range segments {
+		duration += segment.EndTime.Sub(segment.StartTime)
+	}
+
+	applyDuration(task, duration)
+	return nil
+}
+
+func applyDuration(task *models.Task, duration time.Duration) {
+	task.Hours = int(duration.Hours())
+	task.Minutes = int(duration.Minutes())
+}
@@ -0,0 +1,11 @@
+package repositories
+
+import "github.com/Dor1ma/Time-Tracker/internal/models"
+
+type TaskTemplateRepository interface {
+	Create(template *models.TaskTemplate) error
+	GetById(id uint) (*models.TaskTemplate, error)
+	GetAll(userID *uint) ([]models.TaskTemplate, error)
+	Update(template *models.TaskTemplate) error
+	Delete(id uint) error
+}
@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TaskTemplateRepositoryImpl struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewTaskTemplateRepositoryImpl(db *gorm.DB, logger *logrus.Logger) *TaskTemplateRepositoryImpl {
+	return &TaskTemplateRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *TaskTemplateRepositoryImpl) Create(template *models.TaskTemplate) error {
+	r.logger.Infof("Create: creating task template in database for user ID %d, task name: %s", template.UserID, template.TaskName)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := resolveTags(tx, template.Tags); err != nil {
+			return err
+		}
+
+		return tx.Create(template).Error
+	})
+	if err != nil {
+		r.logger.Errorf("Create: failed to create task template in database: %v", err)
+		return err
+	}
+
+	r.logger.Infof("Create: task template created in database successfully with ID %d", template.ID)
+	return nil
+}
+
+func (r *TaskTemplateRepositoryImpl) GetById(id uint) (*models.TaskTemplate, error) {
+	var template models.TaskTemplate
+	result := r.db.Preload("Tags", func(db *gorm.DB) *gorm.DB {
+		return db.Order("name")
+	}).First(&template, id)
+	if result.Error != nil {
+		r.logger.Errorf("GetById: failed to get task template from database with ID %d: %v", id, result.Error)
+		return nil, result.Error
+	}
+
+	r.logger.Infof("GetById: successfully retrieved task template from database with ID %d", id)
+	return &template, nil
+}
+
+func (r *TaskTemplateRepositoryImpl) GetAll(userID *uint) ([]models.TaskTemplate, error) {
+	var templates []models.TaskTemplate
+	query := r.db.Preload("Tags", func(db *gorm.DB) *gorm.DB {
+		return db.Order("name")
+	})
+	if userID != nil {
+		query = query.Where("user_id = ?", *userID)
+	}
+
+	if err := query.Order("id").Find(&templates).Error; err != nil {
+		r.logger.Errorf("GetAll: failed to fetch task templates from database: %v", err)
+		return nil, err
+	}
+
+	r.logger.Infof("GetAll: successfully fetched %d task templates from database", len(templates))
+	return templates, nil
+}
+
+// Update saves the template and replaces its tags.
+func (r *TaskTemplateRepositoryImpl) Update(template *models.TaskTemplate) error {
+	r.logger.Infof("Update: updating task template in database with ID %d", template.ID)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := resolveTags(tx, template.Tags); err != nil {
+			return err
+		}
+		if err := tx.Model(template).Association("Tags").Replace(template.Tags); err != nil {
+			return err
+		}
+
+		return tx.Omit(clause.Associations).Save(template).Error
+	})
+	if err != nil {
+		r.logger.Errorf("Update: failed to update task template with ID %d: %v", template.ID, err)
+		return err
+	}
+
+	r.logger.Infof("Update: task template with ID %d updated successfully in database", template.ID)
+	return nil
+}
+
+// Delete removes the template together with its recurrences and the planned tasks they
+// created. Recorded tasks are kept.
+func (r *TaskTemplateRepositoryImpl) Delete(id uint) error {
+	r.logger.Infof("Delete: deleting task template from database with ID %d", id)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("status = ? AND recurrence_id IN (?)", models.TaskStatusPlanned,
+			tx.Model(&models.Recurrence{}).Select("id").Where("template_id = ?", id)).
+			Delete(&models.Task{}).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Delete(&models.TaskTemplate{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		r.logger.Errorf("Delete: failed to delete task template from database with ID %d: %v", id, err)
+		return err
+	}
+
+	r.logger.Infof("Delete: task template with ID %d deleted from database successfully", id)
+	return nil
+}
@@ -0,0 +1,11 @@
+package repositories
+
+import "github.com/Dor1ma/Time-Tracker/internal/models"
+
+type TeamRepository interface {
+	Create(team *models.Team, userIDs []uint) error
+	GetById(id uint) (*models.Team, error)
+	GetAll() ([]models.Team, error)
+	Update(team *models.Team, userIDs []uint) error
+	Delete(id uint) error
+}
@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TeamRepositoryImpl struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewTeamRepositoryImpl(db *gorm.DB, logger *logrus.Logger) *TeamRepositoryImpl {
+	return &TeamRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *TeamRepositoryImpl) Create(team *models.Team, userIDs []uint) error {
+	r.logger.Infof("Create: creating team in database with name %s and %d members", team.Name, len(userIDs))
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(team).Error; err != nil {
+			return err
+		}
+
+		return replaceTeamMembers(tx, team, userIDs)
+	})
+	if err != nil {
+		r.logger.Errorf("Create: failed to create team in database: %v", err)
+		return err
+	}
+
+	r.logger.Infof("Create: team created in database successfully with ID %d", team.ID)
+	return nil
+}
+
+func (r *TeamRepositoryImpl) GetById(id uint) (*models.Team, error) {
+	var team models.Team
+	result := r.db.Preload("Members", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).First(&team, id)
+	if result.Error != nil {
+		r.logger.Errorf("GetById: failed to get team from database with ID %d: %v", id, result.Error)
+		return nil, result.Error
+	}
+
+	r.logger.Infof("GetById: successfully retrieved team from database with ID %d", id)
+	return &team, nil
+}
+
+func (r *TeamRepositoryImpl) GetAll() ([]models.Team, error) {
+	var teams []models.Team
+	result := r.db.Preload("Members", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Order("name").Find(&teams)
+	if result.Error != nil {
+		r.logger.Errorf("GetAll: failed to fetch all teams from database: %v", result.Error)
+		return nil, result.Error
+	}
+
+	r.logger.Infof("GetAll: successfully fetched %d teams from database", len(teams))
+	return teams, nil
+}
+
+func (r *TeamRepositoryImpl) Update(team *models.Team, userIDs []uint) error {
+	r.logger.Infof("Update: updating team in database with ID %d", team.ID)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(team).Error; err != nil {
+			return err
+		}
+
+		return replaceTeamMembers(tx, team, userIDs)
+	})
+	if err != nil {
+		r.logger.Errorf("Update: failed to update team in database with ID %d: %v", team.ID, err)
+		return err
+	}
+
+	r.logger.Infof("Update: team with ID %d updated successfully in database", team.ID)
+	return nil
+}
+
+func (r *TeamRepositoryImpl) Delete(id uint) error {
+	r.logger.Infof("Delete: deleting team from database with ID %d", id)
+	result := r.db.Delete(&models.Team{}, id)
+	if result.Error != nil {
+		r.logger.Errorf("Delete: failed to delete team from database with ID %d: %v", id, result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	r.logger.Infof("Delete: team with ID %d deleted successfully from database", id)
+	return nil
+}
+
+// replaceTeamMembers makes exactly the given users members of the team. Unknown users
+// are rejected rather than created through the association.
+func replaceTeamMembers(tx *gorm.DB, team *models.Team, userIDs []uint) error {
+	var members []models.User
+	if len(userIDs) > 0 {
+		if err := tx.Where("id IN ?", userIDs).Order("id").Find(&members).Error; err != nil {
+			return err
+		}
+		if len(members) != len(userIDs) {
+			return gorm.ErrRecordNotFound
+		}
+	}
+
+	if err := tx.Exec("DELETE FROM team_members WHERE team_id = ?", team.ID).Error; err != nil {
+		return err
+	}
+	for _, member := range members {
+		if err := tx.Exec("INSERT INTO team_members (team_id, user_id) VALUES (?, ?)", team.ID, member.ID).Error; err != nil {
+			return err
+		}
+	}
+
+	team.Members = members
+	return nil
+}
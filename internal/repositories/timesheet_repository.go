@@ -0,0 +1,10 @@
+package repositories
+
+import "github.com/Dor1ma/Time-Tracker/internal/models"
+
+type TimesheetRepository interface {
+	Create(timesheet *models.Timesheet) error
+	GetById(id uint) (*models.Timesheet, error)
+	GetAll(userID *uint, status string) ([]models.Timesheet, error)
+	Transition(id uint, from []string, to string, actorID uint, comment string) (*models.Timesheet, error)
+}
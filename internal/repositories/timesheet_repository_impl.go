@@ -0,0 +1,196 @@
+package repositories
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TimesheetRepositoryImpl struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewTimesheetRepositoryImpl(db *gorm.DB, logger *logrus.Logger) *TimesheetRepositoryImpl {
+	return &TimesheetRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Create stores a draft timesheet and records its creation by its user.
+func (r *TimesheetRepositoryImpl) Create(timesheet *models.Timesheet) error {
+	r.logger.Infof("Create: creating timesheet in database for user ID %d and week %s",
+		timesheet.UserID, timesheet.WeekStart.Format(time.DateOnly))
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		err := tx.Model(&models.Timesheet{}).
+			Where("user_id = ? AND week_start = ?", timesheet.UserID, timesheet.WeekStart).
+			Count(&existing).Error
+		if err != nil {
+			return err
+		}
+		if existing > 0 {
+			return models.ErrTimesheetExists
+		}
+
+		timesheet.Status = models.TimesheetStatusDraft
+		if err := tx.Omit(clause.Associations).Create(timesheet).Error; err != nil {
+			return err
+		}
+
+		return recordTimesheetTransition(tx, timesheet, nil, timesheet.UserID, "")
+	})
+	if err != nil {
+		r.logger.Errorf("Create: failed to create timesheet in database: %v", err)
+		return err
+	}
+
+	r.logger.Infof("Create: timesheet created in database successfully with ID %d", timesheet.ID)
+	return nil
+}
+
+func (r *TimesheetRepositoryImpl) GetById(id uint) (*models.Timesheet, error) {
+	var timesheet models.Timesheet
+	result := r.db.Preload("Transitions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).First(&timesheet, id)
+	if result.Error != nil {
+		r.logger.Errorf("GetById: failed to get timesheet from database with ID %d: %v", id, result.Error)
+		return nil, result.Error
+	}
+
+	r.logger.Infof("GetById: successfully retrieved timesheet from database with ID %d", id)
+	return &timesheet, nil
+}
+
+// GetAll returns the timesheets of the user, or of all users if userID is nil,
+// optionally only those in status, latest week first.
+func (r *TimesheetRepositoryImpl) GetAll(userID *uint, status string) ([]models.Timesheet, error) {
+	var timesheets []models.Timesheet
+	query := r.db.Model(&models.Timesheet{})
+	if userID != nil {
+		query = query.Where("user_id = ?", *userID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	result := query.Order("week_start DESC, user_id").Find(&timesheets)
+	if result.Error != nil {
+		r.logger.Errorf("GetAll: failed to fetch timesheets from database: %v", result.Error)
+		return nil, result.Error
+	}
+
+	r.logger.Infof("GetAll: successfully fetched %d timesheets from database", len(timesheets))
+	return timesheets, nil
+}
+
+// Transition moves the timesheet to status to on behalf of actorID and records the change.
+// It fails with ErrInvalidTimesheetStatus unless the timesheet is in one of the from statuses,
+// and, when submitting, with ErrTimesheetHasOpenTasks while a task of the week is still open.
+func (r *TimesheetRepositoryImpl) Transition(id uint, from []string, to string, actorID uint, comment string) (*models.Timesheet, error) {
+	var timesheet models.Timesheet
+	r.logger.Infof("Transition: moving timesheet with ID %d to %s", id, to)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&timesheet, id).Error; err != nil {
+			return err
+		}
+		if !slices.Contains(from, timesheet.Status) {
+			return models.ErrInvalidTimesheetStatus
+		}
+
+		if to == models.TimesheetStatusSubmitted {
+			if err := checkWeekStopped(tx, &timesheet); err != nil {
+				return err
+			}
+		}
+
+		previous := timesheet.Status
+		timesheet.Status = to
+		if err := tx.Omit(clause.Associations).Save(&timesheet).Error; err != nil {
+			return err
+		}
+
+		return recordTimesheetTransition(tx, &timesheet, &previous, actorID, comment)
+	})
+	if err != nil {
+		r.logger.Errorf("Transition: failed to move timesheet with ID %d to %s: %v", id, to, err)
+		return nil, err
+	}
+
+	if err := r.db.Where("timesheet_id = ?", id).Order("id").Find(&timesheet.Transitions).Error; err != nil {
+		r.logger.Debugf("Transition: failed to load transitions of timesheet with ID %d: %v", id, err)
+	}
+
+	r.logger.Infof("Transition: timesheet with ID %d moved to %s", id, to)
+	return &timesheet, nil
+}
+
+func recordTimesheetTransition(tx *gorm.DB, timesheet *models.Timesheet, from *string, actorID uint, comment string) error {
+	transition := models.TimesheetTransition{
+		TimesheetID: timesheet.ID,
+		FromStatus:  from,
+		ToStatus:    timesheet.Status,
+		ActorID:     &actorID,
+		Comment:     comment,
+	}
+	if err := tx.Create(&transition).Error; err != nil {
+		return err
+	}
+
+	timesheet.Transitions = append(timesheet.Transitions, transition)
+	return nil
+}
+
+// checkWeekStopped reports ErrTimesheetHasOpenTasks while a task of the week is running or paused,
+// since the time of such a task is not final yet. Planned tasks record no time and do not count.
+func checkWeekStopped(tx *gorm.DB, timesheet *models.Timesheet) error {
+	var open int64
+	weekStart := timesheet.WeekStart.Format(time.DateOnly)
+	err := tx.Model(&models.Task{}).
+		Joins("JOIN users u ON u.id = tasks.user_id").
+		Where(`tasks.user_id = ? AND tasks.status NOT IN (?, ?)
+			AND tasks.start_time >= (?::date)::timestamp AT TIME ZONE u.time_zone
+			AND tasks.start_time < (?::date + 7)::timestamp AT TIME ZONE u.time_zone`,
+			timesheet.UserID, models.TaskStatusStopped, models.TaskStatusPlanned, weekStart, weekStart).
+		Count(&open).Error
+	if err != nil {
+		return err
+	}
+	if open > 0 {
+		return models.ErrTimesheetHasOpenTasks
+	}
+
+	return nil
+}
+
+// checkWeekUnlocked reports ErrTimesheetLocked if a task of the user started at startTime
+// belongs to a submitted or approved timesheet, weeks being those of the user's time zone.
+// The timesheet row is share-locked, so that the timesheet cannot be submitted before
+// the transaction changing the task ends.
+func checkWeekUnlocked(tx *gorm.DB, userID uint, startTime time.Time) error {
+	var timesheet models.Timesheet
+	err := tx.Clauses(clause.Locking{Strength: "SHARE"}).
+		Where(`user_id = ? AND week_start =
+			(SELECT date_trunc('week', ? AT TIME ZONE u.time_zone)::date FROM users u WHERE u.id = ?)`,
+			userID, startTime, userID).
+		First(&timesheet).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if timesheet.Locked() {
+		return models.ErrTimesheetLocked
+	}
+
+	return nil
+}
@@ -1,12 +1,24 @@
 package repositories
 
-import "github.com/Dor1ma/Time-Tracker/internal/models"
+import (
+	"context"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+)
 
 type UserRepository interface {
-	Create(user *models.User) error
+	Create(ctx context.Context, user *models.User) error
 	GetById(id uint) (*models.User, error)
 	GetAll() ([]models.User, error)
-	GetAllWithFiltersAndPagination(filters map[string]interface{}, page int, pageSize int) ([]models.User, error)
-	Update(user *models.User) error
-	Delete(id uint) error
+	GetAllWithFiltersAndPagination(filters map[string]interface{}, page int, pageSize int, includeDeleted bool) ([]models.User, error)
+	Update(ctx context.Context, user *models.User) error
+	SetCalendarTokenHash(id uint, hash string) error
+	Delete(ctx context.Context, id uint) error
+	Restore(ctx context.Context, id uint) (*models.User, error)
+	Purge(ctx context.Context, deletedBefore time.Time) ([]uint, error)
+	Erase(ctx context.Context, id uint, erasure *models.Erasure) (*models.User, error)
+	GetErasure(userID uint) (*models.Erasure, error)
+	SealPassports(limit int) (int, error)
+	OpenPassport(user *models.User) error
 }
@@ -1,27 +1,58 @@
 package repositories
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/secrets"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+	"time"
 )
 
+// userNotInvoiced selects the users none of whose tasks have been invoiced.
+const userNotInvoiced = "NOT EXISTS (SELECT 1 FROM tasks WHERE tasks.user_id = users.id AND tasks.invoice_id IS NOT NULL)"
+
+// UserRepositoryImpl seals the passport numbers of the users it stores with keyring. It returns
+// users with their passport numbers sealed; OpenPassport opens them for the callers that show
+// them.
 type UserRepositoryImpl struct {
-	db     *gorm.DB
-	logger *logrus.Logger
+	db      *gorm.DB
+	keyring *secrets.Keyring
+	logger  *logrus.Logger
 }
 
-func NewUserRepositoryImpl(db *gorm.DB, logger *logrus.Logger) *UserRepositoryImpl {
+func NewUserRepositoryImpl(db *gorm.DB, keyring *secrets.Keyring, logger *logrus.Logger) *UserRepositoryImpl {
 	return &UserRepositoryImpl{
-		db:     db,
-		logger: logger,
+		db:      db,
+		keyring: keyring,
+		logger:  logger,
 	}
 }
 
-func (r *UserRepositoryImpl) Create(user *models.User) error {
-	r.logger.Infof("Create: creating user in database with PassportNumber %s", user.PassportNumber)
-	if err := r.db.Create(user).Error; err != nil {
+func (r *UserRepositoryImpl) Create(ctx context.Context, user *models.User) error {
+	r.logger.Infof("Create: creating user in database")
+	if err := r.sealPassport(user); err != nil {
+		r.logger.Errorf("Create: failed to seal passport number: %v", err)
+		return err
+	}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkPassportUnique(tx, r.keyring.Hashes(user.PassportNumber)[1:]); err != nil {
+			return err
+		}
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		if err := addUserAuditEntry(tx, models.AuditActionCreate, nil, user); err != nil {
+			return err
+		}
+
+		return addUserEvent(tx, models.EventUserCreated, user.ID)
+	})
+	if err != nil {
 		r.logger.Errorf("Create: failed to create user in database: %v", err)
 		return err
 	}
@@ -30,6 +61,7 @@ func (r *UserRepositoryImpl) Create(user *models.User) error {
 	return nil
 }
 
+// GetById returns the user with the given ID, whether deleted or not.
 func (r *UserRepositoryImpl) GetById(id uint) (*models.User, error) {
 	var user models.User
 	result := r.db.First(&user, id)
@@ -42,9 +74,10 @@ func (r *UserRepositoryImpl) GetById(id uint) (*models.User, error) {
 	return &user, nil
 }
 
+// GetAll returns the users that are not deleted.
 func (r *UserRepositoryImpl) GetAll() ([]models.User, error) {
 	var users []models.User
-	result := r.db.Find(&users)
+	result := r.db.Where("deleted_at IS NULL").Find(&users)
 	if result.Error != nil {
 		r.logger.Errorf("GetAll: failed to fetch all users from database: %v", result.Error)
 		return nil, result.Error
@@ -54,15 +87,23 @@ func (r *UserRepositoryImpl) GetAll() ([]models.User, error) {
 	return users, nil
 }
 
-func (r *UserRepositoryImpl) GetAllWithFiltersAndPagination(filters map[string]interface{}, page int, pageSize int) ([]models.User, error) {
+func (r *UserRepositoryImpl) GetAllWithFiltersAndPagination(filters map[string]interface{}, page int, pageSize int, includeDeleted bool) ([]models.User, error) {
 	var users []models.User
 	query := r.db.Model(&models.User{})
+	if !includeDeleted {
+		query = query.Where("deleted_at IS NULL")
+	}
 
 	r.logger.Debugf("GetAllWithFiltersAndPagination: original filters: %v", filters)
 	delete(filters, "page")
 	delete(filters, "pageSize")
 
 	for key, value := range filters {
+		// Passport numbers are sealed, they are looked up by their hash under any hash key.
+		if key == "passport_number" {
+			query = query.Where("passport_hash IN ?", r.keyring.Hashes(fmt.Sprint(value)))
+			continue
+		}
 		query = query.Where(fmt.Sprintf("%s = ?", key), value)
 	}
 
@@ -77,9 +118,24 @@ func (r *UserRepositoryImpl) GetAllWithFiltersAndPagination(filters map[string]i
 	return users, nil
 }
 
-func (r *UserRepositoryImpl) Update(user *models.User) error {
+func (r *UserRepositoryImpl) Update(ctx context.Context, user *models.User) error {
 	r.logger.Infof("Update: updating user in database with ID %d", user.ID)
-	if err := r.db.Save(user).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var stored models.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&stored, user.ID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Save(user).Error; err != nil {
+			return err
+		}
+		if err := addUserAuditEntry(tx, models.AuditActionUpdate, models.NewUserAuditState(&stored), user); err != nil {
+			return err
+		}
+
+		return addUserEvent(tx, models.EventUserUpdated, user.ID)
+	})
+	if err != nil {
 		r.logger.Errorf("Update: failed to update user in database with ID %d: %v", user.ID, err)
 		return err
 	}
@@ -88,13 +144,355 @@ func (r *UserRepositoryImpl) Update(user *models.User) error {
 	return nil
 }
 
-func (r *UserRepositoryImpl) Delete(id uint) error {
-	r.logger.Infof("Delete: deleting user from database with ID %d", id)
-	if err := r.db.Delete(&models.User{}, id).Error; err != nil {
-		r.logger.Errorf("Delete: failed to delete user from database with ID %d: %v", id, err)
+// SetCalendarTokenHash replaces the calendar token of the user, which revokes the previous one.
+func (r *UserRepositoryImpl) SetCalendarTokenHash(id uint, hash string) error {
+	r.logger.Infof("SetCalendarTokenHash: replacing calendar token of user with ID %d", id)
+	result := r.db.Model(&models.User{}).Where("id = ?", id).Update("calendar_token_hash", hash)
+	if result.Error != nil {
+		r.logger.Errorf("SetCalendarTokenHash: failed to replace calendar token of user with ID %d: %v", id, result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// Delete marks the user as deleted, keeping the user and their tasks until they are purged.
+// A user whose timer is running cannot be deleted: the conflict names the running task.
+func (r *UserRepositoryImpl) Delete(ctx context.Context, id uint) error {
+	r.logger.Infof("Delete: deleting user in database with ID %d", id)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, id).Error; err != nil {
+			return err
+		}
+		if user.DeletedAt != nil {
+			return models.ErrUserDeleted
+		}
+		before := models.NewUserAuditState(&user)
+
+		now := time.Now()
+		if _, err := releaseRunningTask(tx, id, 0, false, now); err != nil {
+			return err
+		}
+		if err := tx.Model(&user).Update("deleted_at", now).Error; err != nil {
+			return err
+		}
+		if err := addUserAuditEntry(tx, models.AuditActionDelete, before, &user); err != nil {
+			return err
+		}
+
+		return addUserEvent(tx, models.EventUserDeleted, id)
+	})
+	if err != nil {
+		r.logger.Errorf("Delete: failed to delete user in database with ID %d: %v", id, err)
+		return err
+	}
+
+	r.logger.Infof("Delete: user with ID %d deleted in database successfully", id)
+	return nil
+}
+
+// Restore brings back a deleted user that has not been purged yet.
+func (r *UserRepositoryImpl) Restore(ctx context.Context, id uint) (*models.User, error) {
+	var user models.User
+	r.logger.Infof("Restore: restoring user in database with ID %d", id)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, id).Error; err != nil {
+			return err
+		}
+		if user.DeletedAt == nil {
+			return models.ErrUserNotDeleted
+		}
+		before := models.NewUserAuditState(&user)
+
+		user.DeletedAt = nil
+		if err := tx.Model(&user).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		if err := addUserAuditEntry(tx, models.AuditActionRestore, before, &user); err != nil {
+			return err
+		}
+
+		return addUserEvent(tx, models.EventUserRestored, id)
+	})
+	if err != nil {
+		r.logger.Errorf("Restore: failed to restore user in database with ID %d: %v", id, err)
+		return nil, err
+	}
+
+	r.logger.Infof("Restore: user with ID %d restored in database successfully", id)
+	return &user, nil
+}
+
+// Purge deletes for good the users deleted before deletedBefore, together with their tasks
+// and rates, and returns their IDs. Users with invoiced tasks are kept, as invoices must keep
+// the tasks they bill. Each user is purged in a transaction of their own.
+func (r *UserRepositoryImpl) Purge(ctx context.Context, deletedBefore time.Time) ([]uint, error) {
+	r.logger.Infof("Purge: purging users deleted before %s", deletedBefore.Format(time.RFC3339))
+	var userIDs []uint
+	err := r.db.Model(&models.User{}).
+		Where("deleted_at < ?", deletedBefore).
+		Where(userNotInvoiced).
+		Order("id").
+		Pluck("id", &userIDs).Error
+	if err != nil {
+		r.logger.Errorf("Purge: failed to fetch users to purge from database: %v", err)
+		return nil, err
+	}
+
+	purged := make([]uint, 0, len(userIDs))
+	for _, id := range userIDs {
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var user models.User
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("deleted_at < ?", deletedBefore).
+				Where(userNotInvoiced).
+				First(&user, id).Error
+			if err != nil {
+				return err
+			}
+
+			if err := tx.Where("user_id = ?", id).Delete(&models.Task{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("user_id = ?", id).Delete(&models.Rate{}).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Delete(&user).Error; err != nil {
+				return err
+			}
+			if err := addAuditEntry(tx, models.AuditActionPurge, models.AuditEntityUser, id, nil, nil); err != nil {
+				return err
+			}
+			return addUserEvent(tx, models.EventUserPurged, id)
+		})
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			r.logger.Errorf("Purge: failed to purge user with ID %d from database: %v", id, err)
+			return purged, err
+		}
+		purged = append(purged, id)
+	}
+
+	r.logger.Infof("Purge: purged %d users from database", len(purged))
+	return purged, nil
+}
+
+// Erase anonymises the personal data of the user, redacts it from the audit log and deletes
+// the webhook deliveries that carried it, all in one transaction that also stores erasure as
+// proof. The tasks of the user are kept. Erasure cannot be undone.
+func (r *UserRepositoryImpl) Erase(ctx context.Context, id uint, erasure *models.Erasure) (*models.User, error) {
+	var user models.User
+	r.logger.Infof("Erase: erasing personal data of user in database with ID %d", id)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, id).Error; err != nil {
+			return err
+		}
+		if user.ErasedAt != nil {
+			return models.ErrUserErased
+		}
+
+		now := time.Now()
+		user.PassportNumber = fmt.Sprintf("erased-%d", user.ID)
+		if err := r.sealPassport(&user); err != nil {
+			return err
+		}
+		user.Surname = ""
+		user.Name = ""
+		user.Patronymic = ""
+		user.Address = ""
+		user.WorkdayEnd = nil
+		user.CalendarTokenHash = nil
+		user.ErasedAt = &now
+		if err := tx.Save(&user).Error; err != nil {
+			return err
+		}
+
+		var err error
+		if erasure.AuditEntriesRedacted, err = redactUserAuditEntries(tx, id, models.PersonalDataFields); err != nil {
+			return err
+		}
+		if erasure.WebhookDeliveriesDeleted, err = deleteUserWebhookDeliveries(tx, id); err != nil {
+			return err
+		}
+
+		erasure.UserID = id
+		erasure.Fields = strings.Join(models.PersonalDataFields, ",")
+		erasure.ErasedAt = now
+		if err := tx.Create(erasure).Error; err != nil {
+			return err
+		}
+		if err := addAuditEntry(tx, models.AuditActionErase, models.AuditEntityUser, id, nil, nil); err != nil {
+			return err
+		}
+
+		return addUserEvent(tx, models.EventUserErased, id)
+	})
+	if err != nil {
+		r.logger.Errorf("Erase: failed to erase personal data of user in database with ID %d: %v", id, err)
+		return nil, err
+	}
+
+	r.logger.Infof("Erase: personal data of user with ID %d erased in database successfully", id)
+	return &user, nil
+}
+
+// GetErasure returns the proof of the erasure of the personal data of the user.
+func (r *UserRepositoryImpl) GetErasure(userID uint) (*models.Erasure, error) {
+	var erasure models.Erasure
+	if err := r.db.Where("user_id = ?", userID).First(&erasure).Error; err != nil {
+		r.logger.Errorf("GetErasure: failed to get erasure of user with ID %d from database: %v", userID, err)
+		return nil, err
+	}
+
+	return &erasure, nil
+}
+
+// passportRow is the passport number of a user as stored, in plain text in PassportNumber if it
+// was stored before passport numbers were sealed.
+type passportRow struct {
+	ID                 uint
+	PassportNumber     *string
+	PassportCiphertext []byte
+	PassportDataKey    []byte
+	PassportKeyID      *string
+	PassportHashKeyID  *string
+}
+
+// SealPassports seals up to limit passport numbers stored in plain text, rewraps by the current
+// master key the data keys of those sealed under an older one, and rehashes by the current hash
+// key those hashed under an older one. It returns the number of users updated. The rows are
+// locked, so instances running it at once share the work.
+func (r *UserRepositoryImpl) SealPassports(limit int) (int, error) {
+	var rows []passportRow
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Table("users").
+			Select("id, passport_number, passport_ciphertext, passport_data_key, passport_key_id, passport_hash_key_id").
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("passport_key_id IS NULL OR passport_key_id <> ? OR passport_hash_key_id IS NULL OR passport_hash_key_id <> ?",
+				r.keyring.CurrentKeyID(), r.keyring.CurrentHashKeyID()).
+			Order("id").
+			Limit(limit).
+			Find(&rows).Error
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			updates, err := r.resealPassport(row)
+			if err != nil {
+				return fmt.Errorf("user %d: %w", row.ID, err)
+			}
+			if err := tx.Table("users").Where("id = ?", row.ID).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.Errorf("SealPassports: failed to seal passport numbers in database: %v", err)
+		return 0, err
+	}
+
+	return len(rows), nil
+}
+
+// resealPassport returns the columns to update to seal the passport number of row, or to rewrap
+// its data key and rehash it if it is sealed already.
+func (r *UserRepositoryImpl) resealPassport(row passportRow) (map[string]interface{}, error) {
+	if row.PassportKeyID == nil {
+		if row.PassportNumber == nil {
+			return nil, errors.New("passport number is missing")
+		}
+		user := models.User{PassportNumber: *row.PassportNumber}
+		if err := r.sealPassport(&user); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"passport_number":      nil,
+			"passport_hash":        user.PassportHash,
+			"passport_hash_key_id": user.PassportHashKeyID,
+			"passport_ciphertext":  user.PassportCiphertext,
+			"passport_data_key":    user.PassportDataKey,
+			"passport_key_id":      user.PassportKeyID,
+		}, nil
+	}
+
+	sealed := secrets.Sealed{
+		Ciphertext: row.PassportCiphertext,
+		DataKey:    row.PassportDataKey,
+		KeyID:      *row.PassportKeyID,
+	}
+	updates := make(map[string]interface{})
+	if row.PassportHashKeyID == nil || *row.PassportHashKeyID != r.keyring.CurrentHashKeyID() {
+		passportNumber, err := r.keyring.Open(sealed)
+		if err != nil {
+			return nil, err
+		}
+		updates["passport_hash"] = r.keyring.Hash(passportNumber)
+		updates["passport_hash_key_id"] = r.keyring.CurrentHashKeyID()
+	}
+
+	rewrapped, err := r.keyring.Rewrap(sealed)
+	if err != nil {
+		return nil, err
+	}
+	updates["passport_data_key"] = rewrapped.DataKey
+	updates["passport_key_id"] = rewrapped.KeyID
+	return updates, nil
+}
+
+// OpenPassport decrypts the passport number of the user into PassportNumber.
+func (r *UserRepositoryImpl) OpenPassport(user *models.User) error {
+	passportNumber, err := r.keyring.Open(secrets.Sealed{
+		Ciphertext: user.PassportCiphertext,
+		DataKey:    user.PassportDataKey,
+		KeyID:      user.PassportKeyID,
+	})
+	if err != nil {
+		r.logger.Errorf("OpenPassport: failed to open passport number of user with ID %d: %v", user.ID, err)
+		return err
+	}
+
+	user.PassportNumber = passportNumber
+	return nil
+}
+
+func (r *UserRepositoryImpl) sealPassport(user *models.User) error {
+	sealed, err := r.keyring.Seal(user.PassportNumber)
+	if err != nil {
+		return err
+	}
+
+	user.PassportHash = r.keyring.Hash(user.PassportNumber)
+	user.PassportHashKeyID = r.keyring.CurrentHashKeyID()
+	user.PassportCiphertext = sealed.Ciphertext
+	user.PassportDataKey = sealed.DataKey
+	user.PassportKeyID = sealed.KeyID
+	return nil
+}
+
+// checkPassportUnique fails with gorm.ErrDuplicatedKey if a user has one of the given hashes of
+// a passport number. The unique index only catches a passport number hashed under the current
+// hash key; the others are checked while users are still being rehashed under it.
+func checkPassportUnique(tx *gorm.DB, hashes []string) error {
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	var count int64
+	if err := tx.Model(&models.User{}).Where("passport_hash IN ?", hashes).Count(&count).Error; err != nil {
 		return err
 	}
+	if count > 0 {
+		return gorm.ErrDuplicatedKey
+	}
 
-	r.logger.Infof("Delete: user with ID %d deleted from database successfully", id)
 	return nil
 }
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+)
+
+type WebhookRepository interface {
+	Create(webhook *models.Webhook) error
+	GetById(id uint) (*models.Webhook, error)
+	GetAll() ([]models.Webhook, error)
+	GetActive() ([]models.Webhook, error)
+	Update(webhook *models.Webhook) error
+	Delete(id uint) error
+	CreateDeliveries(deliveries []models.WebhookDelivery) error
+	GetDelivery(webhookID, deliveryID uint) (*models.WebhookDelivery, error)
+	GetDeliveries(webhookID uint, status string, limit int) ([]models.WebhookDelivery, error)
+	ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error)
+	SaveDeliveryAttempt(delivery *models.WebhookDelivery) error
+}
@@ -0,0 +1,188 @@
+package repositories
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepositoryImpl struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewWebhookRepositoryImpl(db *gorm.DB, logger *logrus.Logger) *WebhookRepositoryImpl {
+	return &WebhookRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *WebhookRepositoryImpl) Create(webhook *models.Webhook) error {
+	r.logger.Infof("Create: creating webhook in database for %s", webhook.URL)
+	if err := r.db.Create(webhook).Error; err != nil {
+		r.logger.Errorf("Create: failed to create webhook in database: %v", err)
+		return err
+	}
+
+	r.logger.Infof("Create: webhook created in database successfully with ID %d", webhook.ID)
+	return nil
+}
+
+func (r *WebhookRepositoryImpl) GetById(id uint) (*models.Webhook, error) {
+	var webhook models.Webhook
+	if err := r.db.First(&webhook, id).Error; err != nil {
+		r.logger.Errorf("GetById: failed to get webhook from database with ID %d: %v", id, err)
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (r *WebhookRepositoryImpl) GetAll() ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	if err := r.db.Order("id").Find(&webhooks).Error; err != nil {
+		r.logger.Errorf("GetAll: failed to fetch webhooks from database: %v", err)
+		return nil, err
+	}
+
+	r.logger.Infof("GetAll: successfully fetched %d webhooks from database", len(webhooks))
+	return webhooks, nil
+}
+
+func (r *WebhookRepositoryImpl) GetActive() ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	if err := r.db.Where("active").Order("id").Find(&webhooks).Error; err != nil {
+		r.logger.Errorf("GetActive: failed to fetch active webhooks from database: %v", err)
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (r *WebhookRepositoryImpl) Update(webhook *models.Webhook) error {
+	r.logger.Infof("Update: updating webhook in database with ID %d", webhook.ID)
+	if err := r.db.Save(webhook).Error; err != nil {
+		r.logger.Errorf("Update: failed to update webhook in database with ID %d: %v", webhook.ID, err)
+		return err
+	}
+
+	r.logger.Infof("Update: webhook with ID %d updated successfully in database", webhook.ID)
+	return nil
+}
+
+// Delete removes the webhook together with its delivery log.
+func (r *WebhookRepositoryImpl) Delete(id uint) error {
+	r.logger.Infof("Delete: deleting webhook from database with ID %d", id)
+	result := r.db.Delete(&models.Webhook{}, id)
+	if result.Error != nil {
+		r.logger.Errorf("Delete: failed to delete webhook from database with ID %d: %v", id, result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	r.logger.Infof("Delete: webhook with ID %d deleted from database successfully", id)
+	return nil
+}
+
+// CreateDeliveries stores the deliveries, skipping those of an event already delivered to
+// their webhook; replays are always stored.
+func (r *WebhookRepositoryImpl) CreateDeliveries(deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	err := r.db.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveries).Error
+	if err != nil {
+		r.logger.Errorf("CreateDeliveries: failed to create %d webhook deliveries in database: %v", len(deliveries), err)
+		return err
+	}
+
+	r.logger.Infof("CreateDeliveries: created %d webhook deliveries of event %s", len(deliveries), deliveries[0].Event)
+	return nil
+}
+
+func (r *WebhookRepositoryImpl) GetDelivery(webhookID, deliveryID uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := r.db.Where("webhook_id = ?", webhookID).First(&delivery, deliveryID).Error; err != nil {
+		r.logger.Errorf("GetDelivery: failed to get delivery with ID %d of webhook ID %d: %v", deliveryID, webhookID, err)
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// GetDeliveries returns the latest deliveries of the webhook, newest first, optionally
+// only those with the given status.
+func (r *WebhookRepositoryImpl) GetDeliveries(webhookID uint, status string, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	query := r.db.Where("webhook_id = ?", webhookID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Order("id DESC").Limit(limit).Find(&deliveries).Error; err != nil {
+		r.logger.Errorf("GetDeliveries: failed to fetch deliveries of webhook ID %d: %v", webhookID, err)
+		return nil, err
+	}
+
+	r.logger.Infof("GetDeliveries: successfully fetched %d deliveries of webhook ID %d", len(deliveries), webhookID)
+	return deliveries, nil
+}
+
+// ClaimDueDeliveries returns up to limit pending deliveries due by now with their webhooks,
+// and moves their next attempt lease into the future, so that a delivery in flight is not
+// claimed again by another instance. A delivery whose sender dies is retried after the lease.
+func (r *WebhookRepositoryImpl) ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Preload("Webhook").
+			Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]uint, len(deliveries))
+		for i := range deliveries {
+			ids[i] = deliveries[i].ID
+		}
+		return tx.Model(&models.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		r.logger.Errorf("ClaimDueDeliveries: failed to claim due webhook deliveries: %v", err)
+		return nil, err
+	}
+
+	r.logger.Infof("ClaimDueDeliveries: claimed %d webhook deliveries", len(deliveries))
+	return deliveries, nil
+}
+
+// SaveDeliveryAttempt stores the outcome of an attempt to send the delivery.
+func (r *WebhookRepositoryImpl) SaveDeliveryAttempt(delivery *models.WebhookDelivery) error {
+	if err := r.db.Omit(clause.Associations).Save(delivery).Error; err != nil {
+		r.logger.Errorf("SaveDeliveryAttempt: failed to save attempt of webhook delivery with ID %d: %v", delivery.ID, err)
+		return err
+	}
+
+	return nil
+}
+
+// deleteUserWebhookDeliveries deletes the deliveries of the events of the user, whose
+// payloads may carry their personal data, and returns the number deleted.
+func deleteUserWebhookDeliveries(tx *gorm.DB, userID uint) (int, error) {
+	result := tx.Where("event LIKE ? AND payload::jsonb -> 'data' ->> 'id' = ?", "user.%", strconv.FormatUint(uint64(userID), 10)).
+		Delete(&models.WebhookDelivery{})
+	return int(result.RowsAffected), result.Error
+}
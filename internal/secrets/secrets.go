@@ -0,0 +1,242 @@
+// Package secrets encrypts values at rest with envelope encryption: each value is sealed under
+// a data key of its own, and the data key is wrapped by a master key taken from config. Rotating
+// the master key only takes rewrapping the data keys, the values themselves are left untouched.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// keySize is the size of master and data keys, AES-256.
+const keySize = 32
+
+// ErrUnknownKey is returned when a value was sealed under a master key that is not in the keyring.
+var ErrUnknownKey = errors.New("unknown master key")
+
+// Sealed is a value encrypted under DataKey, which is in turn encrypted under the master key KeyID.
+type Sealed struct {
+	Ciphertext []byte
+	DataKey    []byte
+	KeyID      string
+}
+
+// Keyring seals values under its current master key and opens values sealed under any of its
+// master keys. It also hashes values with hash keys of its own, so that they can be looked up
+// without being decrypted.
+type Keyring struct {
+	current     string
+	keys        map[string]cipher.AEAD
+	currentHash string
+	hashIDs     []string
+	hashKeys    map[string][]byte
+}
+
+// NewKeyring parses keys and hashKeys, both comma-separated id:key pairs with base64 keys.
+// Values are sealed and hashed under the first key of each; the others are only kept to open
+// and look up the values sealed and hashed under them until those have been rewrapped and
+// rehashed.
+func NewKeyring(keys, hashKeys string) (*Keyring, error) {
+	masterIDs, masterKeys, err := parseKeys("master", keys)
+	if err != nil {
+		return nil, err
+	}
+	hashIDs, decodedHashKeys, err := parseKeys("hash", hashKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	keyring := &Keyring{
+		current:     masterIDs[0],
+		keys:        make(map[string]cipher.AEAD),
+		currentHash: hashIDs[0],
+		hashIDs:     hashIDs,
+		hashKeys:    decodedHashKeys,
+	}
+	for id, key := range masterKeys {
+		if keyring.keys[id], err = newAEAD(key); err != nil {
+			return nil, err
+		}
+	}
+
+	return keyring, nil
+}
+
+// CurrentKeyID returns the ID of the master key new values are sealed under.
+func (k *Keyring) CurrentKeyID() string {
+	return k.current
+}
+
+// CurrentHashKeyID returns the ID of the hash key new values are hashed under.
+func (k *Keyring) CurrentHashKeyID() string {
+	return k.currentHash
+}
+
+// Seal encrypts plaintext under a new data key wrapped by the current master key.
+func (k *Keyring) Seal(plaintext string) (Sealed, error) {
+	dataKey := make([]byte, keySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return Sealed{}, err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return Sealed{}, err
+	}
+	ciphertext, err := seal(aead, []byte(plaintext))
+	if err != nil {
+		return Sealed{}, err
+	}
+	wrapped, err := seal(k.keys[k.current], dataKey)
+	if err != nil {
+		return Sealed{}, err
+	}
+
+	return Sealed{Ciphertext: ciphertext, DataKey: wrapped, KeyID: k.current}, nil
+}
+
+// Open decrypts a sealed value.
+func (k *Keyring) Open(sealed Sealed) (string, error) {
+	dataKey, err := k.unwrap(sealed)
+	if err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(aead, sealed.Ciphertext)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// Rewrap wraps the data key of a sealed value by the current master key. The ciphertext is
+// kept as it is.
+func (k *Keyring) Rewrap(sealed Sealed) (Sealed, error) {
+	if sealed.KeyID == k.current {
+		return sealed, nil
+	}
+
+	dataKey, err := k.unwrap(sealed)
+	if err != nil {
+		return Sealed{}, err
+	}
+	wrapped, err := seal(k.keys[k.current], dataKey)
+	if err != nil {
+		return Sealed{}, err
+	}
+
+	return Sealed{Ciphertext: sealed.Ciphertext, DataKey: wrapped, KeyID: k.current}, nil
+}
+
+// Hash returns the hex HMAC-SHA256 of value under the current hash key. Equal values have equal
+// hashes, which lets them be looked up and kept unique while they are stored sealed.
+func (k *Keyring) Hash(value string) string {
+	return hash(k.hashKeys[k.currentHash], value)
+}
+
+// Hashes returns the hashes of value under every hash key, the current one first, which finds
+// the value whichever key it has been hashed under.
+func (k *Keyring) Hashes(value string) []string {
+	hashes := make([]string, 0, len(k.hashIDs))
+	for _, id := range k.hashIDs {
+		hashes = append(hashes, hash(k.hashKeys[id], value))
+	}
+
+	return hashes
+}
+
+func (k *Keyring) unwrap(sealed Sealed) ([]byte, error) {
+	aead, ok := k.keys[sealed.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownKey, sealed.KeyID)
+	}
+
+	return open(aead, sealed.DataKey)
+}
+
+// parseKeys parses comma-separated id:key pairs with base64 keys of the given kind, returning
+// their IDs in order and the keys by ID.
+func parseKeys(kind, pairs string) ([]string, map[string][]byte, error) {
+	if strings.TrimSpace(pairs) == "" {
+		return nil, nil, fmt.Errorf("no %s keys", kind)
+	}
+
+	var ids []string
+	keys := make(map[string][]byte)
+	for _, pair := range strings.Split(pairs, ",") {
+		id, encoded, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || id == "" {
+			return nil, nil, fmt.Errorf("invalid %s key %q: want id:key", kind, id)
+		}
+		if _, ok := keys[id]; ok {
+			return nil, nil, fmt.Errorf("duplicate %s key %q", kind, id)
+		}
+
+		key, err := decodeKey(encoded)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid %s key %q: %w", kind, id, err)
+		}
+		ids = append(ids, id)
+		keys[id] = key
+	}
+
+	return ids, keys, nil
+}
+
+func decodeKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("key is %d bytes long, want %d", len(key), keySize)
+	}
+
+	return key, nil
+}
+
+func hash(key []byte, value string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext with a random nonce, which it prepends to the ciphertext.
+func seal(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(aead cipher.AEAD, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	nonce, ciphertext := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, nil)
+}
@@ -0,0 +1,11 @@
+package services
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/export"
+)
+
+type AuditService interface {
+	GetEntries(request dto.GetAuditEntriesRequest) ([]dto.AuditEntryResponse, error)
+	ExportEntries(request dto.ExportAuditEntriesRequest, writer export.Writer) error
+}
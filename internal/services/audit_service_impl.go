@@ -0,0 +1,109 @@
+package services
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/export"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/sirupsen/logrus"
+)
+
+const defaultAuditEntriesLimit = 100
+
+type AuditServiceImpl struct {
+	auditRepo repositories.AuditRepository
+	logger    *logrus.Logger
+}
+
+func NewAuditServiceImpl(auditRepo repositories.AuditRepository, logger *logrus.Logger) *AuditServiceImpl {
+	return &AuditServiceImpl{
+		auditRepo: auditRepo,
+		logger:    logger,
+	}
+}
+
+func (s *AuditServiceImpl) GetEntries(request dto.GetAuditEntriesRequest) ([]dto.AuditEntryResponse, error) {
+	s.logger.Info("GetEntries: fetching audit entries")
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultAuditEntriesLimit
+	}
+
+	entries, err := s.auditRepo.GetEntries(newAuditFilter(request), limit)
+	if err != nil {
+		s.logger.Debugf("GetEntries: failed to fetch audit entries: %v", err)
+		return nil, err
+	}
+
+	entryResponses := make([]dto.AuditEntryResponse, len(entries))
+	for i := range entries {
+		entryResponses[i] = *newAuditEntryResponse(&entries[i])
+	}
+
+	s.logger.Infof("GetEntries: fetched %d audit entries", len(entries))
+	return entryResponses, nil
+}
+
+func (s *AuditServiceImpl) ExportEntries(request dto.ExportAuditEntriesRequest, writer export.Writer) error {
+	s.logger.Info("ExportEntries: exporting audit entries")
+	names, values, err := exportColumns(request.Columns, auditExportColumns, defaultAuditExportColumns)
+	if err != nil {
+		s.logger.Debugf("ExportEntries: invalid columns: %v", err)
+		return err
+	}
+
+	if err := writeExportHeader(writer, names); err != nil {
+		return err
+	}
+
+	var count int
+	row := make([]any, len(values))
+	err = s.auditRepo.GetEntriesInBatches(newAuditFilter(request.GetAuditEntriesRequest), exportBatchSize, func(entries []models.AuditEntry) error {
+		for i := range entries {
+			if err := writeExportRow(writer, values, &entries[i], row); err != nil {
+				return err
+			}
+		}
+		count += len(entries)
+		return nil
+	})
+	if err != nil {
+		s.logger.Debugf("ExportEntries: failed to export audit entries: %v", err)
+		return err
+	}
+
+	s.logger.Infof("ExportEntries: exported %d audit entries", count)
+	return nil
+}
+
+func newAuditFilter(request dto.GetAuditEntriesRequest) repositories.AuditFilter {
+	return repositories.AuditFilter{
+		EntityType: request.EntityType,
+		EntityID:   request.EntityID,
+		ActorID:    request.ActorID,
+		Action:     request.Action,
+		From:       request.From,
+		To:         request.To,
+		BeforeID:   request.BeforeID,
+	}
+}
+
+func newAuditEntryResponse(entry *models.AuditEntry) *dto.AuditEntryResponse {
+	response := &dto.AuditEntryResponse{
+		ID:         entry.ID,
+		ActorID:    entry.ActorID,
+		Action:     entry.Action,
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		CreatedAt:  entry.CreatedAt.Format(time.RFC3339),
+		Changes:    json.RawMessage(entry.Changes),
+	}
+	if entry.RequestID != nil {
+		response.RequestID = *entry.RequestID
+	}
+
+	return response
+}
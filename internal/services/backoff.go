@@ -0,0 +1,14 @@
+package services
+
+import "time"
+
+// backoffDelay returns the delay before retrying after attempts failed attempts: base after
+// the first, twice as long after every further one, and at most maxDelay.
+func backoffDelay(base, maxDelay time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxDelay)
+}
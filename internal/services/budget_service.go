@@ -0,0 +1,10 @@
+package services
+
+import "github.com/Dor1ma/Time-Tracker/internal/dto"
+
+type BudgetService interface {
+	SetBudget(request dto.SetBudgetRequest) (*dto.BudgetResponse, error)
+	GetBudgets() ([]dto.BudgetResponse, error)
+	GetBudgetProgress(id uint) (*dto.BudgetProgressResponse, error)
+	DeleteBudget(id uint) error
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"math"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/sirupsen/logrus"
+)
+
+type BudgetServiceImpl struct {
+	budgetRepo  repositories.BudgetRepository
+	projectRepo repositories.ProjectRepository
+	logger      *logrus.Logger
+}
+
+func NewBudgetServiceImpl(budgetRepo repositories.BudgetRepository, projectRepo repositories.ProjectRepository,
+	logger *logrus.Logger) *BudgetServiceImpl {
+	return &BudgetServiceImpl{
+		budgetRepo:  budgetRepo,
+		projectRepo: projectRepo,
+		logger:      logger,
+	}
+}
+
+func (s *BudgetServiceImpl) SetBudget(request dto.SetBudgetRequest) (*dto.BudgetResponse, error) {
+	s.logger.Infof("SetBudget: setting budget to %d minutes", request.EstimateMinutes)
+	if (request.ProjectID == nil) == (request.TaskName == nil) {
+		return nil, models.ErrInvalidBudgetScope
+	}
+
+	if request.ProjectID != nil {
+		if _, err := s.projectRepo.GetById(*request.ProjectID); err != nil {
+			s.logger.Debugf("SetBudget: failed to find project: %v", err)
+			return nil, err
+		}
+	}
+
+	budget := &models.Budget{
+		ProjectID:       request.ProjectID,
+		TaskName:        request.TaskName,
+		EstimateMinutes: request.EstimateMinutes,
+	}
+
+	if err := s.budgetRepo.Set(budget); err != nil {
+		s.logger.Debugf("SetBudget: failed to set budget in database: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("SetBudget: budget set with ID: %d", budget.ID)
+	return newBudgetResponse(budget), nil
+}
+
+func (s *BudgetServiceImpl) GetBudgets() ([]dto.BudgetResponse, error) {
+	s.logger.Info("GetBudgets: fetching budgets")
+	budgets, err := s.budgetRepo.GetAll()
+	if err != nil {
+		s.logger.Debugf("GetBudgets: failed to fetch budgets: %v", err)
+		return nil, err
+	}
+
+	budgetResponses := make([]dto.BudgetResponse, len(budgets))
+	for i := range budgets {
+		budgetResponses[i] = *newBudgetResponse(&budgets[i])
+	}
+
+	return budgetResponses, nil
+}
+
+func (s *BudgetServiceImpl) GetBudgetProgress(id uint) (*dto.BudgetProgressResponse, error) {
+	s.logger.Infof("GetBudgetProgress: getting progress of budget with ID: %d", id)
+	budget, err := s.budgetRepo.GetById(id)
+	if err != nil {
+		s.logger.Debugf("GetBudgetProgress: failed to get budget: %v", err)
+		return nil, err
+	}
+
+	consumed, err := s.budgetRepo.GetConsumedMinutes(budget)
+	if err != nil {
+		s.logger.Debugf("GetBudgetProgress: failed to sum consumed minutes: %v", err)
+		return nil, err
+	}
+
+	response := &dto.BudgetProgressResponse{
+		BudgetResponse:   *newBudgetResponse(budget),
+		ConsumedMinutes:  consumed,
+		RemainingMinutes: max(budget.EstimateMinutes-consumed, 0),
+		Percentage:       math.Round(float64(consumed)*1000/float64(budget.EstimateMinutes)) / 10,
+	}
+
+	s.logger.Infof("GetBudgetProgress: budget with ID %d is %.1f%% consumed", id, response.Percentage)
+	return response, nil
+}
+
+func (s *BudgetServiceImpl) DeleteBudget(id uint) error {
+	s.logger.Infof("DeleteBudget: deleting budget with ID: %d", id)
+	if err := s.budgetRepo.Delete(id); err != nil {
+		s.logger.Debugf("DeleteBudget: failed to delete budget: %v", err)
+		return err
+	}
+
+	s.logger.Infof("DeleteBudget: budget deleted with ID: %d", id)
+	return nil
+}
+
+func newBudgetResponse(budget *models.Budget) *dto.BudgetResponse {
+	return &dto.BudgetResponse{
+		ID:              budget.ID,
+		ProjectID:       budget.ProjectID,
+		TaskName:        budget.TaskName,
+		EstimateMinutes: budget.EstimateMinutes,
+	}
+}
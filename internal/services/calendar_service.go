@@ -0,0 +1,12 @@
+package services
+
+import (
+	"io"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+)
+
+type CalendarService interface {
+	RegenerateToken(userID uint) (*dto.CalendarTokenResponse, error)
+	WriteUserCalendar(userID uint, request dto.GetUserCalendarRequest, w io.Writer) error
+}
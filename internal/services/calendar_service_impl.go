@@ -0,0 +1,186 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/calendar"
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	calendarTokenBytes  = 32
+	calendarDefaultDays = 90
+	calendarBatchSize   = 500
+)
+
+type CalendarServiceImpl struct {
+	taskRepo repositories.TaskRepository
+	userRepo repositories.UserRepository
+	logger   *logrus.Logger
+}
+
+func NewCalendarServiceImpl(taskRepo repositories.TaskRepository, userRepo repositories.UserRepository,
+	logger *logrus.Logger) *CalendarServiceImpl {
+	return &CalendarServiceImpl{
+		taskRepo: taskRepo,
+		userRepo: userRepo,
+		logger:   logger,
+	}
+}
+
+// RegenerateToken generates a new token for the calendar feed of the user. The previous
+// token stops working, which revokes the subscriptions made with it.
+func (s *CalendarServiceImpl) RegenerateToken(userID uint) (*dto.CalendarTokenResponse, error) {
+	s.logger.Infof("RegenerateToken: generating calendar token for user ID: %d", userID)
+	secret := make([]byte, calendarTokenBytes)
+	if _, err := rand.Read(secret); err != nil {
+		s.logger.Debugf("RegenerateToken: failed to generate token: %v", err)
+		return nil, err
+	}
+
+	token := hex.EncodeToString(secret)
+	if err := s.userRepo.SetCalendarTokenHash(userID, hashCalendarToken(token)); err != nil {
+		s.logger.Debugf("RegenerateToken: failed to store token: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("RegenerateToken: calendar token generated for user ID: %d", userID)
+	return &dto.CalendarTokenResponse{
+		Token:   token,
+		FeedURL: fmt.Sprintf("/users/%d/calendar.ics?token=%s", userID, url.QueryEscape(token)),
+	}, nil
+}
+
+// WriteUserCalendar writes the tasks of the user within the requested window as events to w,
+// once request.Token has been checked. Stopped tasks span their start and end, running and
+// paused ones are open until now. Planned tasks are left out, as no time has been tracked yet.
+func (s *CalendarServiceImpl) WriteUserCalendar(userID uint, request dto.GetUserCalendarRequest, w io.Writer) error {
+	s.logger.Infof("WriteUserCalendar: writing calendar for user ID: %d, start date: %s, end date: %s",
+		userID, request.StartDate, request.EndDate)
+	user, err := s.userRepo.GetById(userID)
+	if err != nil {
+		s.logger.Debugf("WriteUserCalendar: failed to get user: %v", err)
+		return err
+	}
+	if !validCalendarToken(user, request.Token) {
+		s.logger.Debugf("WriteUserCalendar: invalid calendar token for user ID: %d", userID)
+		return models.ErrInvalidCalendarToken
+	}
+
+	now := time.Now()
+	loc := userLocation(user)
+	filter, err := newCalendarFilter(userID, request, now.In(loc))
+	if err != nil {
+		s.logger.Debugf("WriteUserCalendar: invalid request: %v", err)
+		return err
+	}
+
+	writer := calendar.NewWriter(w, fmt.Sprintf("Tracked time of %s %s", user.Name, user.Surname), now)
+	var count int
+	err = s.taskRepo.GetUserTasksInBatches(filter, calendarBatchSize, func(tasks []models.Task) error {
+		for i := range tasks {
+			if tasks[i].Status == models.TaskStatusPlanned {
+				continue
+			}
+			if err := writer.WriteEvent(newCalendarEvent(&tasks[i], now)); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		s.logger.Debugf("WriteUserCalendar: failed to write calendar: %v", err)
+		return err
+	}
+
+	s.logger.Infof("WriteUserCalendar: wrote %d events for user ID: %d", count, userID)
+	return nil
+}
+
+func hashCalendarToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func validCalendarToken(user *models.User, token string) bool {
+	if user.CalendarTokenHash == nil {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(*user.CalendarTokenHash), []byte(hashCalendarToken(token))) == 1
+}
+
+// newCalendarFilter selects the tasks of the requested days, by default the last
+// calendarDefaultDays up to and including today.
+func newCalendarFilter(userID uint, request dto.GetUserCalendarRequest, today time.Time) (repositories.TaskFilter, error) {
+	end := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location())
+	if request.EndDate != "" {
+		var err error
+		if end, err = parseDateIn(request.EndDate, today.Location()); err != nil {
+			return repositories.TaskFilter{}, err
+		}
+	}
+
+	start := end.AddDate(0, 0, -calendarDefaultDays)
+	if request.StartDate != "" {
+		var err error
+		if start, err = parseDateIn(request.StartDate, today.Location()); err != nil {
+			return repositories.TaskFilter{}, err
+		}
+	}
+	if end.Before(start) {
+		return repositories.TaskFilter{}, models.ErrInvalidPeriod
+	}
+
+	return repositories.TaskFilter{
+		UserID:       userID,
+		StartDate:    start,
+		EndDate:      end.AddDate(0, 0, 1),
+		ProjectID:    request.ProjectID,
+		WithSegments: true,
+	}, nil
+}
+
+// newCalendarEvent turns a task into an event; the description gives the time tracked in
+// its segments, which leaves out the pauses.
+func newCalendarEvent(task *models.Task, now time.Time) calendar.Event {
+	var tracked time.Duration
+	for _, segment := range task.Segments {
+		end := now
+		if segment.EndTime != nil {
+			end = *segment.EndTime
+		}
+		tracked += end.Sub(segment.StartTime)
+	}
+	if len(task.Segments) == 0 {
+		tracked = time.Duration(task.Minutes) * time.Minute
+	}
+
+	event := calendar.Event{
+		UID:         fmt.Sprintf("task-%d@time-tracker", task.ID),
+		Summary:     task.TaskName,
+		Description: "Duration: " + formatDuration(int64(tracked.Seconds())),
+		Start:       task.StartTime,
+		End:         task.EndTime,
+	}
+	if task.Status != models.TaskStatusStopped {
+		event.End = now
+		event.Description = fmt.Sprintf("In progress (%s), %s", task.Status, event.Description)
+	}
+
+	return event
+}
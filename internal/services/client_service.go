@@ -0,0 +1,11 @@
+package services
+
+import "github.com/Dor1ma/Time-Tracker/internal/dto"
+
+type ClientService interface {
+	CreateClient(request dto.CreateClientRequest) (*dto.ClientResponse, error)
+	GetClientById(id uint) (*dto.ClientResponse, error)
+	GetAllClients() ([]dto.ClientResponse, error)
+	UpdateClient(id uint, request dto.UpdateClientRequest) (*dto.ClientResponse, error)
+	DeleteClient(id uint) error
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/sirupsen/logrus"
+)
+
+type ClientServiceImpl struct {
+	clientRepo repositories.ClientRepository
+	logger     *logrus.Logger
+}
+
+func NewClientServiceImpl(clientRepo repositories.ClientRepository, logger *logrus.Logger) *ClientServiceImpl {
+	return &ClientServiceImpl{
+		clientRepo: clientRepo,
+		logger:     logger,
+	}
+}
+
+func (s *ClientServiceImpl) CreateClient(request dto.CreateClientRequest) (*dto.ClientResponse, error) {
+	s.logger.Infof("CreateClient: creating client with name: %s", request.Name)
+	client := &models.Client{Name: request.Name}
+	if err := s.clientRepo.Create(client); err != nil {
+		s.logger.Debugf("CreateClient: failed to create client in database: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("CreateClient: client created with ID: %d", client.ID)
+	return &dto.ClientResponse{
+		ID:   client.ID,
+		Name: client.Name,
+	}, nil
+}
+
+func (s *ClientServiceImpl) GetClientById(id uint) (*dto.ClientResponse, error) {
+	s.logger.Infof("GetClientById: getting client with id: %d", id)
+	client, err := s.clientRepo.GetById(id)
+	if err != nil {
+		s.logger.Debugf("GetClientById: failed to get client in database: %v", err)
+		return nil, err
+	}
+
+	return &dto.ClientResponse{
+		ID:   client.ID,
+		Name: client.Name,
+	}, nil
+}
+
+func (s *ClientServiceImpl) GetAllClients() ([]dto.ClientResponse, error) {
+	s.logger.Info("GetAllClients: fetching all clients")
+	clients, err := s.clientRepo.GetAll()
+	if err != nil {
+		s.logger.Debugf("GetAllClients: failed to fetch clients: %v", err)
+		return nil, err
+	}
+
+	clientResponses := make([]dto.ClientResponse, len(clients))
+	for i, client := range clients {
+		clientResponses[i] = dto.ClientResponse{
+			ID:   client.ID,
+			Name: client.Name,
+		}
+	}
+
+	return clientResponses, nil
+}
+
+func (s *ClientServiceImpl) UpdateClient(id uint, request dto.UpdateClientRequest) (*dto.ClientResponse, error) {
+	s.logger.Infof("UpdateClient: updating client with ID: %d", id)
+	client, err := s.clientRepo.GetById(id)
+	if err != nil {
+		s.logger.Errorf("UpdateClient: failed to update client: %v", err)
+		return nil, err
+	}
+
+	client.Name = request.Name
+	if err := s.clientRepo.Update(client); err != nil {
+		s.logger.Errorf("UpdateClient: failed to update client: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("UpdateClient: client updated with ID: %d", client.ID)
+	return &dto.ClientResponse{
+		ID:   client.ID,
+		Name: client.Name,
+	}, nil
+}
+
+func (s *ClientServiceImpl) DeleteClient(id uint) error {
+	s.logger.Infof("DeleteClient: deleting client with ID: %d", id)
+	if err := s.clientRepo.Delete(id); err != nil {
+		s.logger.Debugf("DeleteClient: failed to delete client: %v", err)
+		return err
+	}
+
+	s.logger.Infof("DeleteClient: client deleted with ID: %d", id)
+	return nil
+}
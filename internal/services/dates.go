@@ -0,0 +1,52 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+)
+
+const dateLayout = "2006-01-02"
+
+func parseDate(value string) (time.Time, error) {
+	return parseDateIn(value, time.UTC)
+}
+
+// parseDateIn parses a date as its midnight in loc. The next day is date.AddDate(0, 0, 1),
+// which stays at midnight across daylight saving changes, when a day lasts 23 or 25 hours.
+func parseDateIn(value string, loc *time.Location) (time.Time, error) {
+	date, err := time.ParseInLocation(dateLayout, value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %v", models.ErrInvalidDateFormat, err)
+	}
+
+	return date, nil
+}
+
+// loadTimeZone returns the IANA time zone called name, with the empty name meaning UTC.
+// The zone of the server, "Local", is not accepted.
+func loadTimeZone(name string) (*time.Location, error) {
+	loc, err := time.LoadLocation(name)
+	if err != nil || loc == time.Local {
+		return nil, fmt.Errorf("%w: %s", models.ErrInvalidTimezone, name)
+	}
+
+	return loc, nil
+}
+
+// userLocation returns the time zone of the user, falling back to UTC for a zone
+// that cannot be loaded, so that the user's tasks can still be shown.
+func userLocation(user *models.User) *time.Location {
+	loc, err := loadTimeZone(user.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
+}
+
+// formatDuration renders seconds as H:MM:SS; hours are not wrapped at a day.
+func formatDuration(seconds int64) string {
+	return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds%3600/60, seconds%60)
+}
@@ -0,0 +1,18 @@
+package services
+
+import "github.com/Dor1ma/Time-Tracker/internal/dto"
+
+// TaskConflictError is returned when an operation clashes with other tasks of the user.
+// Tasks holds the clashing tasks so that clients can resolve the conflict.
+type TaskConflictError struct {
+	Err   error
+	Tasks []dto.TaskResponse
+}
+
+func (e *TaskConflictError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TaskConflictError) Unwrap() error {
+	return e.Err
+}
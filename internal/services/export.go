@@ -0,0 +1,139 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/export"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+)
+
+const exportBatchSize = 500
+
+// exportedTask is a task together with its duration rounded by the policy that applies to it.
+type exportedTask struct {
+	*models.Task
+	RoundedMinutes int
+}
+
+var taskExportColumns = map[string]func(task *exportedTask) any{
+	"id":        func(task *exportedTask) any { return task.ID },
+	"user_id":   func(task *exportedTask) any { return task.UserID },
+	"task_name": func(task *exportedTask) any { return task.TaskName },
+	"project_id": func(task *exportedTask) any {
+		if task.ProjectID == nil {
+			return nil
+		}
+		return *task.ProjectID
+	},
+	"tags": func(task *exportedTask) any {
+		names := make([]string, len(task.Tags))
+		for i, tag := range task.Tags {
+			names[i] = tag.Name
+		}
+		return strings.Join(names, ", ")
+	},
+	"billable":         func(task *exportedTask) any { return task.Billable },
+	"status":           func(task *exportedTask) any { return task.Status },
+	"start_time":       func(task *exportedTask) any { return task.StartTime },
+	"end_time":         func(task *exportedTask) any { return task.EndTime },
+	"minutes":          func(task *exportedTask) any { return task.Minutes },
+	"hours":            func(task *exportedTask) any { return float64(task.Minutes) / 60 },
+	"duration":         func(task *exportedTask) any { return formatDuration(int64(task.Minutes) * 60) },
+	"rounded_minutes":  func(task *exportedTask) any { return task.RoundedMinutes },
+	"rounded_hours":    func(task *exportedTask) any { return float64(task.RoundedMinutes) / 60 },
+	"rounded_duration": func(task *exportedTask) any { return formatDuration(int64(task.RoundedMinutes) * 60) },
+}
+
+var defaultTaskExportColumns = []string{
+	"id", "task_name", "project_id", "tags", "billable", "status", "start_time", "end_time", "hours", "duration", "rounded_hours",
+}
+
+var summaryExportColumns = map[string]func(group *dto.SummaryGroupResponse) any{
+	"key": func(group *dto.SummaryGroupResponse) any { return group.Key },
+	"id": func(group *dto.SummaryGroupResponse) any {
+		if group.ID == nil {
+			return nil
+		}
+		return *group.ID
+	},
+	"task_count":       func(group *dto.SummaryGroupResponse) any { return group.TaskCount },
+	"seconds":          func(group *dto.SummaryGroupResponse) any { return group.Seconds },
+	"hours":            func(group *dto.SummaryGroupResponse) any { return float64(group.Seconds) / 3600 },
+	"duration":         func(group *dto.SummaryGroupResponse) any { return group.Duration },
+	"rounded_seconds":  func(group *dto.SummaryGroupResponse) any { return group.RoundedSeconds },
+	"rounded_hours":    func(group *dto.SummaryGroupResponse) any { return float64(group.RoundedSeconds) / 3600 },
+	"rounded_duration": func(group *dto.SummaryGroupResponse) any { return group.RoundedDuration },
+}
+
+var defaultSummaryExportColumns = []string{"key", "task_count", "hours", "duration", "rounded_hours"}
+
+var auditExportColumns = map[string]func(entry *models.AuditEntry) any{
+	"id":         func(entry *models.AuditEntry) any { return entry.ID },
+	"created_at": func(entry *models.AuditEntry) any { return entry.CreatedAt },
+	"actor_id": func(entry *models.AuditEntry) any {
+		if entry.ActorID == nil {
+			return nil
+		}
+		return *entry.ActorID
+	},
+	"action":      func(entry *models.AuditEntry) any { return entry.Action },
+	"entity_type": func(entry *models.AuditEntry) any { return entry.EntityType },
+	"entity_id":   func(entry *models.AuditEntry) any { return entry.EntityID },
+	"request_id": func(entry *models.AuditEntry) any {
+		if entry.RequestID == nil {
+			return nil
+		}
+		return *entry.RequestID
+	},
+	"changes": func(entry *models.AuditEntry) any { return entry.Changes },
+}
+
+var defaultAuditExportColumns = []string{"id", "created_at", "actor_id", "action", "entity_type", "entity_id", "request_id", "changes"}
+
+// exportColumns resolves the requested column names, which may be comma separated,
+// to their value functions. Without a request the defaults are used.
+func exportColumns[T any](requested []string, available map[string]func(T) any, defaults []string) ([]string, []func(T) any, error) {
+	var names []string
+	for _, value := range requested {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		names = defaults
+	}
+
+	values := make([]func(T) any, len(names))
+	for i, name := range names {
+		value, ok := available[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %s", models.ErrUnknownExportColumn, name)
+		}
+		values[i] = value
+	}
+
+	return names, values, nil
+}
+
+// writeExportRow writes the row of item made of the given column values.
+func writeExportRow[T any](writer export.Writer, values []func(T) any, item T, row []any) error {
+	for i, value := range values {
+		row[i] = value(item)
+	}
+
+	return writer.WriteRow(row)
+}
+
+// writeExportHeader writes the column names as the first row.
+func writeExportHeader(writer export.Writer, names []string) error {
+	header := make([]any, len(names))
+	for i, name := range names {
+		header[i] = name
+	}
+
+	return writer.WriteRow(header)
+}
@@ -0,0 +1,12 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+)
+
+type FocusSessionService interface {
+	AdvanceFocusSessions(ctx context.Context, now time.Time) ([]dto.TaskResponse, error)
+}
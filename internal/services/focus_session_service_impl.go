@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/sirupsen/logrus"
+)
+
+type FocusSessionServiceImpl struct {
+	taskRepo repositories.TaskRepository
+	userRepo repositories.UserRepository
+	logger   *logrus.Logger
+}
+
+func NewFocusSessionServiceImpl(taskRepo repositories.TaskRepository, userRepo repositories.UserRepository,
+	logger *logrus.Logger) *FocusSessionServiceImpl {
+	return &FocusSessionServiceImpl{
+		taskRepo: taskRepo,
+		userRepo: userRepo,
+		logger:   logger,
+	}
+}
+
+// AdvanceFocusSessions ends the pomodoro phases that are over as of now: finished work intervals
+// pause their task for the break, or stop it after the last cycle, and finished breaks resume it.
+// It returns the tasks whose sessions moved on.
+func (s *FocusSessionServiceImpl) AdvanceFocusSessions(ctx context.Context, now time.Time) ([]dto.TaskResponse, error) {
+	s.logger.Infof("AdvanceFocusSessions: looking for focus sessions due at %s", now.Format(time.RFC3339))
+	sessions, err := s.taskRepo.GetDueFocusSessions(now)
+	if err != nil {
+		s.logger.Debugf("AdvanceFocusSessions: failed to fetch focus sessions: %v", err)
+		return nil, err
+	}
+
+	locations := make(map[uint]*time.Location)
+	advanced := make([]dto.TaskResponse, 0, len(sessions))
+	for _, session := range sessions {
+		task, err := s.taskRepo.AdvanceFocusSession(ctx, session.TaskID, now)
+		if err != nil {
+			s.logger.Debugf("AdvanceFocusSessions: failed to advance focus session of task with ID %d: %v", session.TaskID, err)
+			continue
+		}
+
+		loc, ok := locations[task.UserID]
+		if !ok {
+			loc = s.location(task.UserID)
+			locations[task.UserID] = loc
+		}
+
+		advanced = append(advanced, *newTaskResponse(task, loc))
+	}
+
+	s.logger.Infof("AdvanceFocusSessions: advanced %d of %d focus sessions", len(advanced), len(sessions))
+	return advanced, nil
+}
+
+// location returns the time zone of the user, UTC if the user cannot be fetched.
+func (s *FocusSessionServiceImpl) location(userID uint) *time.Location {
+	user, err := s.userRepo.GetById(userID)
+	if err != nil {
+		s.logger.Debugf("location: failed to fetch user with ID %d: %v", userID, err)
+		return time.UTC
+	}
+
+	return userLocation(user)
+}
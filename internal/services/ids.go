@@ -0,0 +1,44 @@
+package services
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+)
+
+// uniqueIDs drops duplicate IDs, keeping the first occurrence of each.
+func uniqueIDs(ids []uint) []uint {
+	var unique []uint
+	seen := make(map[uint]bool)
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+
+	return unique
+}
+
+// parseIDList splits comma separated values into positive IDs.
+func parseIDList(values []string) ([]uint, error) {
+	var ids []uint
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+
+			id, err := strconv.ParseUint(part, 10, 0)
+			if err != nil || id == 0 {
+				return nil, models.ErrInvalidIDList
+			}
+			ids = append(ids, uint(id))
+		}
+	}
+
+	return uniqueIDs(ids), nil
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+	"io"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+)
+
+// Statuses of the rows of an import.
+const (
+	ImportRowCreated     = "created"
+	ImportRowWouldCreate = "would_create"
+	ImportRowDuplicate   = "duplicate"
+	ImportRowInvalid     = "invalid"
+)
+
+type ImportService interface {
+	ImportTasks(ctx context.Context, request dto.ImportTasksRequest, file io.Reader) (*dto.ImportResultResponse, error)
+}
@@ -0,0 +1,402 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/importer"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/sirupsen/logrus"
+)
+
+const importBatchSize = 500
+
+var (
+	errImportUserMissing   = errors.New("user is missing")
+	errImportUserNotFound  = errors.New("no user matches")
+	errImportUserAmbiguous = errors.New("several users match")
+	errImportNameMissing   = errors.New("description is missing")
+)
+
+type ImportServiceImpl struct {
+	taskRepo    repositories.TaskRepository
+	userRepo    repositories.UserRepository
+	projectRepo repositories.ProjectRepository
+	clientRepo  repositories.ClientRepository
+	logger      *logrus.Logger
+}
+
+func NewImportServiceImpl(taskRepo repositories.TaskRepository, userRepo repositories.UserRepository,
+	projectRepo repositories.ProjectRepository, clientRepo repositories.ClientRepository, logger *logrus.Logger) *ImportServiceImpl {
+	return &ImportServiceImpl{
+		taskRepo:    taskRepo,
+		userRepo:    userRepo,
+		projectRepo: projectRepo,
+		clientRepo:  clientRepo,
+		logger:      logger,
+	}
+}
+
+// ImportTasks creates a stopped task for every valid line of a Toggl or Clockify export.
+// Lines imported before are skipped as duplicates, so a file can be uploaded again safely.
+// A dry run reports what would be created without writing anything; overlaps with
+// existing tasks are only detected when the tasks are actually created.
+func (s *ImportServiceImpl) ImportTasks(ctx context.Context, request dto.ImportTasksRequest, file io.Reader) (*dto.ImportResultResponse, error) {
+	s.logger.Infof("ImportTasks: importing tasks, source: %s, dry run: %t", request.Source, request.DryRun)
+	location, err := loadTimeZone(request.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := importer.NewReader(file, request.Source, request.DateFormat, location)
+	if err != nil {
+		s.logger.Debugf("ImportTasks: failed to read file: %v", err)
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidImportFile, err)
+	}
+
+	users, err := s.userRepo.GetAll()
+	if err != nil {
+		s.logger.Debugf("ImportTasks: failed to fetch users: %v", err)
+		return nil, err
+	}
+
+	projects, err := newProjectResolver(s.projectRepo, s.clientRepo, request.DryRun)
+	if err != nil {
+		s.logger.Debugf("ImportTasks: failed to fetch projects: %v", err)
+		return nil, err
+	}
+
+	run := &importRun{
+		service:  s,
+		source:   reader.Source(),
+		dryRun:   request.DryRun,
+		users:    newUserMatcher(users),
+		projects: projects,
+		seen:     make(map[string]bool),
+		result: &dto.ImportResultResponse{
+			Source: reader.Source(),
+			DryRun: request.DryRun,
+			Rows:   []dto.ImportRowResponse{},
+		},
+	}
+
+	batch := make([]importer.Entry, 0, importBatchSize)
+	for {
+		entry, err := reader.Read()
+		if err != nil && !errors.Is(err, io.EOF) {
+			s.logger.Debugf("ImportTasks: failed to read file: %v", err)
+			return nil, fmt.Errorf("%w: %v", models.ErrInvalidImportFile, err)
+		}
+		if err == nil {
+			batch = append(batch, entry)
+		}
+
+		if len(batch) == importBatchSize || errors.Is(err, io.EOF) && len(batch) > 0 {
+			if err := run.importBatch(ctx, batch); err != nil {
+				return nil, err
+			}
+			batch = batch[:0]
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+	}
+	run.result.ProjectsCreated = projects.created
+
+	s.logger.Infof("ImportTasks: imported %d of %d rows, %d duplicates, %d invalid",
+		run.result.Created, run.result.TotalRows, run.result.Duplicates, run.result.Invalid)
+	return run.result, nil
+}
+
+// importRun holds the state of one import across its batches.
+type importRun struct {
+	service  *ImportServiceImpl
+	source   string
+	dryRun   bool
+	users    *userMatcher
+	projects *projectResolver
+	seen     map[string]bool
+	result   *dto.ImportResultResponse
+}
+
+type importCandidate struct {
+	entry  importer.Entry
+	userID uint
+	key    string
+}
+
+func (r *importRun) importBatch(ctx context.Context, entries []importer.Entry) error {
+	candidates := make([]*importCandidate, len(entries))
+	var keys []string
+	for i, entry := range entries {
+		if entry.Err != nil {
+			continue
+		}
+		userID, err := r.users.match(entry)
+		if err != nil {
+			entries[i].Err = err
+			continue
+		}
+		if entry.Description == "" {
+			entries[i].Err = errImportNameMissing
+			continue
+		}
+
+		candidates[i] = &importCandidate{entry: entry, userID: userID, key: importKey(r.source, userID, entry)}
+		keys = append(keys, candidates[i].key)
+	}
+
+	imported, err := r.service.taskRepo.GetImportedKeys(keys)
+	if err != nil {
+		return err
+	}
+	for _, key := range imported {
+		r.seen[key] = true
+	}
+
+	for i, entry := range entries {
+		r.result.TotalRows++
+		row := dto.ImportRowResponse{Line: entry.Line, TaskName: entry.Description}
+		if entry.Err != nil {
+			r.addRow(row, ImportRowInvalid, entry.Err)
+			continue
+		}
+
+		candidate := candidates[i]
+		row.UserID = &candidate.userID
+		row.StartTime = entry.Start.Format(time.RFC3339)
+		row.EndTime = entry.End.Format(time.RFC3339)
+		if r.seen[candidate.key] {
+			r.addRow(row, ImportRowDuplicate, nil)
+			continue
+		}
+
+		taskID, err := r.createTask(ctx, candidate)
+		if err != nil {
+			r.addRow(row, ImportRowInvalid, err)
+			continue
+		}
+		r.seen[candidate.key] = true
+		if r.dryRun {
+			r.addRow(row, ImportRowWouldCreate, nil)
+			continue
+		}
+		row.TaskID = &taskID
+		r.addRow(row, ImportRowCreated, nil)
+	}
+
+	return nil
+}
+
+func (r *importRun) createTask(ctx context.Context, candidate *importCandidate) (uint, error) {
+	entry := candidate.entry
+	projectID, err := r.projects.resolve(entry.Client, entry.Project)
+	if err != nil || r.dryRun {
+		return 0, err
+	}
+
+	key := candidate.key
+	task := &models.Task{
+		UserID:    candidate.userID,
+		TaskName:  entry.Description,
+		ProjectID: projectID,
+		Tags:      newTags(entry.Tags),
+		Billable:  entry.Billable == nil || *entry.Billable,
+		ImportKey: &key,
+		StartTime: entry.Start,
+		EndTime:   entry.End,
+	}
+	if err := r.service.taskRepo.CreateTask(ctx, task); err != nil {
+		return 0, err
+	}
+
+	return task.ID, nil
+}
+
+func (r *importRun) addRow(row dto.ImportRowResponse, status string, err error) {
+	row.Status = status
+	if err != nil {
+		row.Error = err.Error()
+	}
+
+	switch status {
+	case ImportRowCreated, ImportRowWouldCreate:
+		r.result.Created++
+	case ImportRowDuplicate:
+		r.result.Duplicates++
+	case ImportRowInvalid:
+		r.result.Invalid++
+	}
+	r.result.Rows = append(r.result.Rows, row)
+}
+
+// importKey identifies an entry independently of the file it came from.
+func importKey(source string, userID uint, entry importer.Entry) string {
+	hash := sha256.Sum256([]byte(strings.Join([]string{
+		source,
+		fmt.Sprint(userID),
+		entry.Start.UTC().Format(time.RFC3339),
+		entry.End.UTC().Format(time.RFC3339),
+		entry.Client,
+		entry.Project,
+		entry.Description,
+	}, "\x00")))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// userMatcher finds users by ID or by full name in either "Name Surname" or
+// "Surname Name" order, with or without the patronymic.
+type userMatcher struct {
+	ids   map[uint]bool
+	names map[string][]uint
+}
+
+func newUserMatcher(users []models.User) *userMatcher {
+	matcher := &userMatcher{
+		ids:   make(map[uint]bool, len(users)),
+		names: make(map[string][]uint),
+	}
+	for _, user := range users {
+		matcher.ids[user.ID] = true
+		names := []string{
+			user.Name + " " + user.Surname,
+			user.Surname + " " + user.Name,
+			user.Surname + " " + user.Name + " " + user.Patronymic,
+			user.Name + " " + user.Patronymic + " " + user.Surname,
+		}
+		added := make(map[string]bool)
+		for _, name := range names {
+			name = normalizeUserName(name)
+			if !added[name] {
+				added[name] = true
+				matcher.names[name] = append(matcher.names[name], user.ID)
+			}
+		}
+	}
+
+	return matcher
+}
+
+func (m *userMatcher) match(entry importer.Entry) (uint, error) {
+	if entry.UserID != nil {
+		if !m.ids[*entry.UserID] {
+			return 0, fmt.Errorf("%w ID %d", errImportUserNotFound, *entry.UserID)
+		}
+		return *entry.UserID, nil
+	}
+	if entry.UserName == "" {
+		return 0, errImportUserMissing
+	}
+
+	candidates := m.names[normalizeUserName(entry.UserName)]
+	switch len(candidates) {
+	case 0:
+		return 0, fmt.Errorf("%w %q", errImportUserNotFound, entry.UserName)
+	case 1:
+		return candidates[0], nil
+	default:
+		return 0, fmt.Errorf("%w %q", errImportUserAmbiguous, entry.UserName)
+	}
+}
+
+func normalizeUserName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// projectResolver finds projects by client and project name and creates the ones
+// that do not exist yet. In a dry run it only records what it would create.
+type projectResolver struct {
+	projectRepo repositories.ProjectRepository
+	clientRepo  repositories.ClientRepository
+	dryRun      bool
+	clients     map[string]*uint
+	projects    map[[2]string]*uint
+	created     []string
+}
+
+func newProjectResolver(projectRepo repositories.ProjectRepository, clientRepo repositories.ClientRepository, dryRun bool) (*projectResolver, error) {
+	resolver := &projectResolver{
+		projectRepo: projectRepo,
+		clientRepo:  clientRepo,
+		dryRun:      dryRun,
+		clients:     make(map[string]*uint),
+		projects:    make(map[[2]string]*uint),
+	}
+
+	clients, err := clientRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for i := range clients {
+		resolver.clients[strings.ToLower(clients[i].Name)] = &clients[i].ID
+	}
+
+	projects, err := projectRepo.GetAll(nil, true)
+	if err != nil {
+		return nil, err
+	}
+	for i := range projects {
+		clientName := ""
+		if projects[i].Client != nil {
+			clientName = projects[i].Client.Name
+		}
+		resolver.projects[projectKey(clientName, projects[i].Name)] = &projects[i].ID
+	}
+
+	return resolver, nil
+}
+
+func (r *projectResolver) resolve(clientName, projectName string) (*uint, error) {
+	if projectName == "" {
+		return nil, nil
+	}
+
+	key := projectKey(clientName, projectName)
+	if projectID, ok := r.projects[key]; ok {
+		return projectID, nil
+	}
+
+	var clientID *uint
+	if clientName != "" {
+		var ok bool
+		if clientID, ok = r.clients[strings.ToLower(clientName)]; !ok && !r.dryRun {
+			client := &models.Client{Name: clientName}
+			if err := r.clientRepo.Create(client); err != nil {
+				return nil, err
+			}
+			clientID = &client.ID
+		}
+		r.clients[strings.ToLower(clientName)] = clientID
+	}
+
+	if clientName != "" {
+		r.created = append(r.created, clientName+" / "+projectName)
+	} else {
+		r.created = append(r.created, projectName)
+	}
+	if r.dryRun {
+		r.projects[key] = nil
+		return nil, nil
+	}
+
+	project := &models.Project{Name: projectName, ClientID: clientID}
+	if err := r.projectRepo.Create(project); err != nil {
+		return nil, err
+	}
+	r.projects[key] = &project.ID
+
+	return &project.ID, nil
+}
+
+func projectKey(clientName, projectName string) [2]string {
+	return [2]string{strings.ToLower(clientName), strings.ToLower(projectName)}
+}
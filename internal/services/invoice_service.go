@@ -0,0 +1,9 @@
+package services
+
+import "github.com/Dor1ma/Time-Tracker/internal/dto"
+
+type InvoiceService interface {
+	CreateInvoice(request dto.CreateInvoiceRequest) (*dto.InvoiceResponse, error)
+	GetInvoice(id uint) (*dto.InvoiceResponse, error)
+	GetInvoices(clientID *uint) ([]dto.InvoiceResponse, error)
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/sirupsen/logrus"
+)
+
+type InvoiceServiceImpl struct {
+	invoiceRepo  repositories.InvoiceRepository
+	rateRepo     repositories.RateRepository
+	clientRepo   repositories.ClientRepository
+	roundingRepo repositories.RoundingPolicyRepository
+	logger       *logrus.Logger
+}
+
+func NewInvoiceServiceImpl(invoiceRepo repositories.InvoiceRepository, rateRepo repositories.RateRepository,
+	clientRepo repositories.ClientRepository, roundingRepo repositories.RoundingPolicyRepository, logger *logrus.Logger) *InvoiceServiceImpl {
+	return &InvoiceServiceImpl{
+		invoiceRepo:  invoiceRepo,
+		rateRepo:     rateRepo,
+		clientRepo:   clientRepo,
+		roundingRepo: roundingRepo,
+		logger:       logger,
+	}
+}
+
+func (s *InvoiceServiceImpl) CreateInvoice(request dto.CreateInvoiceRequest) (*dto.InvoiceResponse, error) {
+	s.logger.Infof("CreateInvoice: creating invoice for client ID: %d, period: %s - %s",
+		request.ClientID, request.PeriodStart, request.PeriodEnd)
+	periodStart, err := parseDate(request.PeriodStart)
+	if err != nil {
+		s.logger.Debugf("CreateInvoice: invalid period start: %v", err)
+		return nil, err
+	}
+
+	periodEnd, err := parseDate(request.PeriodEnd)
+	if err != nil {
+		s.logger.Debugf("CreateInvoice: invalid period end: %v", err)
+		return nil, err
+	}
+	if periodEnd.Before(periodStart) {
+		return nil, models.ErrInvalidPeriod
+	}
+
+	if _, err := s.clientRepo.GetById(request.ClientID); err != nil {
+		s.logger.Debugf("CreateInvoice: failed to find client: %v", err)
+		return nil, err
+	}
+
+	tasks, err := s.invoiceRepo.GetUnbilledTasks(request.ClientID, periodStart, periodEnd.AddDate(0, 0, 1))
+	if err != nil {
+		s.logger.Debugf("CreateInvoice: failed to fetch unbilled tasks: %v", err)
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		return nil, models.ErrNothingToInvoice
+	}
+
+	rates, err := s.rateRepo.GetForClient(request.ClientID)
+	if err != nil {
+		s.logger.Debugf("CreateInvoice: failed to fetch rates: %v", err)
+		return nil, err
+	}
+
+	policies, err := s.roundingRepo.GetAll()
+	if err != nil {
+		s.logger.Debugf("CreateInvoice: failed to fetch rounding policies: %v", err)
+		return nil, err
+	}
+
+	invoice, err := buildInvoice(request.ClientID, periodStart, periodEnd, tasks, rates, newRoundingPolicies(policies))
+	if err != nil {
+		s.logger.Debugf("CreateInvoice: failed to price tasks: %v", err)
+		return nil, err
+	}
+
+	if err := s.invoiceRepo.Create(invoice); err != nil {
+		s.logger.Debugf("CreateInvoice: failed to create invoice in database: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("CreateInvoice: invoice %s created with ID: %d", invoice.Number, invoice.ID)
+	return newInvoiceResponse(invoice), nil
+}
+
+func (s *InvoiceServiceImpl) GetInvoice(id uint) (*dto.InvoiceResponse, error) {
+	s.logger.Infof("GetInvoice: getting invoice with ID: %d", id)
+	invoice, err := s.invoiceRepo.GetById(id)
+	if err != nil {
+		s.logger.Debugf("GetInvoice: failed to get invoice: %v", err)
+		return nil, err
+	}
+
+	return newInvoiceResponse(invoice), nil
+}
+
+func (s *InvoiceServiceImpl) GetInvoices(clientID *uint) ([]dto.InvoiceResponse, error) {
+	s.logger.Info("GetInvoices: fetching invoices")
+	invoices, err := s.invoiceRepo.GetAll(clientID)
+	if err != nil {
+		s.logger.Debugf("GetInvoices: failed to fetch invoices: %v", err)
+		return nil, err
+	}
+
+	invoiceResponses := make([]dto.InvoiceResponse, len(invoices))
+	for i := range invoices {
+		invoiceResponses[i] = *newInvoiceResponse(&invoices[i])
+	}
+
+	return invoiceResponses, nil
+}
+
+// buildInvoice prices every task with the rate that applies to it and freezes the
+// result into invoice lines. Tasks are billed by their duration after rounding.
+func buildInvoice(clientID uint, periodStart, periodEnd time.Time, tasks []models.Task, rates []models.Rate,
+	rounding *roundingPolicies) (*models.Invoice, error) {
+	invoice := &models.Invoice{
+		ClientID:    clientID,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+	}
+
+	for _, task := range tasks {
+		rate := selectRate(rates, task, clientID)
+		if rate == nil {
+			return nil, fmt.Errorf("%w %d", models.ErrRateNotFound, task.ID)
+		}
+
+		if invoice.Currency == "" {
+			invoice.Currency = rate.Currency
+		} else if invoice.Currency != rate.Currency {
+			return nil, models.ErrCurrencyMismatch
+		}
+
+		roundedMinutes := roundMinutes(task.Minutes, rounding.forProject(task.ProjectID, &clientID))
+		line := models.InvoiceLine{
+			TaskID:          task.ID,
+			Description:     task.TaskName,
+			Minutes:         task.Minutes,
+			RoundedMinutes:  roundedMinutes,
+			HourlyRateCents: rate.HourlyRateCents,
+			AmountCents:     billedAmount(roundedMinutes, rate.HourlyRateCents),
+		}
+		invoice.TotalCents += line.AmountCents
+		invoice.Lines = append(invoice.Lines, line)
+	}
+
+	return invoice, nil
+}
+
+func newInvoiceResponse(invoice *models.Invoice) *dto.InvoiceResponse {
+	response := &dto.InvoiceResponse{
+		ID:          invoice.ID,
+		Number:      invoice.Number,
+		ClientID:    invoice.ClientID,
+		PeriodStart: invoice.PeriodStart.Format(dateLayout),
+		PeriodEnd:   invoice.PeriodEnd.Format(dateLayout),
+		Currency:    invoice.Currency,
+		TotalCents:  invoice.TotalCents,
+		CreatedAt:   invoice.CreatedAt.Format(time.RFC3339),
+	}
+
+	for _, line := range invoice.Lines {
+		response.Lines = append(response.Lines, dto.InvoiceLineResponse{
+			TaskID:          line.TaskID,
+			Description:     line.Description,
+			Minutes:         line.Minutes,
+			RoundedMinutes:  line.RoundedMinutes,
+			HourlyRateCents: line.HourlyRateCents,
+			AmountCents:     line.AmountCents,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/eventbus"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	outboxBatchSize = 100
+	// outboxMaxRetryDelay caps the backoff between attempts to publish an event. Events are
+	// never given up on, so that every subscriber eventually sees every committed change.
+	outboxMaxRetryDelay = 10 * time.Minute
+)
+
+// OutboxRelay publishes the domain events stored in the outbox, oldest first, and publishes
+// again with exponential backoff those whose publishing failed.
+type OutboxRelay struct {
+	outboxRepo repositories.OutboxRepository
+	publisher  eventbus.Publisher
+	retryBase  time.Duration
+	logger     *logrus.Logger
+}
+
+func NewOutboxRelay(outboxRepo repositories.OutboxRepository, publisher eventbus.Publisher, retryBase time.Duration,
+	logger *logrus.Logger) *OutboxRelay {
+	return &OutboxRelay{
+		outboxRepo: outboxRepo,
+		publisher:  publisher,
+		retryBase:  retryBase,
+		logger:     logger,
+	}
+}
+
+// PublishPending publishes the events due by now and returns how many were handed to the
+// publisher, failed attempts included.
+func (r *OutboxRelay) PublishPending(ctx context.Context, now time.Time) (int, error) {
+	var total int
+	for {
+		count, err := r.outboxRepo.PublishPending(now, outboxBatchSize, func(event *models.OutboxEvent) {
+			r.publish(ctx, event, now)
+		})
+		total += count
+		if err != nil {
+			return total, err
+		}
+		if count < outboxBatchSize || ctx.Err() != nil {
+			return total, nil
+		}
+	}
+}
+
+// PruneEvents deletes the events published before the given time.
+func (r *OutboxRelay) PruneEvents(before time.Time) (int64, error) {
+	return r.outboxRepo.DeletePublishedBefore(before)
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, event *models.OutboxEvent, now time.Time) {
+	if err := r.publisher.Publish(ctx, event); err != nil {
+		event.Attempts++
+		lastError := err.Error()
+		event.LastError = &lastError
+		nextAttemptAt := now.Add(backoffDelay(r.retryBase, outboxMaxRetryDelay, event.Attempts))
+		event.NextAttemptAt = &nextAttemptAt
+		r.logger.Errorf("OutboxRelay: failed to publish event %s with ID %d, attempt %d: %v",
+			event.Event, event.ID, event.Attempts, err)
+		return
+	}
+
+	event.PublishedAt = &now
+	event.NextAttemptAt = nil
+}
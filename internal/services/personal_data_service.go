@@ -0,0 +1,13 @@
+package services
+
+import (
+	"context"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+)
+
+type PersonalDataService interface {
+	GetPersonalData(ctx context.Context, userID uint) (*dto.PersonalDataResponse, error)
+	EraseUser(ctx context.Context, userID uint) (*dto.ErasureResponse, error)
+	GetErasure(userID uint) (*dto.ErasureResponse, error)
+}
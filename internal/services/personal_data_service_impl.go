@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/audit"
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PersonalDataServiceImpl struct {
+	userRepo  repositories.UserRepository
+	taskRepo  repositories.TaskRepository
+	auditRepo repositories.AuditRepository
+	logger    *logrus.Logger
+}
+
+func NewPersonalDataServiceImpl(userRepo repositories.UserRepository, taskRepo repositories.TaskRepository,
+	auditRepo repositories.AuditRepository, logger *logrus.Logger) *PersonalDataServiceImpl {
+	return &PersonalDataServiceImpl{
+		userRepo:  userRepo,
+		taskRepo:  taskRepo,
+		auditRepo: auditRepo,
+		logger:    logger,
+	}
+}
+
+// GetPersonalData collects everything stored about the user, deleted or not, with task times
+// in their time zone. The passport number is masked like in user responses, unless passportAccess.
+func (s *PersonalDataServiceImpl) GetPersonalData(ctx context.Context, userID uint) (*dto.PersonalDataResponse, error) {
+	s.logger.Infof("GetPersonalData: collecting personal data of user with ID: %d", userID)
+	user, err := s.userRepo.GetById(userID)
+	if err != nil {
+		s.logger.Debugf("GetPersonalData: failed to get user: %v", err)
+		return nil, err
+	}
+	if err := s.userRepo.OpenPassport(user); err != nil {
+		s.logger.Debugf("GetPersonalData: failed to open passport number: %v", err)
+		return nil, err
+	}
+
+	response := &dto.PersonalDataResponse{
+		ExportedAt:   time.Now().UTC().Format(time.RFC3339),
+		Profile:      newPersonalDataProfile(user, passportAccess(ctx)),
+		Tasks:        make([]dto.TaskResponse, 0),
+		AuditEntries: make([]dto.AuditEntryResponse, 0),
+	}
+
+	loc := userLocation(user)
+	filter := repositories.TaskFilter{UserID: userID, EndDate: maxTaskTime, WithSegments: true}
+	err = s.taskRepo.GetUserTasksInBatches(filter, exportBatchSize, func(tasks []models.Task) error {
+		for i := range tasks {
+			response.Tasks = append(response.Tasks, *newTaskResponse(&tasks[i], loc))
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Debugf("GetPersonalData: failed to fetch tasks: %v", err)
+		return nil, err
+	}
+
+	err = s.auditRepo.GetUserEntriesInBatches(userID, exportBatchSize, func(entries []models.AuditEntry) error {
+		for i := range entries {
+			response.AuditEntries = append(response.AuditEntries, *newAuditEntryResponse(&entries[i]))
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Debugf("GetPersonalData: failed to fetch audit entries: %v", err)
+		return nil, err
+	}
+
+	if user.ErasedAt != nil {
+		erasure, err := s.userRepo.GetErasure(userID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Debugf("GetPersonalData: failed to get erasure: %v", err)
+			return nil, err
+		}
+		if erasure != nil {
+			response.Erasure = newErasureResponse(erasure)
+		}
+	}
+
+	s.logger.Infof("GetPersonalData: collected %d tasks and %d audit entries of user with ID: %d",
+		len(response.Tasks), len(response.AuditEntries), userID)
+	return response, nil
+}
+
+// EraseUser irreversibly anonymises the personal data of the user, keeping their tasks so
+// that the time they tracked can still be accounted for, and returns the proof of erasure
+// made by the actor of ctx.
+func (s *PersonalDataServiceImpl) EraseUser(ctx context.Context, userID uint) (*dto.ErasureResponse, error) {
+	s.logger.Infof("EraseUser: erasing personal data of user with ID: %d", userID)
+	actor := audit.FromContext(ctx)
+	erasure := &models.Erasure{ActorID: actor.UserID}
+	if actor.RequestID != "" {
+		erasure.RequestID = &actor.RequestID
+	}
+
+	if _, err := s.userRepo.Erase(ctx, userID, erasure); err != nil {
+		s.logger.Debugf("EraseUser: failed to erase personal data: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("EraseUser: personal data of user with ID %d erased, %d audit entries redacted",
+		userID, erasure.AuditEntriesRedacted)
+	return newErasureResponse(erasure), nil
+}
+
+func (s *PersonalDataServiceImpl) GetErasure(userID uint) (*dto.ErasureResponse, error) {
+	s.logger.Infof("GetErasure: getting erasure of user with ID: %d", userID)
+	erasure, err := s.userRepo.GetErasure(userID)
+	if err != nil {
+		s.logger.Debugf("GetErasure: failed to get erasure: %v", err)
+		return nil, err
+	}
+
+	return newErasureResponse(erasure), nil
+}
+
+// maxTaskTime bounds the tasks of a user from above when all of them are wanted.
+var maxTaskTime = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+func newPersonalDataProfile(user *models.User, passportAccess bool) dto.PersonalDataProfile {
+	passportNumber := user.PassportNumber
+	if !passportAccess {
+		passportNumber = maskPassportNumber(passportNumber)
+	}
+
+	profile := dto.PersonalDataProfile{
+		ID:             user.ID,
+		PassportNumber: passportNumber,
+		Surname:        user.Surname,
+		Name:           user.Name,
+		Patronymic:     user.Patronymic,
+		Address:        user.Address,
+		Role:           user.Role,
+		TimeZone:       user.TimeZone,
+		WorkdayEnd:     user.WorkdayEnd,
+		CreatedAt:      user.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      user.UpdatedAt.Format(time.RFC3339),
+	}
+	if user.DeletedAt != nil {
+		profile.DeletedAt = user.DeletedAt.Format(time.RFC3339)
+	}
+	if user.ErasedAt != nil {
+		profile.ErasedAt = user.ErasedAt.Format(time.RFC3339)
+	}
+
+	return profile
+}
+
+func newErasureResponse(erasure *models.Erasure) *dto.ErasureResponse {
+	response := &dto.ErasureResponse{
+		ID:                       erasure.ID,
+		UserID:                   erasure.UserID,
+		ActorID:                  erasure.ActorID,
+		Fields:                   strings.Split(erasure.Fields, ","),
+		AuditEntriesRedacted:     erasure.AuditEntriesRedacted,
+		WebhookDeliveriesDeleted: erasure.WebhookDeliveriesDeleted,
+		ErasedAt:                 erasure.ErasedAt.Format(time.RFC3339),
+	}
+	if erasure.RequestID != nil {
+		response.RequestID = *erasure.RequestID
+	}
+
+	return response
+}
@@ -0,0 +1,11 @@
+package services
+
+import "github.com/Dor1ma/Time-Tracker/internal/dto"
+
+type ProjectService interface {
+	CreateProject(request dto.CreateProjectRequest) (*dto.ProjectResponse, error)
+	GetProjectById(id uint) (*dto.ProjectResponse, error)
+	GetProjects(clientID *uint, includeArchived bool) ([]dto.ProjectResponse, error)
+	UpdateProject(id uint, request dto.UpdateProjectRequest) (*dto.ProjectResponse, error)
+	DeleteProject(id uint) error
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/sirupsen/logrus"
+)
+
+type ProjectServiceImpl struct {
+	projectRepo repositories.ProjectRepository
+	clientRepo  repositories.ClientRepository
+	logger      *logrus.Logger
+}
+
+func NewProjectServiceImpl(projectRepo repositories.ProjectRepository, clientRepo repositories.ClientRepository, logger *logrus.Logger) *ProjectServiceImpl {
+	return &ProjectServiceImpl{
+		projectRepo: projectRepo,
+		clientRepo:  clientRepo,
+		logger:      logger,
+	}
+}
+
+func (s *ProjectServiceImpl) CreateProject(request dto.CreateProjectRequest) (*dto.ProjectResponse, error) {
+	s.logger.Infof("CreateProject: creating project with name: %s", request.Name)
+	project := &models.Project{
+		Name:     request.Name,
+		ClientID: request.ClientID,
+	}
+
+	if err := s.attachClient(project); err != nil {
+		s.logger.Debugf("CreateProject: failed to find client: %v", err)
+		return nil, err
+	}
+
+	if err := s.projectRepo.Create(project); err != nil {
+		s.logger.Debugf("CreateProject: failed to create project in database: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("CreateProject: project created with ID: %d", project.ID)
+	return newProjectResponse(project), nil
+}
+
+func (s *ProjectServiceImpl) GetProjectById(id uint) (*dto.ProjectResponse, error) {
+	s.logger.Infof("GetProjectById: getting project with id: %d", id)
+	project, err := s.projectRepo.GetById(id)
+	if err != nil {
+		s.logger.Debugf("GetProjectById: failed to get project in database: %v", err)
+		return nil, err
+	}
+
+	return newProjectResponse(project), nil
+}
+
+func (s *ProjectServiceImpl) GetProjects(clientID *uint, includeArchived bool) ([]dto.ProjectResponse, error) {
+	s.logger.Infof("GetProjects: fetching projects, include archived: %t", includeArchived)
+	projects, err := s.projectRepo.GetAll(clientID, includeArchived)
+	if err != nil {
+		s.logger.Debugf("GetProjects: failed to fetch projects: %v", err)
+		return nil, err
+	}
+
+	projectResponses := make([]dto.ProjectResponse, len(projects))
+	for i := range projects {
+		projectResponses[i] = *newProjectResponse(&projects[i])
+	}
+
+	return projectResponses, nil
+}
+
+func (s *ProjectServiceImpl) UpdateProject(id uint, request dto.UpdateProjectRequest) (*dto.ProjectResponse, error) {
+	s.logger.Infof("UpdateProject: updating project with ID: %d", id)
+	project, err := s.projectRepo.GetById(id)
+	if err != nil {
+		s.logger.Errorf("UpdateProject: failed to update project: %v", err)
+		return nil, err
+	}
+
+	project.Name = request.Name
+	project.ClientID = request.ClientID
+	project.Archived = request.Archived
+	if err := s.attachClient(project); err != nil {
+		s.logger.Debugf("UpdateProject: failed to find client: %v", err)
+		return nil, err
+	}
+
+	if err := s.projectRepo.Update(project); err != nil {
+		s.logger.Errorf("UpdateProject: failed to update project: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("UpdateProject: project updated with ID: %d", project.ID)
+	return newProjectResponse(project), nil
+}
+
+func (s *ProjectServiceImpl) DeleteProject(id uint) error {
+	s.logger.Infof("DeleteProject: deleting project with ID: %d", id)
+	if err := s.projectRepo.Delete(id); err != nil {
+		s.logger.Debugf("DeleteProject: failed to delete project: %v", err)
+		return err
+	}
+
+	s.logger.Infof("DeleteProject: project deleted with ID: %d", id)
+	return nil
+}
+
+// attachClient makes sure the client referenced by the project exists.
+func (s *ProjectServiceImpl) attachClient(project *models.Project) error {
+	project.Client = nil
+	if project.ClientID == nil {
+		return nil
+	}
+
+	client, err := s.clientRepo.GetById(*project.ClientID)
+	if err != nil {
+		return err
+	}
+
+	project.Client = client
+	return nil
+}
+
+func newProjectResponse(project *models.Project) *dto.ProjectResponse {
+	response := &dto.ProjectResponse{
+		ID:       project.ID,
+		Name:     project.Name,
+		ClientID: project.ClientID,
+		Archived: project.Archived,
+	}
+	if project.Client != nil {
+		response.ClientName = project.Client.Name
+	}
+
+	return response
+}
@@ -0,0 +1,9 @@
+package services
+
+import "github.com/Dor1ma/Time-Tracker/internal/dto"
+
+type RateService interface {
+	CreateRate(request dto.CreateRateRequest) (*dto.RateResponse, error)
+	GetRates(userID, projectID, clientID *uint) ([]dto.RateResponse, error)
+	DeleteRate(id uint) error
+}
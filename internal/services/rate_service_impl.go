@@ -0,0 +1,91 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/sirupsen/logrus"
+)
+
+type RateServiceImpl struct {
+	rateRepo repositories.RateRepository
+	logger   *logrus.Logger
+}
+
+func NewRateServiceImpl(rateRepo repositories.RateRepository, logger *logrus.Logger) *RateServiceImpl {
+	return &RateServiceImpl{
+		rateRepo: rateRepo,
+		logger:   logger,
+	}
+}
+
+func (s *RateServiceImpl) CreateRate(request dto.CreateRateRequest) (*dto.RateResponse, error) {
+	s.logger.Infof("CreateRate: creating rate effective from %s", request.EffectiveFrom)
+	if request.ProjectID != nil && request.ClientID != nil {
+		return nil, models.ErrInvalidRateScope
+	}
+
+	effectiveFrom, err := parseDate(request.EffectiveFrom)
+	if err != nil {
+		s.logger.Debugf("CreateRate: invalid effective date: %v", err)
+		return nil, err
+	}
+
+	rate := &models.Rate{
+		UserID:          request.UserID,
+		ProjectID:       request.ProjectID,
+		ClientID:        request.ClientID,
+		HourlyRateCents: request.HourlyRateCents,
+		Currency:        strings.ToUpper(request.Currency),
+		EffectiveFrom:   effectiveFrom,
+	}
+
+	if err := s.rateRepo.Create(rate); err != nil {
+		s.logger.Debugf("CreateRate: failed to create rate in database: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("CreateRate: rate created with ID: %d", rate.ID)
+	return newRateResponse(rate), nil
+}
+
+func (s *RateServiceImpl) GetRates(userID, projectID, clientID *uint) ([]dto.RateResponse, error) {
+	s.logger.Info("GetRates: fetching rates")
+	rates, err := s.rateRepo.GetAll(userID, projectID, clientID)
+	if err != nil {
+		s.logger.Debugf("GetRates: failed to fetch rates: %v", err)
+		return nil, err
+	}
+
+	rateResponses := make([]dto.RateResponse, len(rates))
+	for i := range rates {
+		rateResponses[i] = *newRateResponse(&rates[i])
+	}
+
+	return rateResponses, nil
+}
+
+func (s *RateServiceImpl) DeleteRate(id uint) error {
+	s.logger.Infof("DeleteRate: deleting rate with ID: %d", id)
+	if err := s.rateRepo.Delete(id); err != nil {
+		s.logger.Debugf("DeleteRate: failed to delete rate: %v", err)
+		return err
+	}
+
+	s.logger.Infof("DeleteRate: rate deleted with ID: %d", id)
+	return nil
+}
+
+func newRateResponse(rate *models.Rate) *dto.RateResponse {
+	return &dto.RateResponse{
+		ID:              rate.ID,
+		UserID:          rate.UserID,
+		ProjectID:       rate.ProjectID,
+		ClientID:        rate.ClientID,
+		HourlyRateCents: rate.HourlyRateCents,
+		Currency:        rate.Currency,
+		EffectiveFrom:   rate.EffectiveFrom.Format(dateLayout),
+	}
+}
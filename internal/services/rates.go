@@ -0,0 +1,65 @@
+package services
+
+import "github.com/Dor1ma/Time-Tracker/internal/models"
+
+// rateSpecificity ranks how closely a rate is bound to a task: a project rate beats
+// a client rate, which beats a user rate. Adding a user to a project or client rate
+// makes it more specific still.
+func rateSpecificity(rate models.Rate) int {
+	specificity := 0
+	if rate.ProjectID != nil {
+		specificity += 4
+	}
+	if rate.ClientID != nil {
+		specificity += 2
+	}
+	if rate.UserID != nil {
+		specificity++
+	}
+
+	return specificity
+}
+
+func rateApplies(rate models.Rate, task models.Task, clientID uint) bool {
+	if rate.UserID != nil && *rate.UserID != task.UserID {
+		return false
+	}
+	if rate.ProjectID != nil && (task.ProjectID == nil || *rate.ProjectID != *task.ProjectID) {
+		return false
+	}
+	if rate.ClientID != nil && *rate.ClientID != clientID {
+		return false
+	}
+
+	return !rate.EffectiveFrom.After(task.StartTime)
+}
+
+// selectRate picks the most specific rate in effect when the task started. Among
+// equally specific rates the one that became effective last wins.
+func selectRate(rates []models.Rate, task models.Task, clientID uint) *models.Rate {
+	var selected *models.Rate
+	for i := range rates {
+		rate := &rates[i]
+		if !rateApplies(*rate, task, clientID) {
+			continue
+		}
+
+		if selected == nil {
+			selected = rate
+			continue
+		}
+
+		specificity, selectedSpecificity := rateSpecificity(*rate), rateSpecificity(*selected)
+		if specificity > selectedSpecificity ||
+			specificity == selectedSpecificity && rate.EffectiveFrom.After(selected.EffectiveFrom) {
+			selected = rate
+		}
+	}
+
+	return selected
+}
+
+// billedAmount returns the price of the minutes at the hourly rate, rounded half up to a cent.
+func billedAmount(minutes int, hourlyRateCents int64) int64 {
+	return (int64(minutes)*hourlyRateCents + 30) / 60
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+)
+
+const (
+	recurrenceDaily   = "DAILY"
+	recurrenceWeekly  = "WEEKLY"
+	recurrenceMonthly = "MONTHLY"
+)
+
+var recurrenceWeekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// recurrenceRule is the supported subset of an RFC 5545 RRULE: FREQ of DAILY, WEEKLY or
+// MONTHLY, with INTERVAL, BYDAY of plain weekdays, and at most one of COUNT and UNTIL.
+// UNTIL is a date, YYYYMMDD, in the time zone of the occurrences; it is inclusive.
+type recurrenceRule struct {
+	freq     string
+	interval int
+	byDay    map[time.Weekday]bool
+	count    int
+	until    *time.Time
+}
+
+func parseRecurrenceRule(value string) (*recurrenceRule, error) {
+	rule := &recurrenceRule{interval: 1, byDay: make(map[time.Weekday]bool)}
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(strings.ToUpper(strings.TrimSpace(part)), "=")
+		if !ok || val == "" {
+			return nil, fmt.Errorf("%w: %q is not a KEY=VALUE pair", models.ErrInvalidRecurrenceRule, part)
+		}
+
+		switch key {
+		case "FREQ":
+			if val != recurrenceDaily && val != recurrenceWeekly && val != recurrenceMonthly {
+				return nil, fmt.Errorf("%w: unsupported FREQ %s", models.ErrInvalidRecurrenceRule, val)
+			}
+			rule.freq = val
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("%w: INTERVAL must be a positive integer", models.ErrInvalidRecurrenceRule)
+			}
+			rule.interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("%w: COUNT must be a positive integer", models.ErrInvalidRecurrenceRule)
+			}
+			rule.count = count
+		case "UNTIL":
+			until, err := time.Parse("20060102", val)
+			if err != nil {
+				return nil, fmt.Errorf("%w: UNTIL must be a date in format YYYYMMDD", models.ErrInvalidRecurrenceRule)
+			}
+			rule.until = &until
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				weekday, ok := recurrenceWeekdays[day]
+				if !ok {
+					return nil, fmt.Errorf("%w: unsupported BYDAY %s", models.ErrInvalidRecurrenceRule, day)
+				}
+				rule.byDay[weekday] = true
+			}
+		default:
+			return nil, fmt.Errorf("%w: unsupported part %s", models.ErrInvalidRecurrenceRule, key)
+		}
+	}
+
+	switch {
+	case rule.freq == "":
+		return nil, fmt.Errorf("%w: FREQ is required", models.ErrInvalidRecurrenceRule)
+	case rule.count > 0 && rule.until != nil:
+		return nil, fmt.Errorf("%w: COUNT and UNTIL cannot be combined", models.ErrInvalidRecurrenceRule)
+	case rule.freq == recurrenceMonthly && len(rule.byDay) > 0:
+		return nil, fmt.Errorf("%w: BYDAY is not supported with FREQ=MONTHLY", models.ErrInvalidRecurrenceRule)
+	}
+
+	return rule, nil
+}
+
+// occurrences returns the occurrences of the rule that do not start after end. The first
+// occurrence is first, unless BYDAY excludes its weekday; later ones keep its local time of
+// day in its location, also across daylight saving changes. Months without the day of
+// first are skipped.
+func (r *recurrenceRule) occurrences(first, end time.Time) []time.Time {
+	var occurrences []time.Time
+	emit := func(occurrence time.Time) bool {
+		if occurrence.After(end) || r.after(occurrence) || (r.count > 0 && len(occurrences) == r.count) {
+			return false
+		}
+		occurrences = append(occurrences, occurrence)
+		return true
+	}
+
+	day := func(offset int) time.Time {
+		return time.Date(first.Year(), first.Month(), first.Day()+offset, first.Hour(), first.Minute(), 0, 0, first.Location())
+	}
+
+	switch r.freq {
+	case recurrenceDaily:
+		for n := 0; ; n += r.interval {
+			occurrence := day(n)
+			if occurrence.After(end) || r.after(occurrence) {
+				return occurrences
+			}
+			if len(r.byDay) > 0 && !r.byDay[occurrence.Weekday()] {
+				continue
+			}
+			if !emit(occurrence) {
+				return occurrences
+			}
+		}
+	case recurrenceWeekly:
+		byDay := r.byDay
+		if len(byDay) == 0 {
+			byDay = map[time.Weekday]bool{first.Weekday(): true}
+		}
+
+		monday := -((int(first.Weekday()) + 6) % 7)
+		for week := 0; ; week += r.interval {
+			for offset := 0; offset < 7; offset++ {
+				occurrence := day(monday + 7*week + offset)
+				if occurrence.Before(first) || !byDay[occurrence.Weekday()] {
+					continue
+				}
+				if !emit(occurrence) {
+					return occurrences
+				}
+			}
+		}
+	default:
+		for month := 0; ; month += r.interval {
+			occurrence := time.Date(first.Year(), first.Month()+time.Month(month), first.Day(),
+				first.Hour(), first.Minute(), 0, 0, first.Location())
+			if occurrence.After(end) || r.after(occurrence) {
+				return occurrences
+			}
+			if occurrence.Day() != first.Day() {
+				continue
+			}
+			if !emit(occurrence) {
+				return occurrences
+			}
+		}
+	}
+}
+
+// after reports whether the occurrence falls on a day after UNTIL.
+func (r *recurrenceRule) after(occurrence time.Time) bool {
+	if r.until == nil {
+		return false
+	}
+
+	date := time.Date(occurrence.Year(), occurrence.Month(), occurrence.Day(), 0, 0, 0, 0, time.UTC)
+	return date.After(*r.until)
+}
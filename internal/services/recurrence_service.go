@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+)
+
+type RecurrenceService interface {
+	CreateRecurrence(request dto.CreateRecurrenceRequest) (*dto.RecurrenceResponse, error)
+	GetRecurrences(templateID *uint) ([]dto.RecurrenceResponse, error)
+	DeleteRecurrence(id uint) error
+	SkipOccurrence(id uint, request dto.SkipOccurrenceRequest) (*dto.RecurrenceResponse, error)
+	CreateDueEntries(ctx context.Context, now time.Time) (int, error)
+}
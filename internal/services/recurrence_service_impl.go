@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/sirupsen/logrus"
+)
+
+const recurrenceTimeLayout = "15:04"
+
+type RecurrenceServiceImpl struct {
+	recurrenceRepo  repositories.RecurrenceRepository
+	templateRepo    repositories.TaskTemplateRepository
+	userRepo        repositories.UserRepository
+	planningHorizon time.Duration
+	logger          *logrus.Logger
+}
+
+// NewRecurrenceServiceImpl returns a recurrence service that creates planned tasks for the
+// occurrences starting within planningHorizon from now.
+func NewRecurrenceServiceImpl(recurrenceRepo repositories.RecurrenceRepository, templateRepo repositories.TaskTemplateRepository,
+	userRepo repositories.UserRepository, planningHorizon time.Duration, logger *logrus.Logger) *RecurrenceServiceImpl {
+	return &RecurrenceServiceImpl{
+		recurrenceRepo:  recurrenceRepo,
+		templateRepo:    templateRepo,
+		userRepo:        userRepo,
+		planningHorizon: planningHorizon,
+		logger:          logger,
+	}
+}
+
+func (s *RecurrenceServiceImpl) CreateRecurrence(request dto.CreateRecurrenceRequest) (*dto.RecurrenceResponse, error) {
+	s.logger.Infof("CreateRecurrence: creating recurrence for template ID: %d, rule: %s", request.TemplateID, request.Rule)
+	if _, err := parseRecurrenceRule(request.Rule); err != nil {
+		s.logger.Debugf("CreateRecurrence: invalid rule: %v", err)
+		return nil, err
+	}
+
+	startDate, err := parseDate(request.StartDate)
+	if err != nil {
+		s.logger.Debugf("CreateRecurrence: invalid start date: %v", err)
+		return nil, err
+	}
+
+	template, err := s.templateRepo.GetById(request.TemplateID)
+	if err != nil {
+		s.logger.Debugf("CreateRecurrence: failed to get task template: %v", err)
+		return nil, err
+	}
+
+	recurrence := &models.Recurrence{
+		TemplateID: template.ID,
+		Template:   template,
+		Rule:       request.Rule,
+		StartDate:  startDate,
+		StartTime:  request.StartTime,
+		Mode:       request.Mode,
+	}
+
+	if err := s.recurrenceRepo.Create(recurrence); err != nil {
+		s.logger.Debugf("CreateRecurrence: failed to create recurrence in database: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("CreateRecurrence: recurrence created with ID: %d", recurrence.ID)
+	return newRecurrenceResponse(recurrence), nil
+}
+
+func (s *RecurrenceServiceImpl) GetRecurrences(templateID *uint) ([]dto.RecurrenceResponse, error) {
+	s.logger.Info("GetRecurrences: fetching recurrences")
+	recurrences, err := s.recurrenceRepo.GetAll(templateID)
+	if err != nil {
+		s.logger.Debugf("GetRecurrences: failed to fetch recurrences: %v", err)
+		return nil, err
+	}
+
+	recurrenceResponses := make([]dto.RecurrenceResponse, len(recurrences))
+	for i := range recurrences {
+		recurrenceResponses[i] = *newRecurrenceResponse(&recurrences[i])
+	}
+
+	return recurrenceResponses, nil
+}
+
+func (s *RecurrenceServiceImpl) DeleteRecurrence(id uint) error {
+	s.logger.Infof("DeleteRecurrence: deleting recurrence with ID: %d", id)
+	if err := s.recurrenceRepo.Delete(id); err != nil {
+		s.logger.Debugf("DeleteRecurrence: failed to delete recurrence: %v", err)
+		return err
+	}
+
+	s.logger.Infof("DeleteRecurrence: recurrence deleted with ID: %d", id)
+	return nil
+}
+
+// SkipOccurrence cancels the occurrence on the requested day of the user of the recurrence,
+// removing its planned task if it has been created already.
+func (s *RecurrenceServiceImpl) SkipOccurrence(id uint, request dto.SkipOccurrenceRequest) (*dto.RecurrenceResponse, error) {
+	s.logger.Infof("SkipOccurrence: skipping occurrence of recurrence ID: %d on %s", id, request.Date)
+	recurrence, err := s.recurrenceRepo.GetById(id)
+	if err != nil {
+		s.logger.Debugf("SkipOccurrence: failed to get recurrence: %v", err)
+		return nil, err
+	}
+
+	day, err := parseDateIn(request.Date, s.location(recurrence.Template.UserID))
+	if err != nil {
+		s.logger.Debugf("SkipOccurrence: invalid date: %v", err)
+		return nil, err
+	}
+
+	date, _ := parseDate(request.Date)
+	skip := models.RecurrenceSkip{RecurrenceID: recurrence.ID, Date: date}
+	if err := s.recurrenceRepo.AddSkip(&skip, day, day.AddDate(0, 0, 1)); err != nil {
+		s.logger.Debugf("SkipOccurrence: failed to skip occurrence: %v", err)
+		return nil, err
+	}
+
+	if !skipsDate(recurrence, request.Date) {
+		recurrence.Skips = append(recurrence.Skips, skip)
+	}
+
+	s.logger.Infof("SkipOccurrence: occurrence of recurrence ID %d on %s skipped", id, request.Date)
+	return newRecurrenceResponse(recurrence), nil
+}
+
+// CreateDueEntries creates the tasks of the occurrences that are due as of now: recorded
+// tasks once an occurrence has ended, planned ones once it starts within the planning horizon.
+// An occurrence whose task cannot be created, for example because it overlaps another task
+// of the user, is passed over. It returns the number of tasks created.
+func (s *RecurrenceServiceImpl) CreateDueEntries(ctx context.Context, now time.Time) (int, error) {
+	s.logger.Infof("CreateDueEntries: creating tasks of recurrences due at %s", now.Format(time.RFC3339))
+	recurrences, err := s.recurrenceRepo.GetAll(nil)
+	if err != nil {
+		s.logger.Debugf("CreateDueEntries: failed to fetch recurrences: %v", err)
+		return 0, err
+	}
+
+	locations := make(map[uint]*time.Location)
+	var created int
+	for i := range recurrences {
+		recurrence := &recurrences[i]
+		loc, ok := locations[recurrence.Template.UserID]
+		if !ok {
+			loc = s.location(recurrence.Template.UserID)
+			locations[recurrence.Template.UserID] = loc
+		}
+
+		count, err := s.createDueEntries(ctx, recurrence, loc, now)
+		created += count
+		if err != nil {
+			s.logger.Debugf("CreateDueEntries: failed to create tasks of recurrence with ID %d: %v", recurrence.ID, err)
+		}
+	}
+
+	s.logger.Infof("CreateDueEntries: created %d tasks of %d recurrences", created, len(recurrences))
+	return created, nil
+}
+
+func (s *RecurrenceServiceImpl) createDueEntries(ctx context.Context, recurrence *models.Recurrence, loc *time.Location, now time.Time) (int, error) {
+	rule, err := parseRecurrenceRule(recurrence.Rule)
+	if err != nil {
+		return 0, err
+	}
+
+	first, err := firstOccurrence(recurrence, loc)
+	if err != nil {
+		return 0, err
+	}
+
+	template := recurrence.Template
+	duration := time.Duration(template.DurationMinutes) * time.Minute
+	end := now.Add(-duration)
+	planned := recurrence.Mode == models.RecurrenceModePlanned
+	if planned {
+		end = now.Add(s.planningHorizon)
+	}
+
+	var created int
+	for _, occurrence := range rule.occurrences(first, end) {
+		if recurrence.GeneratedUntil != nil && !occurrence.After(*recurrence.GeneratedUntil) {
+			continue
+		}
+
+		if skipsDate(recurrence, occurrence.Format(dateLayout)) {
+			if err := s.recurrenceRepo.PassOccurrence(recurrence.ID, occurrence); err != nil {
+				return created, err
+			}
+			continue
+		}
+
+		task := &models.Task{
+			UserID:    template.UserID,
+			TaskName:  template.TaskName,
+			ProjectID: template.ProjectID,
+			Billable:  template.Billable,
+			Tags:      newTags(templateTagNames(template)),
+			StartTime: occurrence,
+			EndTime:   occurrence.Add(duration),
+		}
+
+		err := s.recurrenceRepo.CreateOccurrence(ctx, recurrence.ID, task, planned)
+		switch {
+		case err == nil:
+			created++
+		case errors.Is(err, models.ErrOccurrenceRecorded):
+		case errors.Is(err, models.ErrTaskOverlap),
+			errors.Is(err, models.ErrTimesheetLocked),
+			errors.Is(err, models.ErrProjectArchived):
+			s.logger.Debugf("CreateDueEntries: passing over occurrence of recurrence ID %d at %s: %v",
+				recurrence.ID, occurrence.Format(time.RFC3339), err)
+			if err := s.recurrenceRepo.PassOccurrence(recurrence.ID, occurrence); err != nil {
+				return created, err
+			}
+		default:
+			return created, err
+		}
+	}
+
+	return created, nil
+}
+
+// location returns the time zone of the user, UTC if the user cannot be fetched.
+func (s *RecurrenceServiceImpl) location(userID uint) *time.Location {
+	user, err := s.userRepo.GetById(userID)
+	if err != nil {
+		s.logger.Debugf("location: failed to fetch user with ID %d: %v", userID, err)
+		return time.UTC
+	}
+
+	return userLocation(user)
+}
+
+// firstOccurrence combines the start date and time of the recurrence in loc.
+func firstOccurrence(recurrence *models.Recurrence, loc *time.Location) (time.Time, error) {
+	clock, err := time.Parse(recurrenceTimeLayout, recurrence.StartTime)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid start time %q: %w", recurrence.StartTime, err)
+	}
+
+	date := recurrence.StartDate
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), 0, 0, loc), nil
+}
+
+func skipsDate(recurrence *models.Recurrence, date string) bool {
+	for _, skip := range recurrence.Skips {
+		if skip.Date.Format(dateLayout) == date {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newRecurrenceResponse(recurrence *models.Recurrence) *dto.RecurrenceResponse {
+	response := &dto.RecurrenceResponse{
+		ID:         recurrence.ID,
+		TemplateID: recurrence.TemplateID,
+		Rule:       recurrence.Rule,
+		StartDate:  recurrence.StartDate.Format(dateLayout),
+		StartTime:  recurrence.StartTime,
+		Mode:       recurrence.Mode,
+	}
+	if recurrence.GeneratedUntil != nil {
+		response.GeneratedUntil = recurrence.GeneratedUntil.Format(time.RFC3339)
+	}
+	for _, skip := range recurrence.Skips {
+		response.SkippedDates = append(response.SkippedDates, skip.Date.Format(dateLayout))
+	}
+
+	return response
+}
@@ -0,0 +1,12 @@
+package services
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/export"
+)
+
+type ReportService interface {
+	GetUserSummary(userID uint, request dto.GetUserSummaryRequest) (*dto.SummaryResponse, error)
+	ExportUserSummary(userID uint, request dto.ExportUserSummaryRequest, writer export.Writer) error
+	GetPivotReport(request dto.GetPivotReportRequest) (*dto.PivotReportResponse, error)
+}
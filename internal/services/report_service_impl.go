@@ -0,0 +1,319 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/export"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/sirupsen/logrus"
+)
+
+type ReportServiceImpl struct {
+	reportRepo   repositories.ReportRepository
+	userRepo     repositories.UserRepository
+	teamRepo     repositories.TeamRepository
+	roundingRepo repositories.RoundingPolicyRepository
+	logger       *logrus.Logger
+}
+
+func NewReportServiceImpl(reportRepo repositories.ReportRepository, userRepo repositories.UserRepository,
+	teamRepo repositories.TeamRepository, roundingRepo repositories.RoundingPolicyRepository, logger *logrus.Logger) *ReportServiceImpl {
+	return &ReportServiceImpl{
+		reportRepo:   reportRepo,
+		userRepo:     userRepo,
+		teamRepo:     teamRepo,
+		roundingRepo: roundingRepo,
+		logger:       logger,
+	}
+}
+
+func (s *ReportServiceImpl) GetUserSummary(userID uint, request dto.GetUserSummaryRequest) (*dto.SummaryResponse, error) {
+	s.logger.Infof("GetUserSummary: summarizing tasks for user ID: %d, from: %s, to: %s", userID, request.From, request.To)
+	from, err := parseDate(request.From)
+	if err != nil {
+		s.logger.Debugf("GetUserSummary: invalid from date: %v", err)
+		return nil, err
+	}
+
+	to, err := parseDate(request.To)
+	if err != nil {
+		s.logger.Debugf("GetUserSummary: invalid to date: %v", err)
+		return nil, err
+	}
+	if to.Before(from) {
+		return nil, models.ErrInvalidPeriod
+	}
+
+	groupBy := request.GroupBy
+	if groupBy == "" {
+		groupBy = repositories.ReportDimensionDay
+	}
+
+	if _, err := s.userRepo.GetById(userID); err != nil {
+		s.logger.Debugf("GetUserSummary: failed to find user: %v", err)
+		return nil, err
+	}
+
+	rows, err := s.reportRepo.GetUserSummary(repositories.SummaryFilter{
+		UserID:  userID,
+		From:    from,
+		To:      to.AddDate(0, 0, 1),
+		GroupBy: groupBy,
+	})
+	if err != nil {
+		s.logger.Debugf("GetUserSummary: failed to summarize tasks: %v", err)
+		return nil, err
+	}
+
+	policies, err := s.roundingRepo.GetAll()
+	if err != nil {
+		s.logger.Debugf("GetUserSummary: failed to fetch rounding policies: %v", err)
+		return nil, err
+	}
+
+	response := &dto.SummaryResponse{
+		UserID:  userID,
+		From:    request.From,
+		To:      request.To,
+		GroupBy: groupBy,
+		Groups:  newSummaryGroupResponses(rows, newRoundingPolicies(policies)),
+	}
+	for _, group := range response.Groups {
+		response.TotalSeconds += group.Seconds
+		response.RoundedTotalSeconds += group.RoundedSeconds
+	}
+	response.TotalDuration = formatDuration(response.TotalSeconds)
+	response.RoundedTotalDuration = formatDuration(response.RoundedTotalSeconds)
+
+	s.logger.Infof("GetUserSummary: summarized %d groups for user ID: %d", len(response.Groups), userID)
+	return response, nil
+}
+
+func (s *ReportServiceImpl) ExportUserSummary(userID uint, request dto.ExportUserSummaryRequest, writer export.Writer) error {
+	s.logger.Infof("ExportUserSummary: exporting summary for user ID: %d, from: %s, to: %s", userID, request.From, request.To)
+	names, values, err := exportColumns(request.Columns, summaryExportColumns, defaultSummaryExportColumns)
+	if err != nil {
+		s.logger.Debugf("ExportUserSummary: invalid columns: %v", err)
+		return err
+	}
+
+	summary, err := s.GetUserSummary(userID, request.GetUserSummaryRequest)
+	if err != nil {
+		return err
+	}
+
+	if err := writeExportHeader(writer, names); err != nil {
+		return err
+	}
+
+	row := make([]any, len(values))
+	for i := range summary.Groups {
+		if err := writeExportRow(writer, values, &summary.Groups[i], row); err != nil {
+			return err
+		}
+	}
+
+	s.logger.Infof("ExportUserSummary: exported %d groups for user ID: %d", len(summary.Groups), userID)
+	return nil
+}
+
+const defaultPivotPageSize = 50
+
+func (s *ReportServiceImpl) GetPivotReport(request dto.GetPivotReportRequest) (*dto.PivotReportResponse, error) {
+	s.logger.Infof("GetPivotReport: building %s by %s report, from: %s, to: %s", request.Rows, request.Columns, request.From, request.To)
+	from, err := parseDate(request.From)
+	if err != nil {
+		s.logger.Debugf("GetPivotReport: invalid from date: %v", err)
+		return nil, err
+	}
+
+	to, err := parseDate(request.To)
+	if err != nil {
+		s.logger.Debugf("GetPivotReport: invalid to date: %v", err)
+		return nil, err
+	}
+	if to.Before(from) {
+		return nil, models.ErrInvalidPeriod
+	}
+
+	filter := repositories.PivotFilter{
+		From:    from,
+		To:      to.AddDate(0, 0, 1),
+		Rows:    request.Rows,
+		Columns: request.Columns,
+	}
+	if filter.Rows == "" {
+		filter.Rows = repositories.ReportDimensionUser
+	}
+	if filter.Columns == "" {
+		filter.Columns = repositories.ReportDimensionDay
+	}
+	if filter.Rows == filter.Columns {
+		return nil, models.ErrSameReportDimension
+	}
+
+	page, pageSize := request.Page, request.PageSize
+	if page == 0 {
+		page = 1
+	}
+	if pageSize == 0 {
+		pageSize = defaultPivotPageSize
+	}
+	filter.Offset, filter.Limit = (page-1)*pageSize, pageSize
+
+	filter.UserIDs, err = s.reportUserIDs(request)
+	if err != nil {
+		s.logger.Debugf("GetPivotReport: failed to resolve users: %v", err)
+		return nil, err
+	}
+
+	totalRows, err := s.reportRepo.CountPivotRows(filter)
+	if err != nil {
+		s.logger.Debugf("GetPivotReport: failed to count rows: %v", err)
+		return nil, err
+	}
+
+	columns, err := s.reportRepo.GetPivotColumns(filter)
+	if err != nil {
+		s.logger.Debugf("GetPivotReport: failed to fetch columns: %v", err)
+		return nil, err
+	}
+
+	response := &dto.PivotReportResponse{
+		From:            request.From,
+		To:              request.To,
+		RowDimension:    filter.Rows,
+		ColumnDimension: filter.Columns,
+		Page:            page,
+		PageSize:        pageSize,
+		TotalRows:       totalRows,
+		Rows:            []dto.PivotRowResponse{},
+	}
+	response.Columns, response.TotalSeconds = newPivotColumnResponses(columns)
+	response.TotalDuration = formatDuration(response.TotalSeconds)
+
+	columnIndexes := make(map[pivotKey]int, len(columns))
+	for i, column := range columns {
+		columnIndexes[newPivotKey(column.GroupID, column.GroupKey)] = i
+	}
+
+	var current *dto.PivotRowResponse
+	err = s.reportRepo.GetPivotCells(filter, func(cell repositories.PivotCell) error {
+		if current == nil || current.Key != cell.RowKey || !sameID(current.ID, cell.RowID) {
+			response.Rows = append(response.Rows, dto.PivotRowResponse{
+				Key:   cell.RowKey,
+				ID:    cell.RowID,
+				Cells: make([]int64, len(columns)),
+			})
+			current = &response.Rows[len(response.Rows)-1]
+		}
+
+		index, ok := columnIndexes[newPivotKey(cell.ColumnID, cell.ColumnKey)]
+		if !ok {
+			return fmt.Errorf("pivot cell of unknown column %q", cell.ColumnKey)
+		}
+		current.Cells[index] += cell.Seconds
+		current.TotalSeconds += cell.Seconds
+		return nil
+	})
+	if err != nil {
+		s.logger.Debugf("GetPivotReport: failed to fetch cells: %v", err)
+		return nil, err
+	}
+
+	for i := range response.Rows {
+		response.Rows[i].TotalDuration = formatDuration(response.Rows[i].TotalSeconds)
+	}
+
+	s.logger.Infof("GetPivotReport: built %d of %d rows by %d columns", len(response.Rows), totalRows, len(columns))
+	return response, nil
+}
+
+// reportUserIDs combines the requested users with the members of the requested team.
+// It returns nil, selecting every user, only when neither was given.
+func (s *ReportServiceImpl) reportUserIDs(request dto.GetPivotReportRequest) ([]uint, error) {
+	userIDs, err := parseIDList(request.UserIDs)
+	if err != nil {
+		return nil, err
+	}
+	if request.TeamID == nil {
+		return userIDs, nil
+	}
+
+	team, err := s.teamRepo.GetById(*request.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs = append([]uint{}, userIDs...)
+	for _, member := range team.Members {
+		userIDs = append(userIDs, member.ID)
+	}
+
+	return uniqueIDs(userIDs), nil
+}
+
+// pivotKey identifies a pivot row or column; the key alone is not unique, as two
+// projects of different clients may share a name.
+type pivotKey struct {
+	id  uint
+	key string
+}
+
+func newPivotKey(id *uint, key string) pivotKey {
+	if id == nil {
+		return pivotKey{key: key}
+	}
+
+	return pivotKey{id: *id, key: key}
+}
+
+func sameID(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+// newSummaryGroupResponses sums the tasks of every group, rounding each task by its
+// own policy. Rows of a group are adjacent, as the repository orders them by group.
+func newSummaryGroupResponses(rows []repositories.SummaryTaskRow, rounding *roundingPolicies) []dto.SummaryGroupResponse {
+	groups := []dto.SummaryGroupResponse{}
+	var current *dto.SummaryGroupResponse
+	for _, row := range rows {
+		if current == nil || current.Key != row.GroupKey || !sameID(current.ID, row.GroupID) {
+			groups = append(groups, dto.SummaryGroupResponse{Key: row.GroupKey, ID: row.GroupID})
+			current = &groups[len(groups)-1]
+		}
+		current.TaskCount++
+		current.Seconds += row.Seconds
+		current.RoundedSeconds += roundSeconds(row.Seconds, rounding.forProject(row.ProjectID, row.ClientID))
+	}
+
+	for i := range groups {
+		groups[i].Duration = formatDuration(groups[i].Seconds)
+		groups[i].RoundedDuration = formatDuration(groups[i].RoundedSeconds)
+	}
+
+	return groups
+}
+
+func newPivotColumnResponses(rows []repositories.SummaryRow) ([]dto.PivotColumnResponse, int64) {
+	var totalSeconds int64
+	columns := make([]dto.PivotColumnResponse, len(rows))
+	for i, row := range rows {
+		columns[i] = dto.PivotColumnResponse{
+			Key:       row.GroupKey,
+			ID:        row.GroupID,
+			TaskCount: row.TaskCount,
+			Seconds:   row.Seconds,
+			Duration:  formatDuration(row.Seconds),
+		}
+		totalSeconds += row.Seconds
+	}
+
+	return columns, totalSeconds
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+)
+
+// roundingPolicies resolves the rounding policy of a task: the policy of its project
+// wins over the one of its client, which wins over the global one.
+type roundingPolicies struct {
+	global    *models.RoundingPolicy
+	byProject map[uint]*models.RoundingPolicy
+	byClient  map[uint]*models.RoundingPolicy
+	// projectClients maps projects to their clients. It is only loaded when
+	// some policy is scoped to a client.
+	projectClients map[uint]*uint
+}
+
+func newRoundingPolicies(policies []models.RoundingPolicy) *roundingPolicies {
+	resolved := &roundingPolicies{
+		byProject: make(map[uint]*models.RoundingPolicy),
+		byClient:  make(map[uint]*models.RoundingPolicy),
+	}
+	for i := range policies {
+		policy := &policies[i]
+		switch {
+		case policy.ProjectID != nil:
+			resolved.byProject[*policy.ProjectID] = policy
+		case policy.ClientID != nil:
+			resolved.byClient[*policy.ClientID] = policy
+		default:
+			resolved.global = policy
+		}
+	}
+
+	return resolved
+}
+
+// loadRoundingPolicies fetches the rounding policies together with the clients of
+// the projects, when needed, so that forTask can resolve the policy of any task.
+func loadRoundingPolicies(roundingRepo repositories.RoundingPolicyRepository, projectRepo repositories.ProjectRepository) (*roundingPolicies, error) {
+	policies, err := roundingRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := newRoundingPolicies(policies)
+	if len(resolved.byClient) == 0 {
+		return resolved, nil
+	}
+
+	projects, err := projectRepo.GetAll(nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved.projectClients = make(map[uint]*uint, len(projects))
+	for _, project := range projects {
+		resolved.projectClients[project.ID] = project.ClientID
+	}
+
+	return resolved, nil
+}
+
+// forProject returns the policy for tasks of the project of the client, either of which may be nil.
+func (p *roundingPolicies) forProject(projectID, clientID *uint) *models.RoundingPolicy {
+	if projectID != nil {
+		if policy, ok := p.byProject[*projectID]; ok {
+			return policy
+		}
+	}
+	if clientID != nil {
+		if policy, ok := p.byClient[*clientID]; ok {
+			return policy
+		}
+	}
+
+	return p.global
+}
+
+func (p *roundingPolicies) forTask(task *models.Task) *models.RoundingPolicy {
+	var clientID *uint
+	if task.ProjectID != nil {
+		clientID = p.projectClients[*task.ProjectID]
+	}
+
+	return p.forProject(task.ProjectID, clientID)
+}
+
+// roundSeconds rounds seconds to a multiple of the increment of the policy; halves round up
+// when rounding to the nearest increment. Without a policy the seconds are kept as they are.
+func roundSeconds(seconds int64, policy *models.RoundingPolicy) int64 {
+	if policy == nil || policy.IncrementMinutes <= 0 {
+		return seconds
+	}
+
+	increment := int64(policy.IncrementMinutes) * 60
+	switch policy.Mode {
+	case models.RoundingModeUp:
+		return (seconds + increment - 1) / increment * increment
+	case models.RoundingModeDown:
+		return seconds / increment * increment
+	default:
+		return (seconds + increment/2) / increment * increment
+	}
+}
+
+func roundMinutes(minutes int, policy *models.RoundingPolicy) int {
+	return int(roundSeconds(int64(minutes)*60, policy) / 60)
+}
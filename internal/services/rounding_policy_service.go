@@ -0,0 +1,9 @@
+package services
+
+import "github.com/Dor1ma/Time-Tracker/internal/dto"
+
+type RoundingPolicyService interface {
+	SetRoundingPolicy(request dto.SetRoundingPolicyRequest) (*dto.RoundingPolicyResponse, error)
+	GetRoundingPolicies() ([]dto.RoundingPolicyResponse, error)
+	DeleteRoundingPolicy(id uint) error
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/sirupsen/logrus"
+)
+
+type RoundingPolicyServiceImpl struct {
+	roundingRepo repositories.RoundingPolicyRepository
+	logger       *logrus.Logger
+}
+
+func NewRoundingPolicyServiceImpl(roundingRepo repositories.RoundingPolicyRepository, logger *logrus.Logger) *RoundingPolicyServiceImpl {
+	return &RoundingPolicyServiceImpl{
+		roundingRepo: roundingRepo,
+		logger:       logger,
+	}
+}
+
+func (s *RoundingPolicyServiceImpl) SetRoundingPolicy(request dto.SetRoundingPolicyRequest) (*dto.RoundingPolicyResponse, error) {
+	s.logger.Infof("SetRoundingPolicy: setting rounding policy to %d minutes %s", request.IncrementMinutes, request.Mode)
+	if request.ProjectID != nil && request.ClientID != nil {
+		return nil, models.ErrInvalidRoundingScope
+	}
+
+	policy := &models.RoundingPolicy{
+		ProjectID:        request.ProjectID,
+		ClientID:         request.ClientID,
+		IncrementMinutes: request.IncrementMinutes,
+		Mode:             request.Mode,
+	}
+
+	if err := s.roundingRepo.Set(policy); err != nil {
+		s.logger.Debugf("SetRoundingPolicy: failed to set rounding policy in database: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("SetRoundingPolicy: rounding policy set with ID: %d", policy.ID)
+	return newRoundingPolicyResponse(policy), nil
+}
+
+func (s *RoundingPolicyServiceImpl) GetRoundingPolicies() ([]dto.RoundingPolicyResponse, error) {
+	s.logger.Info("GetRoundingPolicies: fetching rounding policies")
+	policies, err := s.roundingRepo.GetAll()
+	if err != nil {
+		s.logger.Debugf("GetRoundingPolicies: failed to fetch rounding policies: %v", err)
+		return nil, err
+	}
+
+	policyResponses := make([]dto.RoundingPolicyResponse, len(policies))
+	for i := range policies {
+		policyResponses[i] = *newRoundingPolicyResponse(&policies[i])
+	}
+
+	return policyResponses, nil
+}
+
+func (s *RoundingPolicyServiceImpl) DeleteRoundingPolicy(id uint) error {
+	s.logger.Infof("DeleteRoundingPolicy: deleting rounding policy with ID: %d", id)
+	if err := s.roundingRepo.Delete(id); err != nil {
+		s.logger.Debugf("DeleteRoundingPolicy: failed to delete rounding policy: %v", err)
+		return err
+	}
+
+	s.logger.Infof("DeleteRoundingPolicy: rounding policy deleted with ID: %d", id)
+	return nil
+}
+
+func newRoundingPolicyResponse(policy *models.RoundingPolicy) *dto.RoundingPolicyResponse {
+	return &dto.RoundingPolicyResponse{
+		ID:               policy.ID,
+		ProjectID:        policy.ProjectID,
+		ClientID:         policy.ClientID,
+		IncrementMinutes: policy.IncrementMinutes,
+		Mode:             policy.Mode,
+	}
+}
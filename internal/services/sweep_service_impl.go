@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/sirupsen/logrus"
+)
+
+const workdayEndLayout = "15:04"
+
+type SweepServiceImpl struct {
+	taskRepo           repositories.TaskRepository
+	userRepo           repositories.UserRepository
+	maxRunningDuration time.Duration
+	workdayEndGrace    time.Duration
+	logger             *logrus.Logger
+}
+
+func NewSweepServiceImpl(taskRepo repositories.TaskRepository, userRepo repositories.UserRepository,
+	maxRunningDuration, workdayEndGrace time.Duration, logger *logrus.Logger) *SweepServiceImpl {
+	return &SweepServiceImpl{
+		taskRepo:           taskRepo,
+		userRepo:           userRepo,
+		maxRunningDuration: maxRunningDuration,
+		workdayEndGrace:    workdayEndGrace,
+		logger:             logger,
+	}
+}
+
+// StopForgottenTasks stops the tasks that have been running for longer than the limit,
+// or past the end of the workday of their user, as of now. The tasks are stopped at the
+// moment the limit was reached, not at now, and are marked as auto-stopped for review.
+func (s *SweepServiceImpl) StopForgottenTasks(ctx context.Context, now time.Time) ([]dto.TaskResponse, error) {
+	s.logger.Infof("StopForgottenTasks: looking for tasks left running")
+	tasks, err := s.taskRepo.GetRunningTasks()
+	if err != nil {
+		s.logger.Debugf("StopForgottenTasks: failed to fetch running tasks: %v", err)
+		return nil, err
+	}
+
+	users := make(map[uint]*models.User)
+	stopped := make([]dto.TaskResponse, 0)
+	for _, task := range tasks {
+		if len(task.Segments) == 0 {
+			continue
+		}
+
+		user, ok := users[task.UserID]
+		if !ok {
+			user = s.user(task.UserID)
+			users[task.UserID] = user
+		}
+
+		stopAt, reason, due := s.autoStopTime(task.Segments[0].StartTime, user, now)
+		if !due {
+			continue
+		}
+
+		stoppedTask, err := s.taskRepo.AutoStopTask(ctx, task.ID, stopAt, reason)
+		if errors.Is(err, models.ErrTaskNotRunning) {
+			continue
+		}
+		if err != nil {
+			s.logger.Debugf("StopForgottenTasks: failed to stop task with ID %d: %v", task.ID, err)
+			continue
+		}
+
+		response := newTaskResponse(stoppedTask, userLocation(user))
+		stopped = append(stopped, *response)
+	}
+
+	s.logger.Infof("StopForgottenTasks: stopped %d of %d running tasks", len(stopped), len(tasks))
+	return stopped, nil
+}
+
+// user returns the user with the given ID. A user that cannot be fetched is treated
+// as having no end of the workday, so that only the running limit applies.
+func (s *SweepServiceImpl) user(userID uint) *models.User {
+	user, err := s.userRepo.GetById(userID)
+	if err != nil {
+		s.logger.Debugf("StopForgottenTasks: failed to fetch user with ID %d: %v", userID, err)
+		return &models.User{ID: userID}
+	}
+
+	return user
+}
+
+// autoStopTime returns when a timer running since start should have been stopped and why,
+// and whether that is due as of now. The end of the workday counts only once the grace
+// period after it has passed, so that a little overtime is not cut off.
+func (s *SweepServiceImpl) autoStopTime(start time.Time, user *models.User, now time.Time) (time.Time, string, bool) {
+	var stopAt, dueAt time.Time
+	var reason string
+	if s.maxRunningDuration > 0 {
+		stopAt = start.Add(s.maxRunningDuration)
+		dueAt = stopAt
+		reason = fmt.Sprintf("timer ran for more than %s", formatDuration(int64(s.maxRunningDuration.Seconds())))
+	}
+
+	if end, ok := nextWorkdayEnd(start, user.WorkdayEnd, userLocation(user)); ok && (stopAt.IsZero() || end.Before(stopAt)) {
+		stopAt = end
+		dueAt = end.Add(s.workdayEndGrace)
+		reason = fmt.Sprintf("timer ran past the end of the workday at %s", *user.WorkdayEnd)
+	}
+
+	return stopAt, reason, !stopAt.IsZero() && !now.Before(dueAt)
+}
+
+// nextWorkdayEnd returns the first end of the workday, in loc, after start.
+func nextWorkdayEnd(start time.Time, workdayEnd *string, loc *time.Location) (time.Time, bool) {
+	if workdayEnd == nil {
+		return time.Time{}, false
+	}
+
+	clock, err := time.Parse(workdayEndLayout, *workdayEnd)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	start = start.In(loc)
+	end := time.Date(start.Year(), start.Month(), start.Day(), clock.Hour(), clock.Minute(), 0, 0, loc)
+	if !end.After(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+
+	return end, true
+}
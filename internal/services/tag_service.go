@@ -0,0 +1,11 @@
+package services
+
+import "github.com/Dor1ma/Time-Tracker/internal/dto"
+
+type TagService interface {
+	CreateTag(request dto.CreateTagRequest) (*dto.TagResponse, error)
+	GetAllTags() ([]dto.TagResponse, error)
+	RenameTag(id uint, request dto.UpdateTagRequest) (*dto.TagResponse, error)
+	DeleteTag(id uint) error
+	MergeTags(sourceID uint, request dto.MergeTagsRequest) (*dto.TagResponse, error)
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type TagServiceImpl struct {
+	tagRepo repositories.TagRepository
+	logger  *logrus.Logger
+}
+
+func NewTagServiceImpl(tagRepo repositories.TagRepository, logger *logrus.Logger) *TagServiceImpl {
+	return &TagServiceImpl{
+		tagRepo: tagRepo,
+		logger:  logger,
+	}
+}
+
+func (s *TagServiceImpl) CreateTag(request dto.CreateTagRequest) (*dto.TagResponse, error) {
+	name := normalizeTagName(request.Name)
+	s.logger.Infof("CreateTag: creating tag with name: %s", name)
+	if err := s.checkNameFree(name, 0); err != nil {
+		s.logger.Debugf("CreateTag: tag name %s is not available: %v", name, err)
+		return nil, err
+	}
+
+	tag := &models.Tag{Name: name}
+	if err := s.tagRepo.Create(tag); err != nil {
+		s.logger.Debugf("CreateTag: failed to create tag in database: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("CreateTag: tag created with ID: %d", tag.ID)
+	return &dto.TagResponse{
+		ID:   tag.ID,
+		Name: tag.Name,
+	}, nil
+}
+
+func (s *TagServiceImpl) GetAllTags() ([]dto.TagResponse, error) {
+	s.logger.Info("GetAllTags: fetching all tags")
+	tags, err := s.tagRepo.GetAll()
+	if err != nil {
+		s.logger.Debugf("GetAllTags: failed to fetch tags: %v", err)
+		return nil, err
+	}
+
+	tagResponses := make([]dto.TagResponse, len(tags))
+	for i, tag := range tags {
+		tagResponses[i] = dto.TagResponse{
+			ID:   tag.ID,
+			Name: tag.Name,
+		}
+	}
+
+	return tagResponses, nil
+}
+
+func (s *TagServiceImpl) RenameTag(id uint, request dto.UpdateTagRequest) (*dto.TagResponse, error) {
+	name := normalizeTagName(request.Name)
+	s.logger.Infof("RenameTag: renaming tag with ID %d to %s", id, name)
+	tag, err := s.tagRepo.GetById(id)
+	if err != nil {
+		s.logger.Debugf("RenameTag: failed to get tag: %v", err)
+		return nil, err
+	}
+
+	if err := s.checkNameFree(name, tag.ID); err != nil {
+		s.logger.Debugf("RenameTag: tag name %s is not available: %v", name, err)
+		return nil, err
+	}
+
+	tag.Name = name
+	if err := s.tagRepo.Update(tag); err != nil {
+		s.logger.Errorf("RenameTag: failed to rename tag: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("RenameTag: tag renamed with ID: %d", tag.ID)
+	return &dto.TagResponse{
+		ID:   tag.ID,
+		Name: tag.Name,
+	}, nil
+}
+
+func (s *TagServiceImpl) DeleteTag(id uint) error {
+	s.logger.Infof("DeleteTag: deleting tag with ID: %d", id)
+	if err := s.tagRepo.Delete(id); err != nil {
+		s.logger.Debugf("DeleteTag: failed to delete tag: %v", err)
+		return err
+	}
+
+	s.logger.Infof("DeleteTag: tag deleted with ID: %d", id)
+	return nil
+}
+
+func (s *TagServiceImpl) MergeTags(sourceID uint, request dto.MergeTagsRequest) (*dto.TagResponse, error) {
+	s.logger.Infof("MergeTags: merging tag with ID %d into tag with ID %d", sourceID, request.TargetID)
+	if sourceID == request.TargetID {
+		return nil, models.ErrInvalidTagMerge
+	}
+
+	if err := s.tagRepo.Merge(sourceID, request.TargetID); err != nil {
+		s.logger.Debugf("MergeTags: failed to merge tags: %v", err)
+		return nil, err
+	}
+
+	target, err := s.tagRepo.GetById(request.TargetID)
+	if err != nil {
+		s.logger.Debugf("MergeTags: failed to get merged tag: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("MergeTags: tag with ID %d merged into tag with ID %d", sourceID, target.ID)
+	return &dto.TagResponse{
+		ID:   target.ID,
+		Name: target.Name,
+	}, nil
+}
+
+// checkNameFree reports ErrTagExists when another tag than exceptID already uses the name.
+// Merging is the way to combine two tags.
+func (s *TagServiceImpl) checkNameFree(name string, exceptID uint) error {
+	if name == "" {
+		return models.ErrEmptyTagName
+	}
+
+	existing, err := s.tagRepo.GetByName(name)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.ID != exceptID {
+		return models.ErrTagExists
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+)
+
+// normalizeTagName makes tag names case-insensitive and free of surrounding spaces.
+func normalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// parseTagNames splits comma separated values and drops empty and duplicate names.
+func parseTagNames(values []string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, value := range values {
+		for _, name := range strings.Split(value, ",") {
+			name = normalizeTagName(name)
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// newTags turns tag names into tag models. It never returns nil, so that an
+// empty list can be told apart from an absent one.
+func newTags(values []string) []models.Tag {
+	names := parseTagNames(values)
+	tags := make([]models.Tag, len(names))
+	for i, name := range names {
+		tags[i] = models.Tag{Name: name}
+	}
+
+	return tags
+}
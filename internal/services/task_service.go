@@ -1,9 +1,27 @@
 package services
 
-import "github.com/Dor1ma/Time-Tracker/internal/dto"
+import (
+	"context"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/export"
+)
+
+// Policies applied when a user starts or resumes a task while another one is running.
+const (
+	RunningTaskPolicyReject = "reject"
+	RunningTaskPolicySwitch = "switch"
+)
 
 type TaskService interface {
-	StartTask(request dto.StartTaskRequest) (*dto.TaskResponse, error)
-	StopTask(request dto.StopTaskRequest) (*dto.TaskResponse, error)
-	GetUserTasks(userID uint, startDate string, endDate string) ([]dto.TaskResponse, error)
+	StartTask(ctx context.Context, request dto.StartTaskRequest) (*dto.TaskResponse, error)
+	PauseTask(ctx context.Context, taskID uint) (*dto.TaskResponse, error)
+	ResumeTask(ctx context.Context, taskID uint) (*dto.TaskResponse, error)
+	StopTask(ctx context.Context, request dto.StopTaskRequest) (*dto.TaskResponse, error)
+	CreateTask(ctx context.Context, request dto.CreateTaskRequest) (*dto.TaskResponse, error)
+	ConfirmTask(ctx context.Context, taskID uint) (*dto.TaskResponse, error)
+	UpdateTask(ctx context.Context, taskID uint, request dto.UpdateTaskRequest) (*dto.TaskResponse, error)
+	GetActiveTask(userID uint) (*dto.TaskResponse, error)
+	GetUserTasks(userID uint, request dto.GetUserTasksRequest) ([]dto.TaskResponse, error)
+	ExportUserTasks(userID uint, request dto.ExportUserTasksRequest, writer export.Writer) error
 }
@@ -1,92 +1,423 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/export"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
 	"github.com/Dor1ma/Time-Tracker/internal/repositories"
 	"github.com/sirupsen/logrus"
 	"time"
 )
 
 type TaskServiceImpl struct {
-	taskRepo repositories.TaskRepository
-	logger   *logrus.Logger
+	taskRepo          repositories.TaskRepository
+	userRepo          repositories.UserRepository
+	roundingRepo      repositories.RoundingPolicyRepository
+	projectRepo       repositories.ProjectRepository
+	runningTaskPolicy string
+	logger            *logrus.Logger
 }
 
-func NewTaskServiceImpl(taskRepo repositories.TaskRepository, logger *logrus.Logger) *TaskServiceImpl {
+func NewTaskServiceImpl(taskRepo repositories.TaskRepository, userRepo repositories.UserRepository,
+	roundingRepo repositories.RoundingPolicyRepository, projectRepo repositories.ProjectRepository,
+	runningTaskPolicy string, logger *logrus.Logger) *TaskServiceImpl {
 	return &TaskServiceImpl{
-		taskRepo: taskRepo,
-		logger:   logger,
+		taskRepo:          taskRepo,
+		userRepo:          userRepo,
+		roundingRepo:      roundingRepo,
+		projectRepo:       projectRepo,
+		runningTaskPolicy: runningTaskPolicy,
+		logger:            logger,
 	}
 }
 
-func (s *TaskServiceImpl) StartTask(request dto.StartTaskRequest) (*dto.TaskResponse, error) {
+func (s *TaskServiceImpl) StartTask(ctx context.Context, request dto.StartTaskRequest) (*dto.TaskResponse, error) {
 	s.logger.Infof("StartTask: starting task for user ID: %d, task name: %s", request.UserID, request.TaskName)
-	task, err := s.taskRepo.StartTask(request.UserID, request.TaskName)
-	if err != nil {
+	task := &models.Task{
+		UserID:    request.UserID,
+		TaskName:  request.TaskName,
+		ProjectID: request.ProjectID,
+		Tags:      newTags(request.Tags),
+		Billable:  request.Billable == nil || *request.Billable,
+	}
+	if session := request.FocusSession; session != nil {
+		task.FocusSession = &models.FocusSession{
+			WorkMinutes:  session.WorkMinutes,
+			BreakMinutes: session.BreakMinutes,
+			Cycles:       session.Cycles,
+		}
+	}
+
+	if err := s.taskRepo.StartTask(ctx, task, s.switchRunningTask()); err != nil {
 		s.logger.Debugf("StartTask: failed to start task: %v", err)
-		return nil, err
+		return nil, s.wrapTaskConflict(err)
 	}
 
 	s.logger.Infof("StartTask: task started with ID: %d", task.ID)
-	return &dto.TaskResponse{
-		ID:        task.ID,
-		UserID:    task.UserID,
-		TaskName:  task.TaskName,
-		StartTime: task.StartTime.Format(time.RFC3339),
-	}, nil
+	response := newTaskResponse(task, s.location(task.UserID))
+	return response, nil
+}
+
+func (s *TaskServiceImpl) PauseTask(ctx context.Context, taskID uint) (*dto.TaskResponse, error) {
+	s.logger.Infof("PauseTask: pausing task with ID: %d", taskID)
+	task, err := s.taskRepo.PauseTask(ctx, taskID)
+	if err != nil {
+		s.logger.Debugf("PauseTask: failed to pause task: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("PauseTask: task paused with ID: %d", task.ID)
+	response := newTaskResponse(task, s.location(task.UserID))
+	return response, nil
+}
+
+func (s *TaskServiceImpl) ResumeTask(ctx context.Context, taskID uint) (*dto.TaskResponse, error) {
+	s.logger.Infof("ResumeTask: resuming task with ID: %d", taskID)
+	task, err := s.taskRepo.ResumeTask(ctx, taskID, s.switchRunningTask())
+	if err != nil {
+		s.logger.Debugf("ResumeTask: failed to resume task: %v", err)
+		return nil, s.wrapTaskConflict(err)
+	}
+
+	s.logger.Infof("ResumeTask: task resumed with ID: %d", task.ID)
+	response := newTaskResponse(task, s.location(task.UserID))
+	return response, nil
 }
 
-func (s *TaskServiceImpl) StopTask(request dto.StopTaskRequest) (*dto.TaskResponse, error) {
+func (s *TaskServiceImpl) StopTask(ctx context.Context, request dto.StopTaskRequest) (*dto.TaskResponse, error) {
 	s.logger.Infof("StopTask: stopping task with ID: %d", request.TaskID)
-	task, err := s.taskRepo.StopTask(request.TaskID)
+	task, err := s.taskRepo.StopTask(ctx, request.TaskID)
 	if err != nil {
-		s.logger.Debug("StopTask: failed to stop task: %v", err)
+		s.logger.Debugf("StopTask: failed to stop task: %v", err)
 		return nil, err
 	}
 
 	s.logger.Infof("StopTask: task stopped with ID: %d", task.ID)
-	return &dto.TaskResponse{
-		ID:        task.ID,
-		UserID:    task.UserID,
-		TaskName:  task.TaskName,
-		StartTime: task.StartTime.Format(time.RFC3339),
-		EndTime:   task.EndTime.Format(time.RFC3339),
-	}, nil
+	response := newTaskResponse(task, s.location(task.UserID))
+	return response, nil
+}
+
+func (s *TaskServiceImpl) CreateTask(ctx context.Context, request dto.CreateTaskRequest) (*dto.TaskResponse, error) {
+	s.logger.Infof("CreateTask: creating manual task for user ID: %d, task name: %s", request.UserID, request.TaskName)
+	endTime, err := resolveEndTime(request.StartTime, request.EndTime, request.DurationMinutes)
+	if err != nil {
+		s.logger.Debugf("CreateTask: invalid task interval: %v", err)
+		return nil, err
+	}
+
+	task := &models.Task{
+		UserID:    request.UserID,
+		TaskName:  request.TaskName,
+		ProjectID: request.ProjectID,
+		Tags:      newTags(request.Tags),
+		Billable:  request.Billable == nil || *request.Billable,
+		StartTime: request.StartTime,
+		EndTime:   endTime,
+	}
+
+	if err := s.taskRepo.CreateTask(ctx, task); err != nil {
+		s.logger.Debugf("CreateTask: failed to create task: %v", err)
+		return nil, s.wrapTaskConflict(err)
+	}
+
+	s.logger.Infof("CreateTask: task created with ID: %d", task.ID)
+	return newTaskResponse(task, s.location(task.UserID)), nil
+}
+
+func (s *TaskServiceImpl) ConfirmTask(ctx context.Context, taskID uint) (*dto.TaskResponse, error) {
+	s.logger.Infof("ConfirmTask: confirming planned task with ID: %d", taskID)
+	task, err := s.taskRepo.ConfirmPlannedTask(ctx, taskID)
+	if err != nil {
+		s.logger.Debugf("ConfirmTask: failed to confirm task: %v", err)
+		return nil, s.wrapTaskConflict(err)
+	}
+
+	s.logger.Infof("ConfirmTask: task confirmed with ID: %d", task.ID)
+	return newTaskResponse(task, s.location(task.UserID)), nil
+}
+
+func (s *TaskServiceImpl) UpdateTask(ctx context.Context, taskID uint, request dto.UpdateTaskRequest) (*dto.TaskResponse, error) {
+	s.logger.Infof("UpdateTask: updating task with ID: %d", taskID)
+	task, err := s.taskRepo.GetTask(taskID)
+	if err != nil {
+		s.logger.Debugf("UpdateTask: failed to get task: %v", err)
+		return nil, err
+	}
+
+	if request.TaskName != nil {
+		task.TaskName = *request.TaskName
+	}
+	if request.ProjectID != nil {
+		task.ProjectID = request.ProjectID
+	}
+	if request.Tags != nil {
+		task.Tags = newTags(request.Tags)
+	}
+	if request.Billable != nil {
+		task.Billable = *request.Billable
+	}
+
+	intervalChanged := request.StartTime != nil || request.EndTime != nil || request.DurationMinutes != nil
+	if intervalChanged {
+		if task.Status != models.TaskStatusStopped {
+			s.logger.Debugf("UpdateTask: task with ID %d is %s", task.ID, task.Status)
+			return nil, models.ErrTaskNotStopped
+		}
+
+		if request.StartTime != nil {
+			task.StartTime = *request.StartTime
+		}
+
+		switch {
+		case request.EndTime != nil && request.DurationMinutes != nil:
+			return nil, models.ErrTaskEndRequired
+		case request.DurationMinutes != nil:
+			task.EndTime = task.StartTime.Add(time.Duration(*request.DurationMinutes) * time.Minute)
+		case request.EndTime != nil:
+			task.EndTime = *request.EndTime
+		}
+
+		if !task.EndTime.After(task.StartTime) {
+			s.logger.Debugf("UpdateTask: invalid task interval for task with ID %d", task.ID)
+			return nil, models.ErrInvalidTaskInterval
+		}
+	}
+
+	if err := s.taskRepo.UpdateTask(ctx, task, intervalChanged); err != nil {
+		s.logger.Debugf("UpdateTask: failed to update task: %v", err)
+		return nil, s.wrapTaskConflict(err)
+	}
+
+	s.logger.Infof("UpdateTask: task updated with ID: %d", task.ID)
+	return newTaskResponse(task, s.location(task.UserID)), nil
 }
 
-func (s *TaskServiceImpl) GetUserTasks(userID uint, startDate string, endDate string) ([]dto.TaskResponse, error) {
-	s.logger.Infof("GetUserTasks: fetching tasks for user ID: %d, start date: %s, end date: %s", userID, startDate, endDate)
-	start, err := time.Parse("2006-01-02", startDate)
+func (s *TaskServiceImpl) GetActiveTask(userID uint) (*dto.TaskResponse, error) {
+	s.logger.Infof("GetActiveTask: fetching running task for user ID: %d", userID)
+	task, err := s.taskRepo.GetRunningTask(userID)
 	if err != nil {
-		s.logger.Debugf("GetUserTasks: invalid start date: %v", err)
+		s.logger.Debugf("GetActiveTask: failed to fetch running task: %v", err)
 		return nil, err
 	}
 
-	end, err := time.Parse("2006-01-02", endDate)
+	s.logger.Infof("GetActiveTask: found running task with ID: %d", task.ID)
+	return newTaskResponse(task, s.location(task.UserID)), nil
+}
+
+func (s *TaskServiceImpl) GetUserTasks(userID uint, request dto.GetUserTasksRequest) ([]dto.TaskResponse, error) {
+	s.logger.Infof("GetUserTasks: fetching tasks for user ID: %d, start date: %s, end date: %s", userID, request.StartDate, request.EndDate)
+	loc := s.location(userID)
+	filter, err := newUserTasksFilter(userID, request, loc)
 	if err != nil {
-		s.logger.Debugf("GetUserTasks: invalid end date: %v", err)
+		s.logger.Debugf("GetUserTasks: invalid request: %v", err)
 		return nil, err
 	}
 
-	tasks, err := s.taskRepo.GetUserTasks(userID, start, end)
+	tasks, err := s.taskRepo.GetUserTasks(filter)
 	if err != nil {
 		s.logger.Debugf("GetUserTasks: failed to fetch tasks: %v", err)
 		return nil, err
 	}
 
+	rounding, err := loadRoundingPolicies(s.roundingRepo, s.projectRepo)
+	if err != nil {
+		s.logger.Debugf("GetUserTasks: failed to load rounding policies: %v", err)
+		return nil, err
+	}
+
 	taskResponses := make([]dto.TaskResponse, len(tasks))
-	for i, task := range tasks {
-		taskResponses[i] = dto.TaskResponse{
-			ID:        task.ID,
-			UserID:    task.UserID,
-			TaskName:  task.TaskName,
-			Hours:     task.Hours,
-			Minutes:   task.Minutes,
-			StartTime: task.StartTime.Format(time.RFC3339),
-			EndTime:   task.EndTime.Format(time.RFC3339),
+	for i := range tasks {
+		taskResponses[i] = *newTaskResponse(&tasks[i], loc)
+		if policy := rounding.forTask(&tasks[i]); policy != nil {
+			roundedMinutes := roundMinutes(tasks[i].Minutes, policy)
+			taskResponses[i].RoundedMinutes = &roundedMinutes
+			taskResponses[i].RoundingPolicyID = &policy.ID
+		} else {
+			taskResponses[i].RoundedMinutes = &tasks[i].Minutes
 		}
 	}
 
 	s.logger.Infof("GetUserTasks: fetched %d tasks for user ID: %d", len(tasks), userID)
 	return taskResponses, nil
 }
+
+func (s *TaskServiceImpl) ExportUserTasks(userID uint, request dto.ExportUserTasksRequest, writer export.Writer) error {
+	s.logger.Infof("ExportUserTasks: exporting tasks for user ID: %d, start date: %s, end date: %s", userID, request.StartDate, request.EndDate)
+	names, values, err := exportColumns(request.Columns, taskExportColumns, defaultTaskExportColumns)
+	if err != nil {
+		s.logger.Debugf("ExportUserTasks: invalid columns: %v", err)
+		return err
+	}
+
+	loc := s.location(userID)
+	filter, err := newUserTasksFilter(userID, request.GetUserTasksRequest, loc)
+	if err != nil {
+		s.logger.Debugf("ExportUserTasks: invalid request: %v", err)
+		return err
+	}
+
+	rounding, err := loadRoundingPolicies(s.roundingRepo, s.projectRepo)
+	if err != nil {
+		s.logger.Debugf("ExportUserTasks: failed to load rounding policies: %v", err)
+		return err
+	}
+
+	if err := writeExportHeader(writer, names); err != nil {
+		return err
+	}
+
+	var count int
+	row := make([]any, len(values))
+	err = s.taskRepo.GetUserTasksInBatches(filter, exportBatchSize, func(tasks []models.Task) error {
+		for i := range tasks {
+			tasks[i].StartTime = tasks[i].StartTime.In(loc)
+			tasks[i].EndTime = tasks[i].EndTime.In(loc)
+			task := &exportedTask{Task: &tasks[i], RoundedMinutes: roundMinutes(tasks[i].Minutes, rounding.forTask(&tasks[i]))}
+			if err := writeExportRow(writer, values, task, row); err != nil {
+				return err
+			}
+		}
+		count += len(tasks)
+		return nil
+	})
+	if err != nil {
+		s.logger.Debugf("ExportUserTasks: failed to export tasks: %v", err)
+		return err
+	}
+
+	s.logger.Infof("ExportUserTasks: exported %d tasks for user ID: %d", count, userID)
+	return nil
+}
+
+func (s *TaskServiceImpl) switchRunningTask() bool {
+	return s.runningTaskPolicy == RunningTaskPolicySwitch
+}
+
+// location returns the time zone of the user, which task times are shown in.
+// It falls back to UTC if the user cannot be fetched.
+func (s *TaskServiceImpl) location(userID uint) *time.Location {
+	user, err := s.userRepo.GetById(userID)
+	if err != nil {
+		s.logger.Debugf("location: failed to get user with ID %d, using UTC: %v", userID, err)
+		return time.UTC
+	}
+
+	return userLocation(user)
+}
+
+// wrapTaskConflict turns a conflict reported by the repository into one carrying task responses.
+func (s *TaskServiceImpl) wrapTaskConflict(err error) error {
+	var conflict *models.TaskConflictError
+	if !errors.As(err, &conflict) || len(conflict.Tasks) == 0 {
+		return err
+	}
+
+	loc := s.location(conflict.Tasks[0].UserID)
+	tasks := make([]dto.TaskResponse, len(conflict.Tasks))
+	for i := range conflict.Tasks {
+		tasks[i] = *newTaskResponse(&conflict.Tasks[i], loc)
+	}
+
+	return &TaskConflictError{Err: conflict.Err, Tasks: tasks}
+}
+
+// newUserTasksFilter turns the query of GetUserTasks into a repository filter,
+// reading the dates as days in loc.
+func newUserTasksFilter(userID uint, request dto.GetUserTasksRequest, loc *time.Location) (repositories.TaskFilter, error) {
+	start, err := parseDateIn(request.StartDate, loc)
+	if err != nil {
+		return repositories.TaskFilter{}, err
+	}
+
+	end, err := parseDateIn(request.EndDate, loc)
+	if err != nil {
+		return repositories.TaskFilter{}, err
+	}
+
+	return repositories.TaskFilter{
+		UserID:       userID,
+		StartDate:    start,
+		EndDate:      end,
+		ProjectID:    request.ProjectID,
+		ClientID:     request.ClientID,
+		Tags:         parseTagNames(request.Tags),
+		MatchAllTags: request.TagsMatch == "all",
+		AutoStopped:  request.AutoStopped,
+		WithSegments: request.Segments,
+	}, nil
+}
+
+// resolveEndTime returns the end of a manual entry given either explicitly or as a duration.
+func resolveEndTime(startTime time.Time, endTime *time.Time, durationMinutes int) (time.Time, error) {
+	if (endTime == nil) == (durationMinutes == 0) {
+		return time.Time{}, models.ErrTaskEndRequired
+	}
+
+	end := startTime.Add(time.Duration(durationMinutes) * time.Minute)
+	if endTime != nil {
+		end = *endTime
+	}
+
+	if !end.After(startTime) {
+		return time.Time{}, models.ErrInvalidTaskInterval
+	}
+
+	return end, nil
+}
+
+// newTaskResponse maps a task to its response, with times in loc.
+func newTaskResponse(task *models.Task, loc *time.Location) *dto.TaskResponse {
+	response := &dto.TaskResponse{
+		ID:        task.ID,
+		UserID:    task.UserID,
+		TaskName:  task.TaskName,
+		ProjectID: task.ProjectID,
+		Billable:  task.Billable,
+		InvoiceID: task.InvoiceID,
+		Status:    task.Status,
+		Hours:     task.Hours,
+		Minutes:   task.Minutes,
+		StartTime: task.StartTime.In(loc).Format(time.RFC3339),
+
+		AutoStopped:  task.AutoStopped,
+		RecurrenceID: task.RecurrenceID,
+	}
+	if task.AutoStopReason != nil {
+		response.AutoStopReason = *task.AutoStopReason
+	}
+	if !task.EndTime.IsZero() {
+		response.EndTime = task.EndTime.In(loc).Format(time.RFC3339)
+	}
+
+	for _, tag := range task.Tags {
+		response.Tags = append(response.Tags, tag.Name)
+	}
+
+	for _, segment := range task.Segments {
+		segmentResponse := dto.TaskSegmentResponse{
+			ID:        segment.ID,
+			StartTime: segment.StartTime.In(loc).Format(time.RFC3339),
+		}
+		if segment.EndTime != nil {
+			segmentResponse.EndTime = segment.EndTime.In(loc).Format(time.RFC3339)
+		}
+		response.Segments = append(response.Segments, segmentResponse)
+	}
+
+	if session := task.FocusSession; session != nil {
+		response.FocusSession = &dto.FocusSessionResponse{
+			WorkMinutes:     session.WorkMinutes,
+			BreakMinutes:    session.BreakMinutes,
+			Cycles:          session.Cycles,
+			CompletedCycles: session.CompletedCycles,
+			Phase:           session.Phase,
+		}
+		if session.PhaseEndsAt != nil {
+			response.FocusSession.PhaseEndsAt = session.PhaseEndsAt.In(loc).Format(time.RFC3339)
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,13 @@
+package services
+
+import (
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+)
+
+type TaskStreamService interface {
+	Subscribe(request dto.TaskStreamRequest) (*TaskStream, error)
+	RelayEvents(now time.Time) (int, error)
+	PruneEvents(before time.Time) (int64, error)
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/Dor1ma/Time-Tracker/internal/stream"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	taskEventBatchSize = 500
+	// taskEventRelayWindow is how far back the relay looks for events. Event IDs are taken
+	// before the transactions storing them commit, so an event may turn up after events with
+	// higher IDs; the window must outlast any such transaction.
+	taskEventRelayWindow = time.Minute
+)
+
+// TaskStream is a subscription to the task events of some users. Backlog holds the stored
+// events after the last event ID of the request; the live ones follow on Events, which may
+// repeat events of the backlog.
+type TaskStream struct {
+	*stream.Subscription
+	Backlog []dto.TaskStreamEvent
+}
+
+// TaskStreamServiceImpl streams task events to clients of every API instance. Task events
+// are stored with the changes they report, and a trigger notifies every instance of them,
+// which relays them to its subscribers through hub. Stored events also let clients catch up
+// after reconnecting.
+type TaskStreamServiceImpl struct {
+	eventRepo repositories.TaskEventRepository
+	hub       *stream.Hub
+	logger    *logrus.Logger
+
+	relayMu sync.Mutex
+	// relayed holds the IDs of the events within the relay window that have been relayed,
+	// with the time they were stored. It is nil until the first relay.
+	relayed map[uint64]time.Time
+}
+
+func NewTaskStreamServiceImpl(eventRepo repositories.TaskEventRepository, hub *stream.Hub, logger *logrus.Logger) *TaskStreamServiceImpl {
+	return &TaskStreamServiceImpl{
+		eventRepo: eventRepo,
+		hub:       hub,
+		logger:    logger,
+	}
+}
+
+// Subscribe subscribes to the events of the users of the request and loads the stored
+// events after its last event ID, if any.
+func (s *TaskStreamServiceImpl) Subscribe(request dto.TaskStreamRequest) (*TaskStream, error) {
+	userIDs, err := parseIDList(request.UserIDs)
+	if err != nil || len(userIDs) == 0 {
+		s.logger.Debugf("Subscribe: invalid user IDs: %v", request.UserIDs)
+		return nil, models.ErrInvalidIDList
+	}
+
+	s.logger.Infof("Subscribe: streaming task events of user IDs %v after event ID %d", userIDs, request.LastEventID)
+	taskStream := &TaskStream{Subscription: s.hub.Subscribe(userIDs)}
+	if request.LastEventID == 0 {
+		return taskStream, nil
+	}
+
+	afterID := request.LastEventID
+	for {
+		events, err := s.eventRepo.GetAfter(afterID, userIDs, taskEventBatchSize)
+		if err != nil {
+			s.logger.Debugf("Subscribe: failed to fetch missed task events: %v", err)
+			taskStream.Close()
+			return nil, err
+		}
+
+		for i := range events {
+			taskStream.Backlog = append(taskStream.Backlog, newTaskStreamEvent(&events[i]))
+		}
+		if len(events) < taskEventBatchSize {
+			break
+		}
+		afterID = events[len(events)-1].ID
+	}
+
+	return taskStream, nil
+}
+
+// RelayEvents publishes the events stored within the relay window before now that it has
+// not relayed yet to the subscribers of this instance and returns how many there were. The
+// first call only finds out which events were stored before it.
+func (s *TaskStreamServiceImpl) RelayEvents(now time.Time) (int, error) {
+	s.relayMu.Lock()
+	defer s.relayMu.Unlock()
+
+	since := now.Add(-taskEventRelayWindow)
+	first := s.relayed == nil
+	if first {
+		s.relayed = make(map[uint64]time.Time)
+	}
+
+	var relayed int
+	var afterID uint64
+	for {
+		events, err := s.eventRepo.GetSince(since, afterID, taskEventBatchSize)
+		if err != nil {
+			return relayed, err
+		}
+
+		for i := range events {
+			afterID = events[i].ID
+			if _, ok := s.relayed[events[i].ID]; ok {
+				continue
+			}
+			s.relayed[events[i].ID] = events[i].CreatedAt
+			if !first {
+				s.hub.Publish(newTaskStreamEvent(&events[i]))
+				relayed++
+			}
+		}
+		if len(events) < taskEventBatchSize {
+			break
+		}
+	}
+
+	for id, createdAt := range s.relayed {
+		if createdAt.Before(since) {
+			delete(s.relayed, id)
+		}
+	}
+	return relayed, nil
+}
+
+// PruneEvents deletes the events stored before the given time; clients that were away for
+// longer no longer get the events they missed.
+func (s *TaskStreamServiceImpl) PruneEvents(before time.Time) (int64, error) {
+	s.logger.Infof("PruneEvents: deleting task events before %s", before.Format(time.RFC3339))
+	deleted, err := s.eventRepo.DeleteBefore(before)
+	if err != nil {
+		s.logger.Debugf("PruneEvents: failed to delete task events: %v", err)
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+func newTaskStreamEvent(event *models.TaskEvent) dto.TaskStreamEvent {
+	return dto.TaskStreamEvent{
+		ID:         event.ID,
+		UserID:     event.UserID,
+		Event:      event.Event,
+		OccurredAt: event.CreatedAt.UTC().Format(time.RFC3339),
+		Data:       json.RawMessage(event.Payload),
+	}
+}
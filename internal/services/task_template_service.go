@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+)
+
+type TaskTemplateService interface {
+	CreateTemplate(request dto.CreateTaskTemplateRequest) (*dto.TaskTemplateResponse, error)
+	GetTemplate(id uint) (*dto.TaskTemplateResponse, error)
+	GetTemplates(userID *uint) ([]dto.TaskTemplateResponse, error)
+	UpdateTemplate(id uint, request dto.UpdateTaskTemplateRequest) (*dto.TaskTemplateResponse, error)
+	DeleteTemplate(id uint) error
+	StartTemplate(ctx context.Context, id uint) (*dto.TaskResponse, error)
+}
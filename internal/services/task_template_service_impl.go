@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/sirupsen/logrus"
+)
+
+type TaskTemplateServiceImpl struct {
+	templateRepo repositories.TaskTemplateRepository
+	userRepo     repositories.UserRepository
+	taskService  TaskService
+	logger       *logrus.Logger
+}
+
+// NewTaskTemplateServiceImpl returns a template service that starts timers through taskService,
+// so that they follow the same running task policy as timers started by hand.
+func NewTaskTemplateServiceImpl(templateRepo repositories.TaskTemplateRepository, userRepo repositories.UserRepository,
+	taskService TaskService, logger *logrus.Logger) *TaskTemplateServiceImpl {
+	return &TaskTemplateServiceImpl{
+		templateRepo: templateRepo,
+		userRepo:     userRepo,
+		taskService:  taskService,
+		logger:       logger,
+	}
+}
+
+func (s *TaskTemplateServiceImpl) CreateTemplate(request dto.CreateTaskTemplateRequest) (*dto.TaskTemplateResponse, error) {
+	s.logger.Infof("CreateTemplate: creating task template for user ID: %d, task name: %s", request.UserID, request.TaskName)
+	if _, err := s.userRepo.GetById(request.UserID); err != nil {
+		s.logger.Debugf("CreateTemplate: failed to find user: %v", err)
+		return nil, err
+	}
+
+	template := &models.TaskTemplate{
+		UserID:          request.UserID,
+		TaskName:        request.TaskName,
+		ProjectID:       request.ProjectID,
+		Billable:        request.Billable == nil || *request.Billable,
+		DurationMinutes: request.DurationMinutes,
+		Tags:            newTags(request.Tags),
+	}
+
+	if err := s.templateRepo.Create(template); err != nil {
+		s.logger.Debugf("CreateTemplate: failed to create task template in database: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("CreateTemplate: task template created with ID: %d", template.ID)
+	return newTaskTemplateResponse(template), nil
+}
+
+func (s *TaskTemplateServiceImpl) GetTemplate(id uint) (*dto.TaskTemplateResponse, error) {
+	s.logger.Infof("GetTemplate: getting task template with ID: %d", id)
+	template, err := s.templateRepo.GetById(id)
+	if err != nil {
+		s.logger.Debugf("GetTemplate: failed to get task template: %v", err)
+		return nil, err
+	}
+
+	return newTaskTemplateResponse(template), nil
+}
+
+func (s *TaskTemplateServiceImpl) GetTemplates(userID *uint) ([]dto.TaskTemplateResponse, error) {
+	s.logger.Info("GetTemplates: fetching task templates")
+	templates, err := s.templateRepo.GetAll(userID)
+	if err != nil {
+		s.logger.Debugf("GetTemplates: failed to fetch task templates: %v", err)
+		return nil, err
+	}
+
+	templateResponses := make([]dto.TaskTemplateResponse, len(templates))
+	for i := range templates {
+		templateResponses[i] = *newTaskTemplateResponse(&templates[i])
+	}
+
+	return templateResponses, nil
+}
+
+func (s *TaskTemplateServiceImpl) UpdateTemplate(id uint, request dto.UpdateTaskTemplateRequest) (*dto.TaskTemplateResponse, error) {
+	s.logger.Infof("UpdateTemplate: updating task template with ID: %d", id)
+	template, err := s.templateRepo.GetById(id)
+	if err != nil {
+		s.logger.Debugf("UpdateTemplate: failed to get task template: %v", err)
+		return nil, err
+	}
+
+	template.TaskName = request.TaskName
+	template.ProjectID = request.ProjectID
+	template.Billable = request.Billable == nil || *request.Billable
+	template.DurationMinutes = request.DurationMinutes
+	template.Tags = newTags(request.Tags)
+
+	if err := s.templateRepo.Update(template); err != nil {
+		s.logger.Debugf("UpdateTemplate: failed to update task template in database: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("UpdateTemplate: task template updated with ID: %d", id)
+	return newTaskTemplateResponse(template), nil
+}
+
+func (s *TaskTemplateServiceImpl) DeleteTemplate(id uint) error {
+	s.logger.Infof("DeleteTemplate: deleting task template with ID: %d", id)
+	if err := s.templateRepo.Delete(id); err != nil {
+		s.logger.Debugf("DeleteTemplate: failed to delete task template: %v", err)
+		return err
+	}
+
+	s.logger.Infof("DeleteTemplate: task template deleted with ID: %d", id)
+	return nil
+}
+
+// StartTemplate starts a timer for the user of the template with its name, project, tags
+// and billability.
+func (s *TaskTemplateServiceImpl) StartTemplate(ctx context.Context, id uint) (*dto.TaskResponse, error) {
+	s.logger.Infof("StartTemplate: starting task from template with ID: %d", id)
+	template, err := s.templateRepo.GetById(id)
+	if err != nil {
+		s.logger.Debugf("StartTemplate: failed to get task template: %v", err)
+		return nil, err
+	}
+
+	billable := template.Billable
+	return s.taskService.StartTask(ctx, dto.StartTaskRequest{
+		UserID:    template.UserID,
+		TaskName:  template.TaskName,
+		ProjectID: template.ProjectID,
+		Tags:      templateTagNames(template),
+		Billable:  &billable,
+	})
+}
+
+func templateTagNames(template *models.TaskTemplate) []string {
+	names := make([]string, len(template.Tags))
+	for i, tag := range template.Tags {
+		names[i] = tag.Name
+	}
+
+	return names
+}
+
+func newTaskTemplateResponse(template *models.TaskTemplate) *dto.TaskTemplateResponse {
+	return &dto.TaskTemplateResponse{
+		ID:              template.ID,
+		UserID:          template.UserID,
+		TaskName:        template.TaskName,
+		ProjectID:       template.ProjectID,
+		Tags:            templateTagNames(template),
+		Billable:        template.Billable,
+		DurationMinutes: template.DurationMinutes,
+	}
+}
@@ -0,0 +1,11 @@
+package services
+
+import "github.com/Dor1ma/Time-Tracker/internal/dto"
+
+type TeamService interface {
+	CreateTeam(request dto.CreateTeamRequest) (*dto.TeamResponse, error)
+	GetTeams() ([]dto.TeamResponse, error)
+	GetTeamById(id uint) (*dto.TeamResponse, error)
+	UpdateTeam(id uint, request dto.UpdateTeamRequest) (*dto.TeamResponse, error)
+	DeleteTeam(id uint) error
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/sirupsen/logrus"
+)
+
+type TeamServiceImpl struct {
+	teamRepo repositories.TeamRepository
+	logger   *logrus.Logger
+}
+
+func NewTeamServiceImpl(teamRepo repositories.TeamRepository, logger *logrus.Logger) *TeamServiceImpl {
+	return &TeamServiceImpl{
+		teamRepo: teamRepo,
+		logger:   logger,
+	}
+}
+
+func (s *TeamServiceImpl) CreateTeam(request dto.CreateTeamRequest) (*dto.TeamResponse, error) {
+	s.logger.Infof("CreateTeam: creating team with name: %s", request.Name)
+	team := &models.Team{Name: request.Name}
+	if err := s.teamRepo.Create(team, uniqueIDs(request.UserIDs)); err != nil {
+		s.logger.Debugf("CreateTeam: failed to create team in database: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("CreateTeam: team created with ID: %d", team.ID)
+	return newTeamResponse(team), nil
+}
+
+func (s *TeamServiceImpl) GetTeams() ([]dto.TeamResponse, error) {
+	s.logger.Info("GetTeams: fetching all teams")
+	teams, err := s.teamRepo.GetAll()
+	if err != nil {
+		s.logger.Debugf("GetTeams: failed to fetch teams: %v", err)
+		return nil, err
+	}
+
+	teamResponses := make([]dto.TeamResponse, len(teams))
+	for i := range teams {
+		teamResponses[i] = *newTeamResponse(&teams[i])
+	}
+
+	return teamResponses, nil
+}
+
+func (s *TeamServiceImpl) GetTeamById(id uint) (*dto.TeamResponse, error) {
+	s.logger.Infof("GetTeamById: getting team with ID: %d", id)
+	team, err := s.teamRepo.GetById(id)
+	if err != nil {
+		s.logger.Debugf("GetTeamById: failed to get team: %v", err)
+		return nil, err
+	}
+
+	return newTeamResponse(team), nil
+}
+
+func (s *TeamServiceImpl) UpdateTeam(id uint, request dto.UpdateTeamRequest) (*dto.TeamResponse, error) {
+	s.logger.Infof("UpdateTeam: updating team with ID: %d", id)
+	team, err := s.teamRepo.GetById(id)
+	if err != nil {
+		s.logger.Debugf("UpdateTeam: failed to get team: %v", err)
+		return nil, err
+	}
+
+	team.Name = request.Name
+	if err := s.teamRepo.Update(team, uniqueIDs(request.UserIDs)); err != nil {
+		s.logger.Debugf("UpdateTeam: failed to update team in database: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("UpdateTeam: team updated with ID: %d", id)
+	return newTeamResponse(team), nil
+}
+
+func (s *TeamServiceImpl) DeleteTeam(id uint) error {
+	s.logger.Infof("DeleteTeam: deleting team with ID: %d", id)
+	if err := s.teamRepo.Delete(id); err != nil {
+		s.logger.Debugf("DeleteTeam: failed to delete team: %v", err)
+		return err
+	}
+
+	s.logger.Infof("DeleteTeam: team deleted with ID: %d", id)
+	return nil
+}
+
+func newTeamResponse(team *models.Team) *dto.TeamResponse {
+	response := &dto.TeamResponse{
+		ID:      team.ID,
+		Name:    team.Name,
+		UserIDs: make([]uint, len(team.Members)),
+	}
+	for i, member := range team.Members {
+		response.UserIDs[i] = member.ID
+	}
+
+	return response
+}
@@ -0,0 +1,129 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/audit"
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditDiff_KeepsChangedFields(t *testing.T) {
+	before := map[string]any{"id": 5, "name": "Ivan", "role": "user"}
+	after := map[string]any{"id": 5, "name": "Ivan", "role": "manager"}
+
+	changes, err := audit.Diff(before, after)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"role"}, keys(changes))
+	assert.JSONEq(t, `"user"`, string(changes["role"].Before))
+	assert.JSONEq(t, `"manager"`, string(changes["role"].After))
+}
+
+func TestAuditDiff_CreateHasNoBefore(t *testing.T) {
+	changes, err := audit.Diff(nil, map[string]any{"id": 7})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id": {"before": null, "after": 7}}`, mustMarshal(t, changes))
+}
+
+func TestGetAuditEntries_Filters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuditRepo := repositories.NewMockAuditRepository(ctrl)
+	service := services.NewAuditServiceImpl(mockAuditRepo, logrus.New())
+
+	entityID := uint(7)
+	from := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	filter := repositories.AuditFilter{EntityType: models.AuditEntityTask, EntityID: &entityID, From: from}
+	mockAuditRepo.EXPECT().GetEntries(filter, 100).Return([]models.AuditEntry{
+		{ID: 2, Action: models.AuditActionStop, EntityType: models.AuditEntityTask, EntityID: 7, Changes: `{}`, CreatedAt: from},
+	}, nil)
+
+	entries, err := service.GetEntries(dto.GetAuditEntriesRequest{EntityType: models.AuditEntityTask, EntityID: &entityID, From: from})
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, uint64(2), entries[0].ID)
+	assert.Equal(t, json.RawMessage(`{}`), entries[0].Changes)
+	assert.Empty(t, entries[0].RequestID)
+}
+
+func TestTaskAuditState_StopChangesStatusAndTimes(t *testing.T) {
+	start := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	running := &models.Task{ID: 7, UserID: 1, TaskName: "Report", Status: models.TaskStatusRunning, StartTime: start}
+	stopped := &models.Task{ID: 7, UserID: 1, TaskName: "Report", Status: models.TaskStatusStopped, StartTime: start,
+		EndTime: start.Add(time.Hour), Minutes: 60, Hours: 1}
+
+	changes, err := audit.Diff(models.NewTaskAuditState(running), models.NewTaskAuditState(stopped))
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"status", "end_time", "minutes", "hours"}, keys(changes))
+	assert.JSONEq(t, `"running"`, string(changes["status"].Before))
+	assert.JSONEq(t, `"stopped"`, string(changes["status"].After))
+}
+
+func TestUserAuditState_LeavesOutPassport(t *testing.T) {
+	user := &models.User{ID: 4, PassportNumber: "1234 567890", Name: "Ivan"}
+	before := models.NewUserAuditState(user)
+	deletedAt := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	user.DeletedAt = &deletedAt
+
+	changes, err := audit.Diff(before, models.NewUserAuditState(user))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"deleted_at"}, keys(changes))
+	assert.JSONEq(t, `null`, string(changes["deleted_at"].Before))
+	assert.NotContains(t, mustMarshal(t, models.NewUserAuditState(user)), "567890")
+}
+
+func TestStopTask_PassesActorToRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logrus.New())
+
+	start := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	stopped := &models.Task{ID: 7, UserID: 1, TaskName: "Report", Status: models.TaskStatusStopped, StartTime: start,
+		EndTime: start.Add(time.Hour), Minutes: 60, Hours: 1}
+
+	var actor audit.Actor
+	mockRepo.EXPECT().StopTask(gomock.Any(), uint(7)).DoAndReturn(func(ctx context.Context, taskID uint) (*models.Task, error) {
+		actor = audit.FromContext(ctx)
+		return stopped, nil
+	})
+
+	actorID := uint(1)
+	ctx := audit.NewContext(context.Background(), audit.Actor{UserID: &actorID, RequestID: "req-2"})
+	_, err := service.StopTask(ctx, dto.StopTaskRequest{TaskID: 7})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &actorID, actor.UserID)
+	assert.Equal(t, "req-2", actor.RequestID)
+}
+
+func keys[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func mustMarshal(t *testing.T, value any) string {
+	data, err := json.Marshal(value)
+	assert.NoError(t, err)
+	return string(data)
+}
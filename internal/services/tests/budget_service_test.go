@@ -0,0 +1,157 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestSetBudget_TaskName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBudgetRepo := repositories.NewMockBudgetRepository(ctrl)
+	mockProjectRepo := repositories.NewMockProjectRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewBudgetServiceImpl(mockBudgetRepo, mockProjectRepo, logger)
+
+	taskName := "TT-42"
+	mockBudgetRepo.EXPECT().Set(&models.Budget{TaskName: &taskName, EstimateMinutes: 120}).
+		DoAndReturn(func(budget *models.Budget) error {
+			budget.ID = 1
+			return nil
+		})
+
+	budget, err := service.SetBudget(dto.SetBudgetRequest{TaskName: &taskName, EstimateMinutes: 120})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), budget.ID)
+	assert.Equal(t, "TT-42", *budget.TaskName)
+	assert.Nil(t, budget.ProjectID)
+}
+
+func TestSetBudget_InvalidScope(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBudgetRepo := repositories.NewMockBudgetRepository(ctrl)
+	mockProjectRepo := repositories.NewMockProjectRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewBudgetServiceImpl(mockBudgetRepo, mockProjectRepo, logger)
+
+	projectID, taskName := uint(3), "TT-42"
+	budget, err := service.SetBudget(dto.SetBudgetRequest{ProjectID: &projectID, TaskName: &taskName, EstimateMinutes: 120})
+	assert.ErrorIs(t, err, models.ErrInvalidBudgetScope)
+	assert.Nil(t, budget)
+
+	budget, err = service.SetBudget(dto.SetBudgetRequest{EstimateMinutes: 120})
+	assert.ErrorIs(t, err, models.ErrInvalidBudgetScope)
+	assert.Nil(t, budget)
+}
+
+func TestSetBudget_UnknownProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBudgetRepo := repositories.NewMockBudgetRepository(ctrl)
+	mockProjectRepo := repositories.NewMockProjectRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewBudgetServiceImpl(mockBudgetRepo, mockProjectRepo, logger)
+
+	projectID := uint(3)
+	mockProjectRepo.EXPECT().GetById(projectID).Return(nil, gorm.ErrRecordNotFound)
+
+	budget, err := service.SetBudget(dto.SetBudgetRequest{ProjectID: &projectID, EstimateMinutes: 2400})
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Nil(t, budget)
+}
+
+func TestGetBudgetProgress_Overrun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBudgetRepo := repositories.NewMockBudgetRepository(ctrl)
+	mockProjectRepo := repositories.NewMockProjectRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewBudgetServiceImpl(mockBudgetRepo, mockProjectRepo, logger)
+
+	projectID := uint(3)
+	budget := &models.Budget{ID: 1, ProjectID: &projectID, EstimateMinutes: 2400}
+	mockBudgetRepo.EXPECT().GetById(uint(1)).Return(budget, nil)
+	mockBudgetRepo.EXPECT().GetConsumedMinutes(budget).Return(2650, nil)
+
+	progress, err := service.GetBudgetProgress(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2650, progress.ConsumedMinutes)
+	assert.Equal(t, 0, progress.RemainingMinutes)
+	assert.Equal(t, 110.4, progress.Percentage)
+}
+
+func TestGetBudgetProgress_InProgress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBudgetRepo := repositories.NewMockBudgetRepository(ctrl)
+	mockProjectRepo := repositories.NewMockProjectRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewBudgetServiceImpl(mockBudgetRepo, mockProjectRepo, logger)
+
+	taskName := "TT-42"
+	budget := &models.Budget{ID: 2, TaskName: &taskName, EstimateMinutes: 120}
+	mockBudgetRepo.EXPECT().GetById(uint(2)).Return(budget, nil)
+	mockBudgetRepo.EXPECT().GetConsumedMinutes(budget).Return(45, nil)
+
+	progress, err := service.GetBudgetProgress(2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), progress.ID)
+	assert.Equal(t, 75, progress.RemainingMinutes)
+	assert.Equal(t, 37.5, progress.Percentage)
+}
+
+func TestBudgetCrossedThresholds(t *testing.T) {
+	budget := &models.Budget{EstimateMinutes: 100}
+
+	tests := []struct {
+		name          string
+		before, after int
+		want          []int
+	}{
+		{name: "below the first threshold", before: 10, after: 79},
+		{name: "reaches the first threshold exactly", before: 79, after: 80, want: []int{80}},
+		{name: "crosses the first threshold", before: 70, after: 90, want: []int{80}},
+		{name: "crosses both thresholds in one stop", before: 50, after: 120, want: []int{80, 100}},
+		{name: "already past the first threshold", before: 85, after: 95},
+		{name: "crosses the second threshold only", before: 85, after: 100, want: []int{100}},
+		{name: "already past both thresholds", before: 100, after: 150},
+		{name: "time spent goes down", before: 120, after: 50},
+		{name: "no time added", before: 80, after: 80},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, budget.CrossedThresholds(test.before, test.after))
+		})
+	}
+}
+
+func TestBudgetCrossedThresholds_UnevenEstimate(t *testing.T) {
+	budget := &models.Budget{EstimateMinutes: 45}
+
+	assert.Nil(t, budget.CrossedThresholds(0, 35))
+	assert.Equal(t, []int{80}, budget.CrossedThresholds(35, 36))
+	assert.Equal(t, []int{100}, budget.CrossedThresholds(44, 45))
+}
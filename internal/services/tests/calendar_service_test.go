@@ -0,0 +1,137 @@
+package tests
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegenerateCalendarToken_StoresHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewCalendarServiceImpl(repositories.NewMockTaskRepository(ctrl), mockUserRepo, logger)
+
+	var storedHash string
+	mockUserRepo.EXPECT().SetCalendarTokenHash(uint(1), gomock.Any()).DoAndReturn(func(id uint, hash string) error {
+		storedHash = hash
+		return nil
+	})
+
+	token, err := service.RegenerateToken(1)
+
+	assert.NoError(t, err)
+	assert.Len(t, token.Token, 64)
+	assert.Equal(t, "/users/1/calendar.ics?token="+token.Token, token.FeedURL)
+	sum := sha256.Sum256([]byte(token.Token))
+	assert.Equal(t, hex.EncodeToString(sum[:]), storedHash)
+}
+
+func TestWriteUserCalendar_InvalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewCalendarServiceImpl(repositories.NewMockTaskRepository(ctrl), mockUserRepo, logger)
+
+	hash := calendarTokenHash("current")
+	mockUserRepo.EXPECT().GetById(uint(1)).Return(&models.User{ID: 1, CalendarTokenHash: &hash}, nil).Times(2)
+	mockUserRepo.EXPECT().GetById(uint(2)).Return(&models.User{ID: 2}, nil)
+
+	var out bytes.Buffer
+	err := service.WriteUserCalendar(1, dto.GetUserCalendarRequest{Token: "revoked"}, &out)
+	assert.ErrorIs(t, err, models.ErrInvalidCalendarToken)
+
+	err = service.WriteUserCalendar(1, dto.GetUserCalendarRequest{Token: ""}, &out)
+	assert.ErrorIs(t, err, models.ErrInvalidCalendarToken)
+
+	err = service.WriteUserCalendar(2, dto.GetUserCalendarRequest{Token: "current"}, &out)
+	assert.ErrorIs(t, err, models.ErrInvalidCalendarToken)
+	assert.Zero(t, out.Len())
+}
+
+func TestWriteUserCalendar_WritesTasksAsEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := repositories.NewMockTaskRepository(ctrl)
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewCalendarServiceImpl(mockTaskRepo, mockUserRepo, logger)
+
+	hash := calendarTokenHash("secret")
+	mockUserRepo.EXPECT().GetById(uint(1)).Return(&models.User{
+		ID: 1, Name: "Ivan", Surname: "Petrov", TimeZone: "Europe/Berlin", CalendarTokenHash: &hash,
+	}, nil)
+
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	projectID := uint(3)
+	start := time.Date(2024, 7, 1, 7, 0, 0, 0, time.UTC)
+	firstEnd := start.Add(time.Hour)
+	secondStart := start.Add(90 * time.Minute)
+	end := start.Add(2 * time.Hour)
+	mockTaskRepo.EXPECT().GetUserTasksInBatches(repositories.TaskFilter{
+		UserID:       1,
+		StartDate:    time.Date(2024, 7, 1, 0, 0, 0, 0, berlin),
+		EndDate:      time.Date(2024, 7, 8, 0, 0, 0, 0, berlin),
+		ProjectID:    &projectID,
+		WithSegments: true,
+	}, gomock.Any(), gomock.Any()).DoAndReturn(func(filter repositories.TaskFilter, batchSize int, visit func([]models.Task) error) error {
+		return visit([]models.Task{
+			{
+				ID: 10, TaskName: "Review; part 2, final", Status: models.TaskStatusStopped, Minutes: 90,
+				StartTime: start, EndTime: end,
+				Segments: []models.TaskSegment{
+					{StartTime: start, EndTime: &firstEnd},
+					{StartTime: secondStart, EndTime: &end},
+				},
+			},
+			{ID: 11, TaskName: "Standup", Status: models.TaskStatusPlanned, StartTime: start, EndTime: end},
+			{
+				ID: 12, TaskName: "Support", Status: models.TaskStatusRunning, StartTime: end,
+				Segments: []models.TaskSegment{{StartTime: end}},
+			},
+		})
+	})
+
+	var out bytes.Buffer
+	err := service.WriteUserCalendar(1, dto.GetUserCalendarRequest{
+		Token: "secret", StartDate: "2024-07-01", EndDate: "2024-07-07", ProjectID: &projectID,
+	}, &out)
+
+	assert.NoError(t, err)
+	feed := out.String()
+	assert.True(t, strings.HasPrefix(feed, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(feed, "END:VEVENT\r\nEND:VCALENDAR\r\n"))
+	assert.Contains(t, feed, "X-WR-CALNAME:Tracked time of Ivan Petrov\r\n")
+	assert.Contains(t, feed, "UID:task-10@time-tracker\r\n")
+	assert.Contains(t, feed, "DTSTART:20240701T070000Z\r\nDTEND:20240701T090000Z\r\n")
+	assert.Contains(t, feed, `SUMMARY:Review\; part 2\, final`+"\r\n")
+	assert.Contains(t, feed, "DESCRIPTION:Duration: 1:30:00\r\n")
+	assert.NotContains(t, feed, "Standup")
+	assert.Contains(t, feed, "UID:task-12@time-tracker\r\nDTSTAMP:")
+	assert.Contains(t, feed, "DESCRIPTION:In progress (running)\\, Duration: ")
+	assert.Equal(t, 2, strings.Count(feed, "BEGIN:VEVENT"))
+}
+
+func calendarTokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
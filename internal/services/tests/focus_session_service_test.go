@@ -0,0 +1,226 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartTask_FocusSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+
+	start := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+	mockRepo.EXPECT().StartTask(gomock.Any(), gomock.Any(), false).DoAndReturn(func(_ context.Context, task *models.Task, stopRunning bool) error {
+		assert.Equal(t, &models.FocusSession{WorkMinutes: 25, BreakMinutes: 5, Cycles: 4}, task.FocusSession)
+		workEndsAt := start.Add(25 * time.Minute)
+		task.ID = 1
+		task.Status = models.TaskStatusRunning
+		task.StartTime = start
+		task.FocusSession.Phase = models.FocusPhaseWork
+		task.FocusSession.PhaseEndsAt = &workEndsAt
+		return nil
+	})
+
+	task, err := service.StartTask(context.Background(), dto.StartTaskRequest{
+		UserID:       1,
+		TaskName:     "Write report",
+		FocusSession: &dto.FocusSessionRequest{WorkMinutes: 25, BreakMinutes: 5, Cycles: 4},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.FocusSessionResponse{
+		WorkMinutes:  25,
+		BreakMinutes: 5,
+		Cycles:       4,
+		Phase:        models.FocusPhaseWork,
+		PhaseEndsAt:  "2024-07-01T09:25:00Z",
+	}, task.FocusSession)
+}
+
+func TestAdvanceFocusSessions_ReportsCompletedCycles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewFocusSessionServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "Europe/Berlin"), logger)
+
+	now := time.Date(2024, 7, 1, 9, 26, 0, 0, time.UTC)
+	breakEndsAt := time.Date(2024, 7, 1, 9, 30, 0, 0, time.UTC)
+	mockRepo.EXPECT().GetDueFocusSessions(now).Return([]models.FocusSession{{TaskID: 1}, {TaskID: 2}}, nil)
+	mockRepo.EXPECT().AdvanceFocusSession(gomock.Any(), uint(1), now).Return(&models.Task{
+		ID:      1,
+		UserID:  1,
+		Status:  models.TaskStatusPaused,
+		Minutes: 25,
+		FocusSession: &models.FocusSession{
+			TaskID: 1, WorkMinutes: 25, BreakMinutes: 5, Cycles: 4, CompletedCycles: 1,
+			Phase: models.FocusPhaseBreak, PhaseEndsAt: &breakEndsAt,
+		},
+	}, nil)
+	mockRepo.EXPECT().AdvanceFocusSession(gomock.Any(), uint(2), now).Return(nil, errors.New("connection reset"))
+
+	tasks, err := service.AdvanceFocusSessions(context.Background(), now)
+
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	assert.Equal(t, models.TaskStatusPaused, tasks[0].Status)
+	assert.Equal(t, 25, tasks[0].Minutes)
+	assert.Equal(t, 1, tasks[0].FocusSession.CompletedCycles)
+	assert.Equal(t, models.FocusPhaseBreak, tasks[0].FocusSession.Phase)
+	assert.Equal(t, "2024-07-01T11:30:00+02:00", tasks[0].FocusSession.PhaseEndsAt)
+}
+
+func TestFocusSessionEndPhase_WorkIntervalStartsBreak(t *testing.T) {
+	workEnd := time.Date(2024, 7, 1, 9, 25, 0, 0, time.UTC)
+	session := &models.FocusSession{WorkMinutes: 25, BreakMinutes: 5, Cycles: 4, CompletedCycles: 2,
+		Phase: models.FocusPhaseWork, PhaseEndsAt: &workEnd}
+
+	step, err := session.EndPhase(models.TaskStatusRunning, unaskedResumable(t))
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.FocusActionPause, step.Action)
+	assert.Equal(t, workEnd, step.At)
+	assert.Equal(t, 3, step.Session.CompletedCycles)
+	assert.Equal(t, models.FocusPhaseBreak, step.Session.Phase)
+	assert.Equal(t, workEnd.Add(5*time.Minute), *step.Session.PhaseEndsAt)
+	assert.Equal(t, 2, session.CompletedCycles)
+	assert.Equal(t, models.FocusPhaseWork, session.Phase)
+}
+
+func TestFocusSessionEndPhase_LastCycleStopsWithoutBreak(t *testing.T) {
+	workEnd := time.Date(2024, 7, 1, 11, 0, 0, 0, time.UTC)
+	session := &models.FocusSession{WorkMinutes: 25, BreakMinutes: 5, Cycles: 4, CompletedCycles: 3,
+		Phase: models.FocusPhaseWork, PhaseEndsAt: &workEnd}
+
+	step, err := session.EndPhase(models.TaskStatusRunning, unaskedResumable(t))
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.FocusActionStop, step.Action)
+	assert.Equal(t, workEnd, step.At)
+	assert.Equal(t, 4, step.Session.CompletedCycles)
+	assert.Equal(t, models.FocusPhaseDone, step.Session.Phase)
+	assert.Nil(t, step.Session.PhaseEndsAt)
+}
+
+func TestFocusSessionEndPhase_BreakResumesWork(t *testing.T) {
+	breakEnd := time.Date(2024, 7, 1, 9, 30, 0, 0, time.UTC)
+	session := &models.FocusSession{WorkMinutes: 25, BreakMinutes: 5, Cycles: 4, CompletedCycles: 1,
+		Phase: models.FocusPhaseBreak, PhaseEndsAt: &breakEnd}
+
+	var askedAt time.Time
+	step, err := session.EndPhase(models.TaskStatusPaused, func(at time.Time) (bool, error) {
+		askedAt = at
+		return true, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, breakEnd, askedAt)
+	assert.Equal(t, models.FocusActionResume, step.Action)
+	assert.Equal(t, breakEnd, step.At)
+	assert.Equal(t, 1, step.Session.CompletedCycles)
+	assert.Equal(t, models.FocusPhaseWork, step.Session.Phase)
+	assert.Equal(t, breakEnd.Add(25*time.Minute), *step.Session.PhaseEndsAt)
+}
+
+func TestFocusSessionEndPhase_UnresumableBreakStopsAtItsStart(t *testing.T) {
+	breakEnd := time.Date(2024, 7, 1, 9, 30, 0, 0, time.UTC)
+	session := &models.FocusSession{WorkMinutes: 25, BreakMinutes: 5, Cycles: 4, CompletedCycles: 1,
+		Phase: models.FocusPhaseBreak, PhaseEndsAt: &breakEnd}
+
+	step, err := session.EndPhase(models.TaskStatusPaused, func(time.Time) (bool, error) { return false, nil })
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.FocusActionStop, step.Action)
+	assert.Equal(t, breakEnd.Add(-5*time.Minute), step.At)
+	assert.Equal(t, 1, step.Session.CompletedCycles)
+	assert.Equal(t, models.FocusPhaseDone, step.Session.Phase)
+}
+
+func TestFocusSessionEndPhase_TaskChangedByHandEndsSession(t *testing.T) {
+	phaseEnd := time.Date(2024, 7, 1, 9, 25, 0, 0, time.UTC)
+	cases := []struct {
+		phase  string
+		status string
+	}{
+		{models.FocusPhaseWork, models.TaskStatusPaused},
+		{models.FocusPhaseWork, models.TaskStatusStopped},
+		{models.FocusPhaseBreak, models.TaskStatusRunning},
+		{models.FocusPhaseBreak, models.TaskStatusStopped},
+	}
+
+	for _, c := range cases {
+		session := &models.FocusSession{WorkMinutes: 25, BreakMinutes: 5, Cycles: 4, CompletedCycles: 1,
+			Phase: c.phase, PhaseEndsAt: &phaseEnd}
+
+		step, err := session.EndPhase(c.status, unaskedResumable(t))
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.FocusActionEnd, step.Action, "%s phase of %s task", c.phase, c.status)
+		assert.Equal(t, 1, step.Session.CompletedCycles)
+		assert.Equal(t, models.FocusPhaseDone, step.Session.Phase)
+		assert.Nil(t, step.Session.PhaseEndsAt)
+	}
+}
+
+func TestFocusSessionEndPhase_ResumableError(t *testing.T) {
+	breakEnd := time.Date(2024, 7, 1, 9, 30, 0, 0, time.UTC)
+	session := &models.FocusSession{WorkMinutes: 25, BreakMinutes: 5, Cycles: 4, CompletedCycles: 1,
+		Phase: models.FocusPhaseBreak, PhaseEndsAt: &breakEnd}
+
+	_, err := session.EndPhase(models.TaskStatusPaused, func(time.Time) (bool, error) {
+		return false, errors.New("connection lost")
+	})
+
+	assert.EqualError(t, err, "connection lost")
+}
+
+func TestResumableAfterBreak(t *testing.T) {
+	breakEnd := time.Date(2024, 7, 1, 9, 30, 0, 0, time.UTC)
+	cases := []struct {
+		name            string
+		others          []models.Task
+		projectArchived bool
+		want            bool
+	}{
+		{name: "no other work", want: true},
+		{name: "other task running", others: []models.Task{{Status: models.TaskStatusRunning}}},
+		{name: "other task stopped after the break",
+			others: []models.Task{{Status: models.TaskStatusStopped, EndTime: breakEnd.Add(time.Second)}}},
+		{name: "other task stopped as the break ended",
+			others: []models.Task{{Status: models.TaskStatusStopped, EndTime: breakEnd}}, want: true},
+		{name: "other task stopped during the break",
+			others: []models.Task{{Status: models.TaskStatusStopped, EndTime: breakEnd.Add(-time.Minute)}}, want: true},
+		{name: "other task paused", others: []models.Task{{Status: models.TaskStatusPaused}}, want: true},
+		{name: "project archived", projectArchived: true},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.want, models.ResumableAfterBreak(breakEnd, c.others, c.projectArchived), c.name)
+	}
+}
+
+// unaskedResumable returns a resumable func for phases other than breaks, which must not call it.
+func unaskedResumable(t *testing.T) func(time.Time) (bool, error) {
+	return func(time.Time) (bool, error) {
+		t.Error("resumable asked outside a break")
+		return false, nil
+	}
+}
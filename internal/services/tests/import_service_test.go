@@ -0,0 +1,206 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+const togglExport = `User,Email,Client,Project,Task,Description,Billable,Start date,Start time,End date,End time,Duration,Tags,Amount ()
+Ivan Ivanov,ivan@example.com,Acme,Website,,Design,Yes,2024-07-10,09:00:00,2024-07-10,10:30:00,01:30:00,"meeting, review",
+Ivan Ivanov,ivan@example.com,Acme,Website,,Design,Yes,2024-07-10,09:00:00,2024-07-10,10:30:00,01:30:00,"meeting, review",
+Petr Petrov,petr@example.com,,,,Support,No,2024-07-11,14:00:00,2024-07-11,15:00:00,01:00:00,,
+Ivan Ivanov,ivan@example.com,,,,Broken,Yes,2024-07-12,09:00:00,2024-07-12,08:00:00,,,
+`
+
+const clockifyExport = `Project,Client,Description,Task,User,Group,Email,Tags,Billable,Start Date,Start Time,End Date,End Time,Duration (h),Duration (decimal)
+Mobile App,Globex,Standup,,Ivanov Ivan,,ivan@example.com,,Yes,07/15/2024,09:30:00 AM,07/15/2024,09:45:00 AM,00:15:00,0.25
+`
+
+func newImportService(ctrl *gomock.Controller) (*services.ImportServiceImpl, *repositories.MockTaskRepository,
+	*repositories.MockUserRepository, *repositories.MockProjectRepository, *repositories.MockClientRepository) {
+	mockTaskRepo := repositories.NewMockTaskRepository(ctrl)
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	mockProjectRepo := repositories.NewMockProjectRepository(ctrl)
+	mockClientRepo := repositories.NewMockClientRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewImportServiceImpl(mockTaskRepo, mockUserRepo, mockProjectRepo, mockClientRepo, logger)
+	return service, mockTaskRepo, mockUserRepo, mockProjectRepo, mockClientRepo
+}
+
+func TestImportTasks_TogglDryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockTaskRepo, mockUserRepo, mockProjectRepo, mockClientRepo := newImportService(ctrl)
+
+	acmeID, websiteID := uint(2), uint(3)
+	mockUserRepo.EXPECT().GetAll().Return([]models.User{
+		{ID: 1, Name: "Ivan", Surname: "Ivanov"},
+		{ID: 2, Name: "Petr", Surname: "Petrov"},
+	}, nil)
+	mockClientRepo.EXPECT().GetAll().Return([]models.Client{{ID: acmeID, Name: "Acme"}}, nil)
+	mockProjectRepo.EXPECT().GetAll(nil, true).Return([]models.Project{
+		{ID: websiteID, Name: "Website", ClientID: &acmeID, Client: &models.Client{ID: acmeID, Name: "Acme"}},
+	}, nil)
+	mockTaskRepo.EXPECT().GetImportedKeys(gomock.Len(3)).Return(nil, nil)
+
+	result, err := service.ImportTasks(context.Background(), dto.ImportTasksRequest{DryRun: true}, strings.NewReader(togglExport))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "toggl", result.Source)
+	assert.Equal(t, 4, result.TotalRows)
+	assert.Equal(t, 2, result.Created)
+	assert.Equal(t, 1, result.Duplicates)
+	assert.Equal(t, 1, result.Invalid)
+	assert.Empty(t, result.ProjectsCreated)
+
+	assert.Equal(t, services.ImportRowWouldCreate, result.Rows[0].Status)
+	assert.Equal(t, uint(1), *result.Rows[0].UserID)
+	assert.Equal(t, "2024-07-10T09:00:00Z", result.Rows[0].StartTime)
+	assert.Equal(t, services.ImportRowDuplicate, result.Rows[1].Status)
+	assert.Equal(t, uint(2), *result.Rows[2].UserID)
+	assert.Equal(t, services.ImportRowInvalid, result.Rows[3].Status)
+	assert.Equal(t, 5, result.Rows[3].Line)
+	assert.Contains(t, result.Rows[3].Error, "end must be after start")
+}
+
+func TestImportTasks_ClockifyCreatesProjectAndSkipsImported(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockTaskRepo, mockUserRepo, mockProjectRepo, mockClientRepo := newImportService(ctrl)
+
+	mockUserRepo.EXPECT().GetAll().Return([]models.User{{ID: 1, Name: "Ivan", Surname: "Ivanov"}}, nil)
+	mockClientRepo.EXPECT().GetAll().Return(nil, nil)
+	mockProjectRepo.EXPECT().GetAll(nil, true).Return(nil, nil)
+	mockTaskRepo.EXPECT().GetImportedKeys(gomock.Len(1)).Return(nil, nil)
+	mockClientRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(client *models.Client) error {
+		assert.Equal(t, "Globex", client.Name)
+		client.ID = 7
+		return nil
+	})
+	mockProjectRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(project *models.Project) error {
+		assert.Equal(t, "Mobile App", project.Name)
+		assert.Equal(t, uint(7), *project.ClientID)
+		project.ID = 8
+		return nil
+	})
+
+	moscow, _ := time.LoadLocation("Europe/Moscow")
+	var importKey string
+	mockTaskRepo.EXPECT().CreateTask(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, task *models.Task) error {
+		assert.Equal(t, "Standup", task.TaskName)
+		assert.Equal(t, uint(8), *task.ProjectID)
+		assert.True(t, task.Billable)
+		assert.True(t, task.StartTime.Equal(time.Date(2024, 7, 15, 9, 30, 0, 0, moscow)))
+		assert.True(t, task.EndTime.Equal(time.Date(2024, 7, 15, 9, 45, 0, 0, moscow)))
+		importKey = *task.ImportKey
+		task.ID = 42
+		return nil
+	})
+
+	result, err := service.ImportTasks(context.Background(), dto.ImportTasksRequest{Timezone: "Europe/Moscow"}, strings.NewReader(clockifyExport))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "clockify", result.Source)
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, []string{"Globex / Mobile App"}, result.ProjectsCreated)
+	assert.Equal(t, uint(42), *result.Rows[0].TaskID)
+
+	mockUserRepo.EXPECT().GetAll().Return([]models.User{{ID: 1, Name: "Ivan", Surname: "Ivanov"}}, nil)
+	mockClientRepo.EXPECT().GetAll().Return([]models.Client{{ID: 7, Name: "Globex"}}, nil)
+	mockProjectRepo.EXPECT().GetAll(nil, true).Return(nil, nil)
+	mockTaskRepo.EXPECT().GetImportedKeys([]string{importKey}).Return([]string{importKey}, nil)
+
+	result, err = service.ImportTasks(context.Background(), dto.ImportTasksRequest{Timezone: "Europe/Moscow"}, strings.NewReader(clockifyExport))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Created)
+	assert.Equal(t, 1, result.Duplicates)
+}
+
+func TestImportTasks_InvalidFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, _, _, _, _ := newImportService(ctrl)
+
+	result, err := service.ImportTasks(context.Background(), dto.ImportTasksRequest{}, strings.NewReader("name,hours\nDesign,2\n"))
+	assert.ErrorIs(t, err, models.ErrInvalidImportFile)
+	assert.Nil(t, result)
+
+	result, err = service.ImportTasks(context.Background(), dto.ImportTasksRequest{Timezone: "Mars/Olympus"}, strings.NewReader(togglExport))
+	assert.ErrorIs(t, err, models.ErrInvalidTimezone)
+	assert.Nil(t, result)
+}
+
+const clockifyDayFirstExport = `Project,Client,Description,Task,User,Group,Email,Tags,Billable,Start Date,Start Time,End Date,End Time,Duration (h),Duration (decimal)
+,,Standup,,Ivanov Ivan,,ivan@example.com,,No,03/04/2024,09:30:00,03/04/2024,09:45:00,00:15:00,0.25
+,,Review,,Ivanov Ivan,,ivan@example.com,,No,13/04/2024,10:00:00,13/04/2024,11:00:00,01:00:00,1.00
+`
+
+const clockifyAmbiguousExport = `Project,Client,Description,Task,User,Group,Email,Tags,Billable,Start Date,Start Time,End Date,End Time,Duration (h),Duration (decimal)
+,,Standup,,Ivanov Ivan,,ivan@example.com,,No,03/04/2024,09:30:00,03/04/2024,09:45:00,00:15:00,0.25
+,,Review,,Ivanov Ivan,,ivan@example.com,,No,05/06/2024,10:00:00,05/06/2024,11:00:00,01:00:00,1.00
+`
+
+func TestImportTasks_DetectsDayFirstDatesForWholeFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockTaskRepo, mockUserRepo, mockProjectRepo, mockClientRepo := newImportService(ctrl)
+
+	mockUserRepo.EXPECT().GetAll().Return([]models.User{{ID: 1, Name: "Ivan", Surname: "Ivanov"}}, nil)
+	mockClientRepo.EXPECT().GetAll().Return(nil, nil)
+	mockProjectRepo.EXPECT().GetAll(nil, true).Return(nil, nil)
+	mockTaskRepo.EXPECT().GetImportedKeys(gomock.Len(2)).Return(nil, nil)
+
+	result, err := service.ImportTasks(context.Background(), dto.ImportTasksRequest{DryRun: true}, strings.NewReader(clockifyDayFirstExport))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Created)
+	assert.Equal(t, "2024-04-03T09:30:00Z", result.Rows[0].StartTime)
+	assert.Equal(t, "2024-04-13T10:00:00Z", result.Rows[1].StartTime)
+}
+
+func TestImportTasks_RejectsAmbiguousDates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, _, _, _, _ := newImportService(ctrl)
+
+	result, err := service.ImportTasks(context.Background(), dto.ImportTasksRequest{DryRun: true}, strings.NewReader(clockifyAmbiguousExport))
+
+	assert.ErrorIs(t, err, models.ErrInvalidImportFile)
+	assert.Nil(t, result)
+}
+
+func TestImportTasks_AmbiguousDatesWithDateFormat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockTaskRepo, mockUserRepo, mockProjectRepo, mockClientRepo := newImportService(ctrl)
+
+	mockUserRepo.EXPECT().GetAll().Return([]models.User{{ID: 1, Name: "Ivan", Surname: "Ivanov"}}, nil)
+	mockClientRepo.EXPECT().GetAll().Return(nil, nil)
+	mockProjectRepo.EXPECT().GetAll(nil, true).Return(nil, nil)
+	mockTaskRepo.EXPECT().GetImportedKeys(gomock.Len(2)).Return(nil, nil)
+
+	result, err := service.ImportTasks(context.Background(), dto.ImportTasksRequest{DryRun: true, DateFormat: "DD/MM/YYYY"},
+		strings.NewReader(clockifyAmbiguousExport))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-04-03T09:30:00Z", result.Rows[0].StartTime)
+	assert.Equal(t, "2024-06-05T10:00:00Z", result.Rows[1].StartTime)
+}
@@ -0,0 +1,197 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateInvoice_MostSpecificRateWins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvoiceRepo := repositories.NewMockInvoiceRepository(ctrl)
+	mockRateRepo := repositories.NewMockRateRepository(ctrl)
+	mockClientRepo := repositories.NewMockClientRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewInvoiceServiceImpl(mockInvoiceRepo, mockRateRepo, mockClientRepo, newRoundingPolicyRepository(ctrl), logger)
+
+	clientID, projectID, userID := uint(2), uint(3), uint(1)
+	periodStart := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)
+
+	tasks := []models.Task{
+		{ID: 10, UserID: userID, ProjectID: &projectID, TaskName: "Design", Minutes: 90,
+			StartTime: time.Date(2024, 7, 5, 9, 0, 0, 0, time.UTC)},
+		{ID: 11, UserID: userID, ProjectID: &projectID, TaskName: "Review", Minutes: 20,
+			StartTime: time.Date(2024, 7, 20, 9, 0, 0, 0, time.UTC)},
+	}
+	rates := []models.Rate{
+		{ID: 1, HourlyRateCents: 5000, Currency: "EUR", EffectiveFrom: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, ClientID: &clientID, HourlyRateCents: 8000, Currency: "EUR", EffectiveFrom: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 3, ProjectID: &projectID, HourlyRateCents: 10000, Currency: "EUR", EffectiveFrom: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 4, ProjectID: &projectID, HourlyRateCents: 12000, Currency: "EUR", EffectiveFrom: time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC)},
+	}
+
+	mockClientRepo.EXPECT().GetById(clientID).Return(&models.Client{ID: clientID, Name: "Acme"}, nil)
+	mockInvoiceRepo.EXPECT().GetUnbilledTasks(clientID, periodStart, periodEnd.AddDate(0, 0, 1)).Return(tasks, nil)
+	mockRateRepo.EXPECT().GetForClient(clientID).Return(rates, nil)
+	mockInvoiceRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(invoice *models.Invoice) error {
+		invoice.ID = 1
+		invoice.Number = "INV-000001"
+		return nil
+	})
+
+	invoiceResponse, err := service.CreateInvoice(dto.CreateInvoiceRequest{
+		ClientID:    clientID,
+		PeriodStart: "2024-07-01",
+		PeriodEnd:   "2024-07-31",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "INV-000001", invoiceResponse.Number)
+	assert.Equal(t, "EUR", invoiceResponse.Currency)
+	assert.Len(t, invoiceResponse.Lines, 2)
+	assert.Equal(t, int64(10000), invoiceResponse.Lines[0].HourlyRateCents)
+	assert.Equal(t, int64(15000), invoiceResponse.Lines[0].AmountCents)
+	assert.Equal(t, int64(12000), invoiceResponse.Lines[1].HourlyRateCents)
+	assert.Equal(t, int64(4000), invoiceResponse.Lines[1].AmountCents)
+	assert.Equal(t, int64(19000), invoiceResponse.TotalCents)
+}
+
+func TestCreateInvoice_BillsRoundedMinutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvoiceRepo := repositories.NewMockInvoiceRepository(ctrl)
+	mockRateRepo := repositories.NewMockRateRepository(ctrl)
+	mockClientRepo := repositories.NewMockClientRepository(ctrl)
+	logger := logrus.New()
+
+	clientID, projectID := uint(2), uint(3)
+	mockRoundingRepo := newRoundingPolicyRepository(ctrl,
+		models.RoundingPolicy{ID: 1, ClientID: &clientID, IncrementMinutes: 15, Mode: models.RoundingModeUp},
+	)
+
+	service := services.NewInvoiceServiceImpl(mockInvoiceRepo, mockRateRepo, mockClientRepo, mockRoundingRepo, logger)
+
+	tasks := []models.Task{
+		{ID: 10, UserID: 1, ProjectID: &projectID, TaskName: "Design", Minutes: 50,
+			StartTime: time.Date(2024, 7, 5, 9, 0, 0, 0, time.UTC)},
+	}
+	rates := []models.Rate{
+		{ID: 1, ClientID: &clientID, HourlyRateCents: 8000, Currency: "EUR", EffectiveFrom: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	mockClientRepo.EXPECT().GetById(clientID).Return(&models.Client{ID: clientID, Name: "Acme"}, nil)
+	mockInvoiceRepo.EXPECT().GetUnbilledTasks(clientID, gomock.Any(), gomock.Any()).Return(tasks, nil)
+	mockRateRepo.EXPECT().GetForClient(clientID).Return(rates, nil)
+	mockInvoiceRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	invoiceResponse, err := service.CreateInvoice(dto.CreateInvoiceRequest{
+		ClientID:    clientID,
+		PeriodStart: "2024-07-01",
+		PeriodEnd:   "2024-07-31",
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, invoiceResponse.Lines, 1)
+	assert.Equal(t, 50, invoiceResponse.Lines[0].Minutes)
+	assert.Equal(t, 60, invoiceResponse.Lines[0].RoundedMinutes)
+	assert.Equal(t, int64(8000), invoiceResponse.TotalCents)
+}
+
+func TestCreateInvoice_NoApplicableRate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvoiceRepo := repositories.NewMockInvoiceRepository(ctrl)
+	mockRateRepo := repositories.NewMockRateRepository(ctrl)
+	mockClientRepo := repositories.NewMockClientRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewInvoiceServiceImpl(mockInvoiceRepo, mockRateRepo, mockClientRepo, newRoundingPolicyRepository(ctrl), logger)
+
+	clientID, projectID := uint(2), uint(3)
+	tasks := []models.Task{
+		{ID: 10, UserID: 1, ProjectID: &projectID, TaskName: "Design", Minutes: 60,
+			StartTime: time.Date(2024, 7, 5, 9, 0, 0, 0, time.UTC)},
+	}
+	rates := []models.Rate{
+		{ID: 1, ClientID: &clientID, HourlyRateCents: 8000, Currency: "EUR", EffectiveFrom: time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	mockClientRepo.EXPECT().GetById(clientID).Return(&models.Client{ID: clientID, Name: "Acme"}, nil)
+	mockInvoiceRepo.EXPECT().GetUnbilledTasks(clientID, gomock.Any(), gomock.Any()).Return(tasks, nil)
+	mockRateRepo.EXPECT().GetForClient(clientID).Return(rates, nil)
+
+	invoiceResponse, err := service.CreateInvoice(dto.CreateInvoiceRequest{
+		ClientID:    clientID,
+		PeriodStart: "2024-07-01",
+		PeriodEnd:   "2024-07-31",
+	})
+
+	assert.ErrorIs(t, err, models.ErrRateNotFound)
+	assert.Nil(t, invoiceResponse)
+}
+
+func TestCreateInvoice_NothingToInvoice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvoiceRepo := repositories.NewMockInvoiceRepository(ctrl)
+	mockRateRepo := repositories.NewMockRateRepository(ctrl)
+	mockClientRepo := repositories.NewMockClientRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewInvoiceServiceImpl(mockInvoiceRepo, mockRateRepo, mockClientRepo, newRoundingPolicyRepository(ctrl), logger)
+
+	clientID := uint(2)
+	mockClientRepo.EXPECT().GetById(clientID).Return(&models.Client{ID: clientID, Name: "Acme"}, nil)
+	mockInvoiceRepo.EXPECT().GetUnbilledTasks(clientID, gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	invoiceResponse, err := service.CreateInvoice(dto.CreateInvoiceRequest{
+		ClientID:    clientID,
+		PeriodStart: "2024-07-01",
+		PeriodEnd:   "2024-07-31",
+	})
+
+	assert.ErrorIs(t, err, models.ErrNothingToInvoice)
+	assert.Nil(t, invoiceResponse)
+}
+
+func TestCreateInvoice_InvalidPeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvoiceRepo := repositories.NewMockInvoiceRepository(ctrl)
+	mockRateRepo := repositories.NewMockRateRepository(ctrl)
+	mockClientRepo := repositories.NewMockClientRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewInvoiceServiceImpl(mockInvoiceRepo, mockRateRepo, mockClientRepo, newRoundingPolicyRepository(ctrl), logger)
+
+	invoiceResponse, err := service.CreateInvoice(dto.CreateInvoiceRequest{
+		ClientID:    2,
+		PeriodStart: "2024-07-31",
+		PeriodEnd:   "2024-07-01",
+	})
+	assert.ErrorIs(t, err, models.ErrInvalidPeriod)
+	assert.Nil(t, invoiceResponse)
+
+	invoiceResponse, err = service.CreateInvoice(dto.CreateInvoiceRequest{
+		ClientID:    2,
+		PeriodStart: "01.07.2024",
+		PeriodEnd:   "2024-07-31",
+	})
+	assert.ErrorIs(t, err, models.ErrInvalidDateFormat)
+	assert.Nil(t, invoiceResponse)
+}
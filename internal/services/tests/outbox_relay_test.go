@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/eventbus"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+// publishOutboxEvents makes the mock hand the events to the relay as the repository does.
+func publishOutboxEvents(mockRepo *repositories.MockOutboxRepository, events ...*models.OutboxEvent) *gomock.Call {
+	return mockRepo.EXPECT().PublishPending(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(now time.Time, limit int, publish func(event *models.OutboxEvent)) (int, error) {
+			for _, event := range events {
+				publish(event)
+			}
+			return len(events), nil
+		})
+}
+
+func TestOutboxRelay_PublishesToSubscribers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockOutboxRepository(ctrl)
+	bus := eventbus.NewBus()
+	relay := services.NewOutboxRelay(mockRepo, bus, time.Second, logrus.New())
+
+	var started, all []uint64
+	bus.Subscribe(models.EventTaskStarted, func(ctx context.Context, event *models.OutboxEvent) error {
+		started = append(started, event.ID)
+		return nil
+	})
+	bus.SubscribeAll(func(ctx context.Context, event *models.OutboxEvent) error {
+		all = append(all, event.ID)
+		return nil
+	})
+
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	startedEvent := &models.OutboxEvent{ID: 1, Event: models.EventTaskStarted, AggregateID: 7, Payload: `{"task_id":7}`}
+	deletedEvent := &models.OutboxEvent{ID: 2, Event: models.EventUserDeleted, AggregateID: 3, Payload: `{"user_id":3}`}
+	publishOutboxEvents(mockRepo, startedEvent, deletedEvent)
+
+	count, err := relay.PublishPending(context.Background(), now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []uint64{1}, started)
+	assert.Equal(t, []uint64{1, 2}, all)
+	assert.Equal(t, now, *startedEvent.PublishedAt)
+	assert.Equal(t, now, *deletedEvent.PublishedAt)
+	assert.Equal(t, 0, startedEvent.Attempts)
+}
+
+func TestOutboxRelay_RetriesFailedEventsWithBackoff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockOutboxRepository(ctrl)
+	bus := eventbus.NewBus()
+	relay := services.NewOutboxRelay(mockRepo, bus, 5*time.Second, logrus.New())
+
+	var calls int
+	bus.SubscribeAll(func(ctx context.Context, event *models.OutboxEvent) error {
+		return errors.New("subscriber unavailable")
+	})
+	bus.SubscribeAll(func(ctx context.Context, event *models.OutboxEvent) error {
+		calls++
+		return nil
+	})
+
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	event := &models.OutboxEvent{ID: 1, Event: models.EventTaskStopped, Attempts: 2}
+	publishOutboxEvents(mockRepo, event)
+
+	_, err := relay.PublishPending(context.Background(), now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Nil(t, event.PublishedAt)
+	assert.Equal(t, 3, event.Attempts)
+	assert.Contains(t, *event.LastError, "subscriber unavailable")
+	assert.Equal(t, now.Add(20*time.Second), *event.NextAttemptAt)
+}
+
+func TestOutboxRelay_RepositoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockOutboxRepository(ctrl)
+	relay := services.NewOutboxRelay(mockRepo, eventbus.NewBus(), time.Second, logrus.New())
+
+	expectedError := errors.New("database error")
+	mockRepo.EXPECT().PublishPending(gomock.Any(), gomock.Any(), gomock.Any()).Return(0, expectedError)
+
+	count, err := relay.PublishPending(context.Background(), time.Now())
+
+	assert.Equal(t, expectedError, err)
+	assert.Equal(t, 0, count)
+}
@@ -0,0 +1,137 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/audit"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPersonalData_CollectsProfileTasksAndAuditEntries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	mockTaskRepo := repositories.NewMockTaskRepository(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepository(ctrl)
+	service := services.NewPersonalDataServiceImpl(mockUserRepo, mockTaskRepo, mockAuditRepo, logrus.New())
+
+	mockUserRepo.EXPECT().GetById(uint(4)).Return(&models.User{ID: 4, Name: "Ivan", TimeZone: "UTC"}, nil)
+	mockUserRepo.EXPECT().OpenPassport(gomock.Any()).DoAndReturn(func(user *models.User) error {
+		user.PassportNumber = "1234 567890"
+		return nil
+	})
+	start := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	mockTaskRepo.EXPECT().GetUserTasksInBatches(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(filter repositories.TaskFilter, batchSize int, visit func(tasks []models.Task) error) error {
+			assert.Equal(t, uint(4), filter.UserID)
+			assert.True(t, filter.StartDate.IsZero())
+			assert.True(t, filter.WithSegments)
+			return visit([]models.Task{{ID: 7, UserID: 4, TaskName: "Report", StartTime: start, EndTime: start.Add(time.Hour)}})
+		})
+	mockAuditRepo.EXPECT().GetUserEntriesInBatches(uint(4), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(userID uint, batchSize int, visit func(entries []models.AuditEntry) error) error {
+			return visit([]models.AuditEntry{{ID: 1, Action: models.AuditActionCreate, EntityType: models.AuditEntityUser, EntityID: 4, Changes: `{}`}})
+		})
+
+	data, err := service.GetPersonalData(audit.NewContext(context.Background(), audit.Actor{Admin: true}), 4)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1234 567890", data.Profile.PassportNumber)
+	assert.Len(t, data.Tasks, 1)
+	assert.Equal(t, uint(7), data.Tasks[0].ID)
+	assert.Len(t, data.AuditEntries, 1)
+	assert.Nil(t, data.Erasure)
+}
+
+func TestGetPersonalData_MasksPassportWithoutAdminToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	mockTaskRepo := repositories.NewMockTaskRepository(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepository(ctrl)
+	service := services.NewPersonalDataServiceImpl(mockUserRepo, mockTaskRepo, mockAuditRepo, logrus.New())
+
+	mockUserRepo.EXPECT().GetById(uint(4)).Return(&models.User{ID: 4, Name: "Ivan", TimeZone: "UTC"}, nil)
+	mockUserRepo.EXPECT().OpenPassport(gomock.Any()).DoAndReturn(func(user *models.User) error {
+		user.PassportNumber = "1234 567890"
+		return nil
+	})
+	mockTaskRepo.EXPECT().GetUserTasksInBatches(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	mockAuditRepo.EXPECT().GetUserEntriesInBatches(uint(4), gomock.Any(), gomock.Any()).Return(nil)
+
+	actorID := uint(4)
+	data, err := service.GetPersonalData(audit.NewContext(context.Background(), audit.Actor{UserID: &actorID}), 4)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "12** ****90", data.Profile.PassportNumber)
+}
+
+func TestEraseUser_RecordsProof(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	service := services.NewPersonalDataServiceImpl(mockUserRepo, repositories.NewMockTaskRepository(ctrl),
+		repositories.NewMockAuditRepository(ctrl), logrus.New())
+
+	erasedAt := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	mockUserRepo.EXPECT().Erase(gomock.Any(), uint(4), gomock.Any()).DoAndReturn(func(_ context.Context, id uint, erasure *models.Erasure) (*models.User, error) {
+		erasure.ID = 1
+		erasure.UserID = id
+		erasure.Fields = "passport_number,surname,name"
+		erasure.AuditEntriesRedacted = 2
+		erasure.ErasedAt = erasedAt
+		return &models.User{ID: id, PassportNumber: "erased-4", ErasedAt: &erasedAt}, nil
+	})
+
+	actorID := uint(9)
+	ctx := audit.NewContext(context.Background(), audit.Actor{UserID: &actorID, RequestID: "req-3"})
+	erasure, err := service.EraseUser(ctx, 4)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(4), erasure.UserID)
+	assert.Equal(t, &actorID, erasure.ActorID)
+	assert.Equal(t, "req-3", erasure.RequestID)
+	assert.Equal(t, []string{"passport_number", "surname", "name"}, erasure.Fields)
+	assert.Equal(t, 2, erasure.AuditEntriesRedacted)
+	assert.Equal(t, "2024-06-03T09:00:00Z", erasure.ErasedAt)
+}
+
+func TestEraseUser_AlreadyErased(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	service := services.NewPersonalDataServiceImpl(mockUserRepo, repositories.NewMockTaskRepository(ctrl),
+		repositories.NewMockAuditRepository(ctrl), logrus.New())
+
+	mockUserRepo.EXPECT().Erase(gomock.Any(), uint(4), gomock.Any()).Return(nil, models.ErrUserErased)
+
+	erasure, err := service.EraseUser(context.Background(), 4)
+
+	assert.ErrorIs(t, err, models.ErrUserErased)
+	assert.Nil(t, erasure)
+}
+
+func TestAuditRedact_ReplacesPersonalFields(t *testing.T) {
+	changes := `{"name":{"before":"Ivan","after":"Pyotr"},"role":{"before":"user","after":"admin"}}`
+
+	redacted, ok, err := audit.Redact(changes, models.PersonalDataFields)
+
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.JSONEq(t, `{"name":{"before":"[erased]","after":"[erased]"},"role":{"before":"user","after":"admin"}}`, redacted)
+
+	_, ok, err = audit.Redact(`{"role":{"before":"user","after":"admin"}}`, models.PersonalDataFields)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateProject_WithClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProjectRepo := repositories.NewMockProjectRepository(ctrl)
+	mockClientRepo := repositories.NewMockClientRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewProjectServiceImpl(mockProjectRepo, mockClientRepo, logger)
+
+	clientID := uint(2)
+	client := &models.Client{ID: clientID, Name: "Acme"}
+
+	mockClientRepo.EXPECT().GetById(clientID).Return(client, nil)
+	mockProjectRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(project *models.Project) error {
+		project.ID = 1
+		return nil
+	})
+
+	projectResponse, err := service.CreateProject(dto.CreateProjectRequest{Name: "Website", ClientID: &clientID})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), projectResponse.ID)
+	assert.Equal(t, "Website", projectResponse.Name)
+	assert.Equal(t, &clientID, projectResponse.ClientID)
+	assert.Equal(t, "Acme", projectResponse.ClientName)
+	assert.False(t, projectResponse.Archived)
+}
+
+func TestCreateProject_UnknownClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProjectRepo := repositories.NewMockProjectRepository(ctrl)
+	mockClientRepo := repositories.NewMockClientRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewProjectServiceImpl(mockProjectRepo, mockClientRepo, logger)
+
+	clientID := uint(2)
+	mockClientRepo.EXPECT().GetById(clientID).Return(nil, gorm.ErrRecordNotFound)
+
+	projectResponse, err := service.CreateProject(dto.CreateProjectRequest{Name: "Website", ClientID: &clientID})
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Nil(t, projectResponse)
+}
+
+func TestUpdateProject_Archive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProjectRepo := repositories.NewMockProjectRepository(ctrl)
+	mockClientRepo := repositories.NewMockClientRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewProjectServiceImpl(mockProjectRepo, mockClientRepo, logger)
+
+	project := &models.Project{ID: 1, Name: "Website"}
+
+	mockProjectRepo.EXPECT().GetById(uint(1)).Return(project, nil)
+	mockProjectRepo.EXPECT().Update(project).Return(nil)
+
+	projectResponse, err := service.UpdateProject(1, dto.UpdateProjectRequest{Name: "Website", Archived: true})
+
+	assert.NoError(t, err)
+	assert.True(t, projectResponse.Archived)
+	assert.Nil(t, projectResponse.ClientID)
+}
+
+func TestDeleteProject_InUse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProjectRepo := repositories.NewMockProjectRepository(ctrl)
+	mockClientRepo := repositories.NewMockClientRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewProjectServiceImpl(mockProjectRepo, mockClientRepo, logger)
+
+	mockProjectRepo.EXPECT().Delete(uint(1)).Return(models.ErrProjectInUse)
+
+	err := service.DeleteProject(1)
+
+	assert.True(t, errors.Is(err, models.ErrProjectInUse))
+}
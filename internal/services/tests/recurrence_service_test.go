@@ -0,0 +1,161 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateDueEntries_WeeklyAcrossDaylightSavingChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecurrenceRepo := repositories.NewMockRecurrenceRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewRecurrenceServiceImpl(mockRecurrenceRepo, repositories.NewMockTaskTemplateRepository(ctrl),
+		newUserRepositoryInZone(ctrl, "Europe/Berlin"), 24*time.Hour, logger)
+
+	recurrence := models.Recurrence{
+		ID:         5,
+		TemplateID: 2,
+		Template: &models.TaskTemplate{
+			ID: 2, UserID: 1, TaskName: "Standup", Billable: true, DurationMinutes: 15,
+			Tags: []models.Tag{{ID: 4, Name: "meetings"}},
+		},
+		Rule:      "FREQ=WEEKLY;BYDAY=MO,WE",
+		StartDate: time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC),
+		StartTime: "09:30",
+		Mode:      models.RecurrenceModeActual,
+		Skips:     []models.RecurrenceSkip{{RecurrenceID: 5, Date: time.Date(2024, 3, 27, 0, 0, 0, 0, time.UTC)}},
+	}
+	mockRecurrenceRepo.EXPECT().GetAll(nil).Return([]models.Recurrence{recurrence}, nil)
+
+	// Berlin switches to summer time on 2024-03-31; occurrences keep 09:30 local time.
+	var created []time.Time
+	mockRecurrenceRepo.EXPECT().CreateOccurrence(gomock.Any(), uint(5), gomock.Any(), false).
+		DoAndReturn(func(_ context.Context, recurrenceID uint, task *models.Task, planned bool) error {
+			assert.Equal(t, uint(1), task.UserID)
+			assert.Equal(t, "Standup", task.TaskName)
+			assert.True(t, task.Billable)
+			assert.Equal(t, "meetings", task.Tags[0].Name)
+			assert.Equal(t, 15*time.Minute, task.EndTime.Sub(task.StartTime))
+			created = append(created, task.StartTime.UTC())
+			return nil
+		}).Times(2)
+	mockRecurrenceRepo.EXPECT().PassOccurrence(uint(5), gomock.Any()).
+		DoAndReturn(func(recurrenceID uint, occurrence time.Time) error {
+			assert.Equal(t, time.Date(2024, 3, 27, 8, 30, 0, 0, time.UTC), occurrence.UTC())
+			return nil
+		})
+
+	// The occurrence of 2024-04-03 has not ended yet.
+	count, err := service.CreateDueEntries(context.Background(), time.Date(2024, 4, 3, 7, 40, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []time.Time{
+		time.Date(2024, 3, 25, 8, 30, 0, 0, time.UTC),
+		time.Date(2024, 4, 1, 7, 30, 0, 0, time.UTC),
+	}, created)
+}
+
+func TestCreateDueEntries_PlannedPassesOverlappingOccurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecurrenceRepo := repositories.NewMockRecurrenceRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewRecurrenceServiceImpl(mockRecurrenceRepo, repositories.NewMockTaskTemplateRepository(ctrl),
+		newUserRepositoryInZone(ctrl, "UTC"), 48*time.Hour, logger)
+
+	generatedUntil := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+	recurrence := models.Recurrence{
+		ID:             6,
+		TemplateID:     2,
+		Template:       &models.TaskTemplate{ID: 2, UserID: 1, TaskName: "Review", DurationMinutes: 60},
+		Rule:           "FREQ=DAILY;COUNT=5",
+		StartDate:      time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+		StartTime:      "09:00",
+		Mode:           models.RecurrenceModePlanned,
+		GeneratedUntil: &generatedUntil,
+	}
+	mockRecurrenceRepo.EXPECT().GetAll(nil).Return([]models.Recurrence{recurrence}, nil)
+
+	gomock.InOrder(
+		mockRecurrenceRepo.EXPECT().CreateOccurrence(gomock.Any(), uint(6), gomock.Any(), true).
+			DoAndReturn(func(_ context.Context, recurrenceID uint, task *models.Task, planned bool) error {
+				assert.Equal(t, time.Date(2024, 7, 2, 9, 0, 0, 0, time.UTC), task.StartTime)
+				return models.ErrTaskOverlap
+			}),
+		mockRecurrenceRepo.EXPECT().PassOccurrence(uint(6), time.Date(2024, 7, 2, 9, 0, 0, 0, time.UTC)).Return(nil),
+		mockRecurrenceRepo.EXPECT().CreateOccurrence(gomock.Any(), uint(6), gomock.Any(), true).
+			DoAndReturn(func(_ context.Context, recurrenceID uint, task *models.Task, planned bool) error {
+				assert.Equal(t, time.Date(2024, 7, 3, 9, 0, 0, 0, time.UTC), task.StartTime)
+				return nil
+			}),
+	)
+
+	count, err := service.CreateDueEntries(context.Background(), time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestCreateRecurrence_InvalidRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := logrus.New()
+	service := services.NewRecurrenceServiceImpl(repositories.NewMockRecurrenceRepository(ctrl),
+		repositories.NewMockTaskTemplateRepository(ctrl), repositories.NewMockUserRepository(ctrl), time.Hour, logger)
+
+	for _, rule := range []string{"FREQ=YEARLY", "FREQ=WEEKLY;BYDAY=XX", "FREQ=DAILY;COUNT=3;UNTIL=20240801", "INTERVAL=2"} {
+		recurrence, err := service.CreateRecurrence(dto.CreateRecurrenceRequest{
+			TemplateID: 2, Rule: rule, StartDate: "2024-07-01", StartTime: "09:00", Mode: models.RecurrenceModeActual,
+		})
+		assert.ErrorIs(t, err, models.ErrInvalidRecurrenceRule, rule)
+		assert.Nil(t, recurrence)
+	}
+}
+
+func TestSkipOccurrence_InUserTimeZone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRecurrenceRepo := repositories.NewMockRecurrenceRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewRecurrenceServiceImpl(mockRecurrenceRepo, repositories.NewMockTaskTemplateRepository(ctrl),
+		newUserRepositoryInZone(ctrl, "America/New_York"), time.Hour, logger)
+
+	mockRecurrenceRepo.EXPECT().GetById(uint(5)).Return(&models.Recurrence{
+		ID:        5,
+		Template:  &models.TaskTemplate{ID: 2, UserID: 1},
+		Rule:      "FREQ=DAILY",
+		StartDate: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+		StartTime: "09:00",
+		Mode:      models.RecurrenceModePlanned,
+	}, nil)
+	mockRecurrenceRepo.EXPECT().AddSkip(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(skip *models.RecurrenceSkip, from, to time.Time) error {
+			assert.Equal(t, uint(5), skip.RecurrenceID)
+			assert.Equal(t, time.Date(2024, 7, 4, 4, 0, 0, 0, time.UTC), from.UTC())
+			assert.Equal(t, time.Date(2024, 7, 5, 4, 0, 0, 0, time.UTC), to.UTC())
+			return nil
+		})
+
+	recurrence, err := service.SkipOccurrence(5, dto.SkipOccurrenceRequest{Date: "2024-07-04"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2024-07-04"}, recurrence.SkippedDates)
+}
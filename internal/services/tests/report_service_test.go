@@ -0,0 +1,269 @@
+package tests
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/export"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestGetUserSummary_ByDay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReportRepo := repositories.NewMockReportRepository(ctrl)
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	mockTeamRepo := repositories.NewMockTeamRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewReportServiceImpl(mockReportRepo, mockUserRepo, mockTeamRepo, newRoundingPolicyRepository(ctrl), logger)
+
+	mockUserRepo.EXPECT().GetById(uint(1)).Return(&models.User{ID: 1}, nil)
+	mockReportRepo.EXPECT().GetUserSummary(repositories.SummaryFilter{
+		UserID:  1,
+		From:    time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC),
+		GroupBy: repositories.ReportDimensionDay,
+	}).Return([]repositories.SummaryTaskRow{
+		{GroupKey: "2024-07-01", TaskID: 1, Seconds: 18000},
+		{GroupKey: "2024-07-01", TaskID: 2, Seconds: 9000},
+		{GroupKey: "2024-07-02", TaskID: 3, Seconds: 3725},
+	}, nil)
+
+	summary, err := service.GetUserSummary(1, dto.GetUserSummaryRequest{From: "2024-07-01", To: "2024-07-07"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, repositories.ReportDimensionDay, summary.GroupBy)
+	assert.Len(t, summary.Groups, 2)
+	assert.Equal(t, int64(2), summary.Groups[0].TaskCount)
+	assert.Equal(t, "7:30:00", summary.Groups[0].Duration)
+	assert.Equal(t, "1:02:05", summary.Groups[1].Duration)
+	assert.Equal(t, int64(30725), summary.TotalSeconds)
+	assert.Equal(t, "8:32:05", summary.TotalDuration)
+}
+
+func TestGetUserSummary_RoundsEveryTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReportRepo := repositories.NewMockReportRepository(ctrl)
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	mockTeamRepo := repositories.NewMockTeamRepository(ctrl)
+	logger := logrus.New()
+
+	projectID, otherProjectID, clientID := uint(3), uint(4), uint(2)
+	mockRoundingRepo := newRoundingPolicyRepository(ctrl,
+		models.RoundingPolicy{ID: 1, IncrementMinutes: 6, Mode: models.RoundingModeNearest},
+		models.RoundingPolicy{ID: 2, ClientID: &clientID, IncrementMinutes: 15, Mode: models.RoundingModeDown},
+		models.RoundingPolicy{ID: 3, ProjectID: &projectID, IncrementMinutes: 15, Mode: models.RoundingModeUp},
+	)
+
+	service := services.NewReportServiceImpl(mockReportRepo, mockUserRepo, mockTeamRepo, mockRoundingRepo, logger)
+
+	mockUserRepo.EXPECT().GetById(uint(1)).Return(&models.User{ID: 1}, nil)
+	mockReportRepo.EXPECT().GetUserSummary(gomock.Any()).Return([]repositories.SummaryTaskRow{
+		{GroupKey: "2024-07-01", TaskID: 1, ProjectID: &projectID, ClientID: &clientID, Seconds: 60},
+		{GroupKey: "2024-07-01", TaskID: 2, ProjectID: &otherProjectID, ClientID: &clientID, Seconds: 1740},
+		{GroupKey: "2024-07-02", TaskID: 3, Seconds: 200},
+	}, nil)
+
+	summary, err := service.GetUserSummary(1, dto.GetUserSummaryRequest{From: "2024-07-01", To: "2024-07-07"})
+
+	assert.NoError(t, err)
+	assert.Len(t, summary.Groups, 2)
+	assert.Equal(t, int64(1800), summary.Groups[0].Seconds)
+	assert.Equal(t, int64(1800), summary.Groups[0].RoundedSeconds)
+	assert.Equal(t, int64(200), summary.Groups[1].Seconds)
+	assert.Equal(t, int64(360), summary.Groups[1].RoundedSeconds)
+	assert.Equal(t, int64(2000), summary.TotalSeconds)
+	assert.Equal(t, int64(2160), summary.RoundedTotalSeconds)
+	assert.Equal(t, "0:36:00", summary.RoundedTotalDuration)
+}
+
+func TestGetUserSummary_UnknownUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReportRepo := repositories.NewMockReportRepository(ctrl)
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	mockTeamRepo := repositories.NewMockTeamRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewReportServiceImpl(mockReportRepo, mockUserRepo, mockTeamRepo, newRoundingPolicyRepository(ctrl), logger)
+
+	mockUserRepo.EXPECT().GetById(uint(1)).Return(nil, gorm.ErrRecordNotFound)
+
+	summary, err := service.GetUserSummary(1, dto.GetUserSummaryRequest{From: "2024-07-01", To: "2024-07-07", GroupBy: "month"})
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Nil(t, summary)
+}
+
+func TestGetUserSummary_InvalidPeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReportRepo := repositories.NewMockReportRepository(ctrl)
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	mockTeamRepo := repositories.NewMockTeamRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewReportServiceImpl(mockReportRepo, mockUserRepo, mockTeamRepo, newRoundingPolicyRepository(ctrl), logger)
+
+	summary, err := service.GetUserSummary(1, dto.GetUserSummaryRequest{From: "2024-07-07", To: "2024-07-01"})
+
+	assert.ErrorIs(t, err, models.ErrInvalidPeriod)
+	assert.Nil(t, summary)
+}
+
+func TestGetPivotReport_TeamByUserAndDay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReportRepo := repositories.NewMockReportRepository(ctrl)
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	mockTeamRepo := repositories.NewMockTeamRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewReportServiceImpl(mockReportRepo, mockUserRepo, mockTeamRepo, newRoundingPolicyRepository(ctrl), logger)
+
+	teamID := uint(4)
+	ivanID, petrID := uint(1), uint(2)
+	mockTeamRepo.EXPECT().GetById(teamID).Return(&models.Team{
+		ID:      teamID,
+		Members: []models.User{{ID: 2}, {ID: 3}},
+	}, nil)
+
+	filter := repositories.PivotFilter{
+		UserIDs: []uint{1, 2, 3},
+		From:    time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2024, 7, 3, 0, 0, 0, 0, time.UTC),
+		Rows:    repositories.ReportDimensionUser,
+		Columns: repositories.ReportDimensionDay,
+		Offset:  0,
+		Limit:   50,
+	}
+	mockReportRepo.EXPECT().CountPivotRows(filter).Return(int64(2), nil)
+	mockReportRepo.EXPECT().GetPivotColumns(filter).Return([]repositories.SummaryRow{
+		{GroupKey: "2024-07-01", TaskCount: 3, Seconds: 10800},
+		{GroupKey: "2024-07-02", TaskCount: 1, Seconds: 1800},
+	}, nil)
+	mockReportRepo.EXPECT().GetPivotCells(filter, gomock.Any()).DoAndReturn(
+		func(filter repositories.PivotFilter, visit func(cell repositories.PivotCell) error) error {
+			cells := []repositories.PivotCell{
+				{RowID: &ivanID, RowKey: "Ivanov Ivan", ColumnKey: "2024-07-01", Seconds: 7200},
+				{RowID: &ivanID, RowKey: "Ivanov Ivan", ColumnKey: "2024-07-02", Seconds: 1800},
+				{RowID: &petrID, RowKey: "Petrov Petr", ColumnKey: "2024-07-01", Seconds: 3600},
+			}
+			for _, cell := range cells {
+				if err := visit(cell); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	report, err := service.GetPivotReport(dto.GetPivotReportRequest{
+		UserIDs: []string{"1,2"},
+		TeamID:  &teamID,
+		From:    "2024-07-01",
+		To:      "2024-07-02",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), report.TotalRows)
+	assert.Len(t, report.Columns, 2)
+	assert.Len(t, report.Rows, 2)
+	assert.Equal(t, []int64{7200, 1800}, report.Rows[0].Cells)
+	assert.Equal(t, "2:30:00", report.Rows[0].TotalDuration)
+	assert.Equal(t, []int64{3600, 0}, report.Rows[1].Cells)
+	assert.Equal(t, &petrID, report.Rows[1].ID)
+	assert.Equal(t, int64(12600), report.TotalSeconds)
+}
+
+func TestGetPivotReport_InvalidRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReportRepo := repositories.NewMockReportRepository(ctrl)
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	mockTeamRepo := repositories.NewMockTeamRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewReportServiceImpl(mockReportRepo, mockUserRepo, mockTeamRepo, newRoundingPolicyRepository(ctrl), logger)
+
+	report, err := service.GetPivotReport(dto.GetPivotReportRequest{
+		From:    "2024-07-01",
+		To:      "2024-07-31",
+		Rows:    "day",
+		Columns: "day",
+	})
+	assert.ErrorIs(t, err, models.ErrSameReportDimension)
+	assert.Nil(t, report)
+
+	report, err = service.GetPivotReport(dto.GetPivotReportRequest{
+		UserIDs: []string{"1,x"},
+		From:    "2024-07-01",
+		To:      "2024-07-31",
+	})
+	assert.ErrorIs(t, err, models.ErrInvalidIDList)
+	assert.Nil(t, report)
+}
+
+func TestExportUserSummary_XLSX(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReportRepo := repositories.NewMockReportRepository(ctrl)
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	mockTeamRepo := repositories.NewMockTeamRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewReportServiceImpl(mockReportRepo, mockUserRepo, mockTeamRepo, newRoundingPolicyRepository(ctrl), logger)
+
+	mockUserRepo.EXPECT().GetById(uint(1)).Return(&models.User{ID: 1}, nil)
+	mockReportRepo.EXPECT().GetUserSummary(gomock.Any()).Return([]repositories.SummaryTaskRow{
+		{GroupKey: "Design & review", TaskID: 1, Seconds: 2700},
+		{GroupKey: "Design & review", TaskID: 2, Seconds: 2700},
+	}, nil)
+
+	var output bytes.Buffer
+	writer, err := export.NewWriter(export.FormatXLSX, &output, export.ParseLocale("en"))
+	assert.NoError(t, err)
+
+	err = service.ExportUserSummary(1, dto.ExportUserSummaryRequest{
+		GetUserSummaryRequest: dto.GetUserSummaryRequest{From: "2024-07-01", To: "2024-07-31", GroupBy: "task_name"},
+	}, writer)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	archive, err := zip.NewReader(bytes.NewReader(output.Bytes()), int64(output.Len()))
+	assert.NoError(t, err)
+
+	var sheet string
+	for _, file := range archive.File {
+		if file.Name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+		reader, err := file.Open()
+		assert.NoError(t, err)
+		content, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		sheet = string(content)
+	}
+
+	assert.Len(t, archive.File, 5)
+	assert.Contains(t, sheet, `<c r="A2" t="inlineStr"><is><t xml:space="preserve">Design &amp; review</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="B2"><v>2</v></c><c r="C2"><v>1.50</v></c>`)
+}
@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/Dor1ma/Time-Tracker/internal/secrets"
+	"github.com/stretchr/testify/assert"
+)
+
+func testKey(b byte) string {
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = b
+	}
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+func TestKeyring_SealAndOpen(t *testing.T) {
+	keyring, err := secrets.NewKeyring("k1:"+testKey(1), "h1:"+testKey(9))
+	assert.NoError(t, err)
+
+	sealed, err := keyring.Seal("1234 567890")
+	assert.NoError(t, err)
+	assert.Equal(t, "k1", sealed.KeyID)
+	assert.NotContains(t, string(sealed.Ciphertext), "1234 567890")
+
+	passportNumber, err := keyring.Open(sealed)
+	assert.NoError(t, err)
+	assert.Equal(t, "1234 567890", passportNumber)
+}
+
+func TestKeyring_RewrapByNewKey(t *testing.T) {
+	oldKeyring, err := secrets.NewKeyring("k1:"+testKey(1), "h1:"+testKey(9))
+	assert.NoError(t, err)
+	sealed, err := oldKeyring.Seal("1234 567890")
+	assert.NoError(t, err)
+
+	keyring, err := secrets.NewKeyring("k2:"+testKey(2)+",k1:"+testKey(1), "h1:"+testKey(9))
+	assert.NoError(t, err)
+	rewrapped, err := keyring.Rewrap(sealed)
+	assert.NoError(t, err)
+	assert.Equal(t, "k2", rewrapped.KeyID)
+	assert.Equal(t, sealed.Ciphertext, rewrapped.Ciphertext)
+
+	newKeyring, err := secrets.NewKeyring("k2:"+testKey(2), "h1:"+testKey(9))
+	assert.NoError(t, err)
+	passportNumber, err := newKeyring.Open(rewrapped)
+	assert.NoError(t, err)
+	assert.Equal(t, "1234 567890", passportNumber)
+
+	_, err = newKeyring.Open(sealed)
+	assert.ErrorIs(t, err, secrets.ErrUnknownKey)
+}
+
+func TestKeyring_Hash(t *testing.T) {
+	keyring, err := secrets.NewKeyring("k1:"+testKey(1), "h1:"+testKey(9))
+	assert.NoError(t, err)
+	otherKeyring, err := secrets.NewKeyring("k1:"+testKey(1), "h1:"+testKey(8))
+	assert.NoError(t, err)
+
+	assert.Equal(t, keyring.Hash("1234 567890"), keyring.Hash("1234 567890"))
+	assert.NotEqual(t, keyring.Hash("1234 567890"), keyring.Hash("1234 567891"))
+	assert.NotEqual(t, keyring.Hash("1234 567890"), otherKeyring.Hash("1234 567890"))
+}
+
+func TestKeyring_HashesUnderEveryHashKey(t *testing.T) {
+	oldKeyring, err := secrets.NewKeyring("k1:"+testKey(1), "h1:"+testKey(9))
+	assert.NoError(t, err)
+	keyring, err := secrets.NewKeyring("k1:"+testKey(1), "h2:"+testKey(8)+",h1:"+testKey(9))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "h2", keyring.CurrentHashKeyID())
+	assert.NotEqual(t, oldKeyring.Hash("1234 567890"), keyring.Hash("1234 567890"))
+	assert.Equal(t, []string{keyring.Hash("1234 567890"), oldKeyring.Hash("1234 567890")}, keyring.Hashes("1234 567890"))
+}
+
+func TestNewKeyring_InvalidKeys(t *testing.T) {
+	_, err := secrets.NewKeyring("", "h1:"+testKey(9))
+	assert.Error(t, err)
+	_, err = secrets.NewKeyring("k1:"+base64.StdEncoding.EncodeToString([]byte("short")), "h1:"+testKey(9))
+	assert.Error(t, err)
+	_, err = secrets.NewKeyring("k1:"+testKey(1)+",k1:"+testKey(2), "h1:"+testKey(9))
+	assert.Error(t, err)
+	_, err = secrets.NewKeyring("k1:"+testKey(1), "")
+	assert.Error(t, err)
+	_, err = secrets.NewKeyring("k1:"+testKey(1), testKey(9))
+	assert.Error(t, err)
+}
@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStopForgottenTasks_CapsAtRunningLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := repositories.NewMockTaskRepository(ctrl)
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewSweepServiceImpl(mockTaskRepo, mockUserRepo, 10*time.Hour, 2*time.Hour, logger)
+
+	start := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+	now := start.Add(16 * time.Hour)
+	tasks := []models.Task{
+		{ID: 1, UserID: 1, Status: models.TaskStatusRunning, StartTime: start,
+			Segments: []models.TaskSegment{{TaskID: 1, StartTime: start}}},
+		{ID: 2, UserID: 2, Status: models.TaskStatusRunning, StartTime: now.Add(-time.Hour),
+			Segments: []models.TaskSegment{{TaskID: 2, StartTime: now.Add(-time.Hour)}}},
+	}
+
+	mockTaskRepo.EXPECT().GetRunningTasks().Return(tasks, nil)
+	mockUserRepo.EXPECT().GetById(uint(1)).Return(&models.User{ID: 1}, nil)
+	mockUserRepo.EXPECT().GetById(uint(2)).Return(&models.User{ID: 2}, nil)
+	mockTaskRepo.EXPECT().AutoStopTask(gomock.Any(), uint(1), start.Add(10*time.Hour), "timer ran for more than 10:00:00").
+		DoAndReturn(func(_ context.Context, taskID uint, stopAt time.Time, reason string) (*models.Task, error) {
+			return &models.Task{ID: taskID, UserID: 1, Status: models.TaskStatusStopped, Hours: 10,
+				StartTime: start, EndTime: stopAt, AutoStopped: true, AutoStopReason: &reason}, nil
+		})
+
+	stopped, err := service.StopForgottenTasks(context.Background(), now)
+
+	assert.NoError(t, err)
+	assert.Len(t, stopped, 1)
+	assert.Equal(t, uint(1), stopped[0].ID)
+	assert.True(t, stopped[0].AutoStopped)
+	assert.Equal(t, "timer ran for more than 10:00:00", stopped[0].AutoStopReason)
+	assert.Equal(t, "2024-07-01T19:00:00Z", stopped[0].EndTime)
+}
+
+func TestStopForgottenTasks_StopsAtWorkdayEndAfterGrace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := repositories.NewMockTaskRepository(ctrl)
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewSweepServiceImpl(mockTaskRepo, mockUserRepo, 12*time.Hour, 2*time.Hour, logger)
+
+	workdayEnd := "18:00"
+	start := time.Date(2024, 7, 1, 14, 0, 0, 0, time.UTC)
+	tasks := []models.Task{
+		{ID: 1, UserID: 1, Status: models.TaskStatusRunning, StartTime: start,
+			Segments: []models.TaskSegment{{TaskID: 1, StartTime: start}}},
+	}
+	mockTaskRepo.EXPECT().GetRunningTasks().Return(tasks, nil).Times(2)
+	mockUserRepo.EXPECT().GetById(uint(1)).Return(&models.User{ID: 1, WorkdayEnd: &workdayEnd}, nil).Times(2)
+
+	stopped, err := service.StopForgottenTasks(context.Background(), time.Date(2024, 7, 1, 19, 30, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Empty(t, stopped)
+
+	workdayEndTime := time.Date(2024, 7, 1, 18, 0, 0, 0, time.UTC)
+	mockTaskRepo.EXPECT().AutoStopTask(gomock.Any(), uint(1), workdayEndTime, "timer ran past the end of the workday at 18:00").
+		Return(&models.Task{ID: 1, UserID: 1, Status: models.TaskStatusStopped, StartTime: start, EndTime: workdayEndTime, AutoStopped: true}, nil)
+
+	stopped, err = service.StopForgottenTasks(context.Background(), time.Date(2024, 7, 1, 20, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Len(t, stopped, 1)
+}
+
+func TestStopForgottenTasks_SkipsTasksStoppedMeanwhile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := repositories.NewMockTaskRepository(ctrl)
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewSweepServiceImpl(mockTaskRepo, mockUserRepo, time.Hour, 0, logger)
+
+	start := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+	tasks := []models.Task{
+		{ID: 1, UserID: 1, Status: models.TaskStatusRunning, StartTime: start,
+			Segments: []models.TaskSegment{{TaskID: 1, StartTime: start}}},
+	}
+	mockTaskRepo.EXPECT().GetRunningTasks().Return(tasks, nil)
+	mockUserRepo.EXPECT().GetById(uint(1)).Return(&models.User{ID: 1}, nil)
+	mockTaskRepo.EXPECT().AutoStopTask(gomock.Any(), uint(1), start.Add(time.Hour), gomock.Any()).Return(nil, models.ErrTaskNotRunning)
+
+	stopped, err := service.StopForgottenTasks(context.Background(), start.Add(2*time.Hour))
+
+	assert.NoError(t, err)
+	assert.Empty(t, stopped)
+}
@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateTag_NormalizesName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTagRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTagServiceImpl(mockRepo, logger)
+
+	mockRepo.EXPECT().GetByName("meeting").Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(tag *models.Tag) error {
+		tag.ID = 1
+		return nil
+	})
+
+	tagResponse, err := service.CreateTag(dto.CreateTagRequest{Name: "  Meeting "})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), tagResponse.ID)
+	assert.Equal(t, "meeting", tagResponse.Name)
+}
+
+func TestRenameTag_NameTaken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTagRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTagServiceImpl(mockRepo, logger)
+
+	mockRepo.EXPECT().GetById(uint(1)).Return(&models.Tag{ID: 1, Name: "meetings"}, nil)
+	mockRepo.EXPECT().GetByName("meeting").Return(&models.Tag{ID: 2, Name: "meeting"}, nil)
+
+	tagResponse, err := service.RenameTag(1, dto.UpdateTagRequest{Name: "meeting"})
+
+	assert.ErrorIs(t, err, models.ErrTagExists)
+	assert.Nil(t, tagResponse)
+}
+
+func TestMergeTags_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTagRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTagServiceImpl(mockRepo, logger)
+
+	mockRepo.EXPECT().Merge(uint(1), uint(2)).Return(nil)
+	mockRepo.EXPECT().GetById(uint(2)).Return(&models.Tag{ID: 2, Name: "meeting"}, nil)
+
+	tagResponse, err := service.MergeTags(1, dto.MergeTagsRequest{TargetID: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), tagResponse.ID)
+	assert.Equal(t, "meeting", tagResponse.Name)
+}
+
+func TestMergeTags_IntoItself(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTagRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTagServiceImpl(mockRepo, logger)
+
+	tagResponse, err := service.MergeTags(2, dto.MergeTagsRequest{TargetID: 2})
+
+	assert.ErrorIs(t, err, models.ErrInvalidTagMerge)
+	assert.Nil(t, tagResponse)
+}
@@ -1,6 +1,8 @@
 package tests
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"github.com/Dor1ma/Time-Tracker/internal/repositories"
 	"github.com/golang/mock/gomock"
@@ -10,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/export"
 	"github.com/Dor1ma/Time-Tracker/internal/models"
 	"github.com/Dor1ma/Time-Tracker/internal/services"
 	"github.com/sirupsen/logrus"
@@ -22,7 +25,8 @@ func TestStartTask_Success(t *testing.T) {
 	mockRepo := repositories.NewMockTaskRepository(ctrl)
 	logger := logrus.New()
 
-	service := services.NewTaskServiceImpl(mockRepo, logger)
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
 
 	userID := uint(1)
 	taskName := "Sample Task"
@@ -35,9 +39,14 @@ func TestStartTask_Success(t *testing.T) {
 		StartTime: time.Now(),
 	}
 
-	mockRepo.EXPECT().StartTask(userID, taskName).Return(expectedTask, nil)
+	mockRepo.EXPECT().StartTask(gomock.Any(), gomock.Any(), false).DoAndReturn(func(_ context.Context, task *models.Task, stopRunning bool) error {
+		assert.Equal(t, userID, task.UserID)
+		assert.Equal(t, taskName, task.TaskName)
+		*task = *expectedTask
+		return nil
+	})
 
-	taskResponse, err := service.StartTask(request)
+	taskResponse, err := service.StartTask(context.Background(), request)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, taskResponse)
@@ -54,7 +63,8 @@ func TestStartTask_Failure(t *testing.T) {
 	mockRepo := repositories.NewMockTaskRepository(ctrl)
 	logger := logrus.New()
 
-	service := services.NewTaskServiceImpl(mockRepo, logger)
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
 
 	userID := uint(1)
 	taskName := "Sample Task"
@@ -62,15 +72,305 @@ func TestStartTask_Failure(t *testing.T) {
 
 	expectedError := errors.New("repository error")
 
-	mockRepo.EXPECT().StartTask(userID, taskName).Return(nil, expectedError)
+	mockRepo.EXPECT().StartTask(gomock.Any(), gomock.Any(), false).Return(expectedError)
 
-	taskResponse, err := service.StartTask(request)
+	taskResponse, err := service.StartTask(context.Background(), request)
 
 	assert.Error(t, err)
 	assert.Nil(t, taskResponse)
 	assert.Equal(t, expectedError, err)
 }
 
+func TestStartTask_AlreadyRunning(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+
+	userID := uint(1)
+	request := dto.StartTaskRequest{UserID: userID, TaskName: "Second Task"}
+
+	runningTask := models.Task{
+		ID:        7,
+		UserID:    userID,
+		TaskName:  "First Task",
+		Status:    models.TaskStatusRunning,
+		StartTime: time.Now().Add(-time.Hour),
+	}
+	conflict := &models.TaskConflictError{Err: models.ErrTaskAlreadyRunning, Tasks: []models.Task{runningTask}}
+
+	mockRepo.EXPECT().StartTask(gomock.Any(), gomock.Any(), false).Return(conflict)
+
+	taskResponse, err := service.StartTask(context.Background(), request)
+
+	assert.Nil(t, taskResponse)
+	assert.ErrorIs(t, err, models.ErrTaskAlreadyRunning)
+
+	var conflictErr *services.TaskConflictError
+	assert.ErrorAs(t, err, &conflictErr)
+	assert.Len(t, conflictErr.Tasks, 1)
+	assert.Equal(t, runningTask.ID, conflictErr.Tasks[0].ID)
+	assert.Equal(t, runningTask.TaskName, conflictErr.Tasks[0].TaskName)
+}
+
+func TestStartTask_SwitchPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicySwitch, logger)
+
+	userID := uint(1)
+	request := dto.StartTaskRequest{UserID: userID, TaskName: "Second Task"}
+
+	expectedTask := &models.Task{
+		ID:        8,
+		UserID:    userID,
+		TaskName:  request.TaskName,
+		Status:    models.TaskStatusRunning,
+		StartTime: time.Now(),
+	}
+
+	mockRepo.EXPECT().StartTask(gomock.Any(), gomock.Any(), true).DoAndReturn(func(_ context.Context, task *models.Task, stopRunning bool) error {
+		*task = *expectedTask
+		return nil
+	})
+
+	taskResponse, err := service.StartTask(context.Background(), request)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedTask.ID, taskResponse.ID)
+	assert.Equal(t, models.TaskStatusRunning, taskResponse.Status)
+}
+
+func TestCreateTask_WithDuration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+
+	startTime := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+	request := dto.CreateTaskRequest{
+		UserID:          1,
+		TaskName:        "Forgotten Task",
+		StartTime:       startTime,
+		DurationMinutes: 90,
+	}
+
+	mockRepo.EXPECT().CreateTask(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, task *models.Task) error {
+		assert.Equal(t, startTime.Add(90*time.Minute), task.EndTime)
+		task.ID = 5
+		task.Status = models.TaskStatusStopped
+		return nil
+	})
+
+	taskResponse, err := service.CreateTask(context.Background(), request)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(5), taskResponse.ID)
+	assert.Equal(t, models.TaskStatusStopped, taskResponse.Status)
+	assert.Equal(t, startTime.Add(90*time.Minute).Format(time.RFC3339), taskResponse.EndTime)
+}
+
+func TestCreateTask_InvalidInterval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+
+	startTime := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(-time.Hour)
+
+	taskResponse, err := service.CreateTask(context.Background(), dto.CreateTaskRequest{
+		UserID:    1,
+		TaskName:  "Backwards Task",
+		StartTime: startTime,
+		EndTime:   &endTime,
+	})
+	assert.ErrorIs(t, err, models.ErrInvalidTaskInterval)
+	assert.Nil(t, taskResponse)
+
+	taskResponse, err = service.CreateTask(context.Background(), dto.CreateTaskRequest{
+		UserID:          1,
+		TaskName:        "Ambiguous Task",
+		StartTime:       startTime,
+		EndTime:         &endTime,
+		DurationMinutes: 30,
+	})
+	assert.ErrorIs(t, err, models.ErrTaskEndRequired)
+	assert.Nil(t, taskResponse)
+}
+
+func TestCreateTask_Overlap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+
+	startTime := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(time.Hour)
+	clashing := []models.Task{
+		{ID: 2, UserID: 1, TaskName: "Task 2", Status: models.TaskStatusStopped, StartTime: startTime.Add(-time.Hour), EndTime: startTime.Add(time.Minute)},
+		{ID: 3, UserID: 1, TaskName: "Task 3", Status: models.TaskStatusStopped, StartTime: startTime.Add(30 * time.Minute), EndTime: endTime},
+	}
+
+	mockRepo.EXPECT().CreateTask(gomock.Any(), gomock.Any()).
+		Return(&models.TaskConflictError{Err: models.ErrTaskOverlap, Tasks: clashing})
+
+	taskResponse, err := service.CreateTask(context.Background(), dto.CreateTaskRequest{
+		UserID:    1,
+		TaskName:  "Overlapping Task",
+		StartTime: startTime,
+		EndTime:   &endTime,
+	})
+
+	assert.Nil(t, taskResponse)
+	assert.ErrorIs(t, err, models.ErrTaskOverlap)
+
+	var conflictErr *services.TaskConflictError
+	assert.ErrorAs(t, err, &conflictErr)
+	assert.Len(t, conflictErr.Tasks, 2)
+	assert.Equal(t, uint(2), conflictErr.Tasks[0].ID)
+	assert.Equal(t, uint(3), conflictErr.Tasks[1].ID)
+}
+
+func TestUpdateTask_ChangeEndTime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+
+	taskID := uint(1)
+	startTime := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+	existingTask := &models.Task{
+		ID:        taskID,
+		UserID:    1,
+		TaskName:  "Overnight Task",
+		Status:    models.TaskStatusStopped,
+		Hours:     16,
+		Minutes:   960,
+		StartTime: startTime,
+		EndTime:   startTime.Add(16 * time.Hour),
+	}
+	newEndTime := startTime.Add(2 * time.Hour)
+
+	mockRepo.EXPECT().GetTask(taskID).Return(existingTask, nil)
+	mockRepo.EXPECT().UpdateTask(gomock.Any(), existingTask, true).Return(nil)
+
+	taskResponse, err := service.UpdateTask(context.Background(), taskID, dto.UpdateTaskRequest{EndTime: &newEndTime})
+
+	assert.NoError(t, err)
+	assert.Equal(t, newEndTime.Format(time.RFC3339), taskResponse.EndTime)
+}
+
+func TestUpdateTask_RunningTaskInterval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+
+	taskID := uint(1)
+	existingTask := &models.Task{
+		ID:        taskID,
+		UserID:    1,
+		TaskName:  "Running Task",
+		Status:    models.TaskStatusRunning,
+		StartTime: time.Now().Add(-time.Hour),
+	}
+	duration := 30
+
+	mockRepo.EXPECT().GetTask(taskID).Return(existingTask, nil)
+
+	taskResponse, err := service.UpdateTask(context.Background(), taskID, dto.UpdateTaskRequest{DurationMinutes: &duration})
+
+	assert.ErrorIs(t, err, models.ErrTaskNotStopped)
+	assert.Nil(t, taskResponse)
+}
+
+func TestUpdateTask_Rename(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+
+	taskID := uint(1)
+	existingTask := &models.Task{
+		ID:        taskID,
+		UserID:    1,
+		TaskName:  "Running Task",
+		Status:    models.TaskStatusRunning,
+		StartTime: time.Now().Add(-time.Hour),
+	}
+	newName := "Renamed Task"
+
+	mockRepo.EXPECT().GetTask(taskID).Return(existingTask, nil)
+	mockRepo.EXPECT().UpdateTask(gomock.Any(), existingTask, false).Return(nil)
+
+	taskResponse, err := service.UpdateTask(context.Background(), taskID, dto.UpdateTaskRequest{TaskName: &newName})
+
+	assert.NoError(t, err)
+	assert.Equal(t, newName, taskResponse.TaskName)
+}
+
+func TestGetActiveTask_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+
+	userID := uint(1)
+	expectedTask := &models.Task{
+		ID:        3,
+		UserID:    userID,
+		TaskName:  "Running Task",
+		Status:    models.TaskStatusRunning,
+		StartTime: time.Now(),
+	}
+
+	mockRepo.EXPECT().GetRunningTask(userID).Return(expectedTask, nil)
+
+	taskResponse, err := service.GetActiveTask(userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedTask.ID, taskResponse.ID)
+	assert.Empty(t, taskResponse.EndTime)
+}
+
 func TestStopTask_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -78,7 +378,8 @@ func TestStopTask_Success(t *testing.T) {
 	mockRepo := repositories.NewMockTaskRepository(ctrl)
 	logger := logrus.New()
 
-	service := services.NewTaskServiceImpl(mockRepo, logger)
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
 
 	taskID := uint(1)
 
@@ -90,10 +391,10 @@ func TestStopTask_Success(t *testing.T) {
 		EndTime:   time.Now().Add(time.Hour),
 	}
 
-	mockRepo.EXPECT().StopTask(taskID).Return(expectedTask, nil)
+	mockRepo.EXPECT().StopTask(gomock.Any(), taskID).Return(expectedTask, nil)
 
 	request := dto.StopTaskRequest{TaskID: taskID}
-	taskResponse, err := service.StopTask(request)
+	taskResponse, err := service.StopTask(context.Background(), request)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, taskResponse)
@@ -111,22 +412,386 @@ func TestStopTask_Failure(t *testing.T) {
 	mockRepo := repositories.NewMockTaskRepository(ctrl)
 	logger := logrus.New()
 
-	service := services.NewTaskServiceImpl(mockRepo, logger)
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
 
 	taskID := uint(1)
 
 	expectedError := errors.New("repository error")
 
-	mockRepo.EXPECT().StopTask(taskID).Return(nil, expectedError)
+	mockRepo.EXPECT().StopTask(gomock.Any(), taskID).Return(nil, expectedError)
 
 	request := dto.StopTaskRequest{TaskID: taskID}
-	taskResponse, err := service.StopTask(request)
+	taskResponse, err := service.StopTask(context.Background(), request)
 
 	assert.Error(t, err)
 	assert.Nil(t, taskResponse)
 	assert.Equal(t, expectedError, err)
 }
 
+func TestPauseTask_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+
+	taskID := uint(1)
+
+	expectedTask := &models.Task{
+		ID:        taskID,
+		UserID:    1,
+		TaskName:  "Sample Task",
+		Status:    models.TaskStatusPaused,
+		Minutes:   25,
+		StartTime: time.Now().Add(-25 * time.Minute),
+	}
+
+	mockRepo.EXPECT().PauseTask(gomock.Any(), taskID).Return(expectedTask, nil)
+
+	taskResponse, err := service.PauseTask(context.Background(), taskID)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, taskResponse)
+	assert.Equal(t, expectedTask.ID, taskResponse.ID)
+	assert.Equal(t, models.TaskStatusPaused, taskResponse.Status)
+	assert.Equal(t, expectedTask.Minutes, taskResponse.Minutes)
+	assert.Empty(t, taskResponse.EndTime)
+}
+
+func TestPauseTask_NotRunning(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+
+	taskID := uint(1)
+
+	mockRepo.EXPECT().PauseTask(gomock.Any(), taskID).Return(nil, models.ErrTaskNotRunning)
+
+	taskResponse, err := service.PauseTask(context.Background(), taskID)
+
+	assert.ErrorIs(t, err, models.ErrTaskNotRunning)
+	assert.Nil(t, taskResponse)
+}
+
+func TestResumeTask_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+
+	taskID := uint(1)
+
+	expectedTask := &models.Task{
+		ID:        taskID,
+		UserID:    1,
+		TaskName:  "Sample Task",
+		Status:    models.TaskStatusRunning,
+		StartTime: time.Now().Add(-time.Hour),
+	}
+
+	mockRepo.EXPECT().ResumeTask(gomock.Any(), taskID, false).Return(expectedTask, nil)
+
+	taskResponse, err := service.ResumeTask(context.Background(), taskID)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, taskResponse)
+	assert.Equal(t, expectedTask.ID, taskResponse.ID)
+	assert.Equal(t, models.TaskStatusRunning, taskResponse.Status)
+}
+
+func TestResumeTask_NotPaused(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+
+	taskID := uint(1)
+
+	mockRepo.EXPECT().ResumeTask(gomock.Any(), taskID, false).Return(nil, models.ErrTaskNotPaused)
+
+	taskResponse, err := service.ResumeTask(context.Background(), taskID)
+
+	assert.ErrorIs(t, err, models.ErrTaskNotPaused)
+	assert.Nil(t, taskResponse)
+}
+
+func TestGetUserTasks_WithSegments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+
+	userID := uint(1)
+	startDate := "2024-07-01"
+	endDate := "2024-07-31"
+
+	firstStart := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+	firstEnd := firstStart.Add(time.Hour)
+	secondStart := firstEnd.Add(30 * time.Minute)
+	secondEnd := secondStart.Add(30 * time.Minute)
+
+	expectedTasks := []models.Task{
+		{
+			ID:        1,
+			UserID:    userID,
+			TaskName:  "Task 1",
+			Status:    models.TaskStatusStopped,
+			Hours:     1,
+			Minutes:   90,
+			StartTime: firstStart,
+			EndTime:   secondEnd,
+			Segments: []models.TaskSegment{
+				{ID: 1, TaskID: 1, StartTime: firstStart, EndTime: &firstEnd},
+				{ID: 2, TaskID: 1, StartTime: secondStart, EndTime: &secondEnd},
+			},
+		},
+	}
+
+	startTime, _ := time.Parse("2006-01-02", startDate)
+	endTime, _ := time.Parse("2006-01-02", endDate)
+
+	mockRepo.EXPECT().GetUserTasks(repositories.TaskFilter{
+		UserID:       userID,
+		StartDate:    startTime,
+		EndDate:      endTime,
+		WithSegments: true,
+	}).Return(expectedTasks, nil)
+
+	taskResponses, err := service.GetUserTasks(userID, dto.GetUserTasksRequest{StartDate: startDate, EndDate: endDate, Segments: true})
+
+	assert.NoError(t, err)
+	assert.Len(t, taskResponses, 1)
+	assert.Len(t, taskResponses[0].Segments, 2)
+	assert.Equal(t, firstStart.Format(time.RFC3339), taskResponses[0].Segments[0].StartTime)
+	assert.Equal(t, firstEnd.Format(time.RFC3339), taskResponses[0].Segments[0].EndTime)
+	assert.Equal(t, secondEnd.Format(time.RFC3339), taskResponses[0].Segments[1].EndTime)
+}
+
+func TestStartTask_WithProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+
+	projectID := uint(4)
+	request := dto.StartTaskRequest{UserID: 1, TaskName: "Project Task", ProjectID: &projectID}
+
+	mockRepo.EXPECT().StartTask(gomock.Any(), gomock.Any(), false).DoAndReturn(func(_ context.Context, task *models.Task, stopRunning bool) error {
+		assert.Equal(t, &projectID, task.ProjectID)
+		task.ID = 9
+		return nil
+	})
+
+	taskResponse, err := service.StartTask(context.Background(), request)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &projectID, taskResponse.ProjectID)
+}
+
+func TestStartTask_ArchivedProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+
+	projectID := uint(4)
+	request := dto.StartTaskRequest{UserID: 1, TaskName: "Project Task", ProjectID: &projectID}
+
+	mockRepo.EXPECT().StartTask(gomock.Any(), gomock.Any(), false).Return(models.ErrProjectArchived)
+
+	taskResponse, err := service.StartTask(context.Background(), request)
+
+	assert.ErrorIs(t, err, models.ErrProjectArchived)
+	assert.Nil(t, taskResponse)
+}
+
+func TestGetUserTasks_FilterByClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+
+	userID := uint(1)
+	clientID := uint(2)
+	startTime, _ := time.Parse("2006-01-02", "2024-07-01")
+	endTime, _ := time.Parse("2006-01-02", "2024-07-31")
+
+	mockRepo.EXPECT().GetUserTasks(repositories.TaskFilter{
+		UserID:    userID,
+		StartDate: startTime,
+		EndDate:   endTime,
+		ClientID:  &clientID,
+	}).Return([]models.Task{}, nil)
+
+	taskResponses, err := service.GetUserTasks(userID, dto.GetUserTasksRequest{
+		StartDate: "2024-07-01",
+		EndDate:   "2024-07-31",
+		ClientID:  &clientID,
+	})
+
+	assert.NoError(t, err)
+	assert.Empty(t, taskResponses)
+}
+
+func TestStartTask_WithTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+
+	request := dto.StartTaskRequest{UserID: 1, TaskName: "Standup", Tags: []string{"Meeting", " meeting", "oncall"}}
+
+	mockRepo.EXPECT().StartTask(gomock.Any(), gomock.Any(), false).DoAndReturn(func(_ context.Context, task *models.Task, stopRunning bool) error {
+		assert.Equal(t, []models.Tag{{Name: "meeting"}, {Name: "oncall"}}, task.Tags)
+		task.ID = 10
+		task.Tags[0].ID = 1
+		task.Tags[1].ID = 2
+		return nil
+	})
+
+	taskResponse, err := service.StartTask(context.Background(), request)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"meeting", "oncall"}, taskResponse.Tags)
+}
+
+func TestUpdateTask_ClearTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+
+	taskID := uint(1)
+	existingTask := &models.Task{
+		ID:        taskID,
+		UserID:    1,
+		TaskName:  "Tagged Task",
+		Status:    models.TaskStatusStopped,
+		Tags:      []models.Tag{{ID: 1, Name: "review"}},
+		StartTime: time.Now().Add(-time.Hour),
+		EndTime:   time.Now(),
+	}
+
+	mockRepo.EXPECT().GetTask(taskID).Return(existingTask, nil)
+	mockRepo.EXPECT().UpdateTask(gomock.Any(), gomock.Any(), false).DoAndReturn(func(_ context.Context, task *models.Task, intervalChanged bool) error {
+		assert.NotNil(t, task.Tags)
+		assert.Empty(t, task.Tags)
+		return nil
+	})
+
+	taskResponse, err := service.UpdateTask(context.Background(), taskID, dto.UpdateTaskRequest{Tags: []string{}})
+
+	assert.NoError(t, err)
+	assert.Empty(t, taskResponse.Tags)
+}
+
+func TestGetUserTasks_FilterByAllTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+
+	userID := uint(1)
+	startTime, _ := time.Parse("2006-01-02", "2024-07-01")
+	endTime, _ := time.Parse("2006-01-02", "2024-07-31")
+
+	mockRepo.EXPECT().GetUserTasks(repositories.TaskFilter{
+		UserID:       userID,
+		StartDate:    startTime,
+		EndDate:      endTime,
+		Tags:         []string{"meeting", "review"},
+		MatchAllTags: true,
+	}).Return([]models.Task{}, nil)
+
+	taskResponses, err := service.GetUserTasks(userID, dto.GetUserTasksRequest{
+		StartDate: "2024-07-01",
+		EndDate:   "2024-07-31",
+		Tags:      []string{"meeting,Review"},
+		TagsMatch: "all",
+	})
+
+	assert.NoError(t, err)
+	assert.Empty(t, taskResponses)
+}
+
+func TestCreateTask_NotBillable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+
+	billable := false
+	startTime := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+
+	mockRepo.EXPECT().CreateTask(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, task *models.Task) error {
+		assert.False(t, task.Billable)
+		task.ID = 6
+		task.Status = models.TaskStatusStopped
+		return nil
+	})
+
+	taskResponse, err := service.CreateTask(context.Background(), dto.CreateTaskRequest{
+		UserID:          1,
+		TaskName:        "Internal Training",
+		Billable:        &billable,
+		StartTime:       startTime,
+		DurationMinutes: 60,
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, taskResponse.Billable)
+	assert.Nil(t, taskResponse.InvoiceID)
+}
+
 func TestGetUserTasks_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -134,7 +799,8 @@ func TestGetUserTasks_Success(t *testing.T) {
 	mockRepo := repositories.NewMockTaskRepository(ctrl)
 	logger := logrus.New()
 
-	service := services.NewTaskServiceImpl(mockRepo, logger)
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
 
 	userID := uint(1)
 	startDate := "2024-07-01"
@@ -164,9 +830,13 @@ func TestGetUserTasks_Success(t *testing.T) {
 	startTime, _ := time.Parse("2006-01-02", startDate)
 	endTime, _ := time.Parse("2006-01-02", endDate)
 
-	mockRepo.EXPECT().GetUserTasks(userID, startTime, endTime).Return(expectedTasks, nil)
+	mockRepo.EXPECT().GetUserTasks(repositories.TaskFilter{
+		UserID:    userID,
+		StartDate: startTime,
+		EndDate:   endTime,
+	}).Return(expectedTasks, nil)
 
-	taskResponses, err := service.GetUserTasks(userID, startDate, endDate)
+	taskResponses, err := service.GetUserTasks(userID, dto.GetUserTasksRequest{StartDate: startDate, EndDate: endDate})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, taskResponses)
@@ -183,6 +853,52 @@ func TestGetUserTasks_Success(t *testing.T) {
 	}
 }
 
+func TestGetUserTasks_RoundedByProjectPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	mockProjectRepo := repositories.NewMockProjectRepository(ctrl)
+	logger := logrus.New()
+
+	projectID, otherProjectID, clientID := uint(3), uint(4), uint(2)
+	mockRoundingRepo := newRoundingPolicyRepository(ctrl,
+		models.RoundingPolicy{ID: 1, ClientID: &clientID, IncrementMinutes: 6, Mode: models.RoundingModeDown},
+		models.RoundingPolicy{ID: 2, ProjectID: &projectID, IncrementMinutes: 15, Mode: models.RoundingModeUp},
+	)
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), mockRoundingRepo,
+		mockProjectRepo, services.RunningTaskPolicyReject, logger)
+
+	start := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+	tasks := []models.Task{
+		{ID: 1, UserID: 1, ProjectID: &projectID, TaskName: "Design", Minutes: 31,
+			StartTime: start, EndTime: start.Add(31 * time.Minute)},
+		{ID: 2, UserID: 1, ProjectID: &otherProjectID, TaskName: "Review", Minutes: 17,
+			StartTime: start.Add(time.Hour), EndTime: start.Add(77 * time.Minute)},
+		{ID: 3, UserID: 1, TaskName: "Email", Minutes: 7,
+			StartTime: start.Add(2 * time.Hour), EndTime: start.Add(127 * time.Minute)},
+	}
+
+	mockRepo.EXPECT().GetUserTasks(gomock.Any()).Return(tasks, nil)
+	mockProjectRepo.EXPECT().GetAll(nil, true).Return([]models.Project{
+		{ID: projectID, ClientID: &clientID},
+		{ID: otherProjectID, ClientID: &clientID},
+	}, nil)
+
+	taskResponses, err := service.GetUserTasks(1, dto.GetUserTasksRequest{StartDate: "2024-07-01", EndDate: "2024-07-01"})
+
+	assert.NoError(t, err)
+	assert.Len(t, taskResponses, 3)
+	assert.Equal(t, 31, taskResponses[0].Minutes)
+	assert.Equal(t, 45, *taskResponses[0].RoundedMinutes)
+	assert.Equal(t, uint(2), *taskResponses[0].RoundingPolicyID)
+	assert.Equal(t, 12, *taskResponses[1].RoundedMinutes)
+	assert.Equal(t, uint(1), *taskResponses[1].RoundingPolicyID)
+	assert.Equal(t, 7, *taskResponses[2].RoundedMinutes)
+	assert.Nil(t, taskResponses[2].RoundingPolicyID)
+}
+
 func TestGetUserTasks_InvalidStartDate(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -190,13 +906,14 @@ func TestGetUserTasks_InvalidStartDate(t *testing.T) {
 	mockRepo := repositories.NewMockTaskRepository(ctrl)
 	logger := logrus.New()
 
-	service := services.NewTaskServiceImpl(mockRepo, logger)
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
 
 	userID := uint(1)
 	startDate := "invalid-date"
 	endDate := "2024-07-31"
 
-	taskResponses, err := service.GetUserTasks(userID, startDate, endDate)
+	taskResponses, err := service.GetUserTasks(userID, dto.GetUserTasksRequest{StartDate: startDate, EndDate: endDate})
 
 	assert.Error(t, err)
 	assert.Nil(t, taskResponses)
@@ -210,15 +927,127 @@ func TestGetUserTasks_InvalidEndDate(t *testing.T) {
 	mockRepo := repositories.NewMockTaskRepository(ctrl)
 	logger := logrus.New()
 
-	service := services.NewTaskServiceImpl(mockRepo, logger)
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
 
 	userID := uint(1)
 	startDate := "2024-07-01"
 	endDate := "invalid-date"
 
-	taskResponses, err := service.GetUserTasks(userID, startDate, endDate)
+	taskResponses, err := service.GetUserTasks(userID, dto.GetUserTasksRequest{StartDate: startDate, EndDate: endDate})
 
 	assert.Error(t, err)
 	assert.Nil(t, taskResponses)
 	assert.Contains(t, err.Error(), "parsing time")
 }
+
+func TestExportUserTasks_CSVWithLocale(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+
+	startTime := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+	mockRepo.EXPECT().GetUserTasksInBatches(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(filter repositories.TaskFilter, batchSize int, visit func(tasks []models.Task) error) error {
+			assert.Equal(t, uint(1), filter.UserID)
+			if err := visit([]models.Task{{ID: 1, TaskName: "Design", Minutes: 90, StartTime: startTime}}); err != nil {
+				return err
+			}
+			return visit([]models.Task{{ID: 2, TaskName: "=SUM(A1:A2)", Minutes: 20, StartTime: startTime.Add(2 * time.Hour)}})
+		})
+
+	var output bytes.Buffer
+	writer, err := export.NewWriter(export.FormatCSV, &output, export.ParseLocale("ru-RU,ru;q=0.9,en;q=0.8"))
+	assert.NoError(t, err)
+
+	err = service.ExportUserTasks(1, dto.ExportUserTasksRequest{
+		GetUserTasksRequest: dto.GetUserTasksRequest{StartDate: "2024-07-01", EndDate: "2024-07-31"},
+		ExportOptions:       dto.ExportOptions{Columns: []string{"id,task_name", "hours", "duration"}},
+	}, writer)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	assert.Equal(t, "id;task_name;hours;duration\n1;Design;1,50;1:30:00\n2;'=SUM(A1:A2);0,33;0:20:00\n", output.String())
+}
+
+func TestExportUserTasks_UnknownColumn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "UTC"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+
+	var output bytes.Buffer
+	writer, err := export.NewWriter(export.FormatCSV, &output, export.ParseLocale("en"))
+	assert.NoError(t, err)
+
+	err = service.ExportUserTasks(1, dto.ExportUserTasksRequest{
+		GetUserTasksRequest: dto.GetUserTasksRequest{StartDate: "2024-07-01", EndDate: "2024-07-31"},
+		ExportOptions:       dto.ExportOptions{Columns: []string{"id,passport_number"}},
+	}, writer)
+
+	assert.ErrorIs(t, err, models.ErrUnknownExportColumn)
+	assert.Empty(t, output.String())
+}
+
+func TestGetUserTasks_InUserTimeZone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockTaskRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTaskServiceImpl(mockRepo, newUserRepositoryInZone(ctrl, "Europe/Berlin"), newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+
+	// Clocks in Berlin went forward on 2024-03-31, which lasted 23 hours.
+	start := time.Date(2024, 3, 31, 0, 0, 0, 0, berlin)
+	end := time.Date(2024, 4, 1, 0, 0, 0, 0, berlin)
+	assert.Equal(t, 23*time.Hour, end.Sub(start))
+
+	taskStart := time.Date(2024, 3, 31, 8, 0, 0, 0, time.UTC)
+	mockRepo.EXPECT().GetUserTasks(repositories.TaskFilter{
+		UserID:    1,
+		StartDate: start,
+		EndDate:   end,
+		Tags:      []string(nil),
+	}).Return([]models.Task{
+		{ID: 1, UserID: 1, TaskName: "Task 1", Status: models.TaskStatusStopped, Hours: 1,
+			StartTime: taskStart, EndTime: taskStart.Add(time.Hour)},
+	}, nil)
+
+	taskResponses, err := service.GetUserTasks(1, dto.GetUserTasksRequest{StartDate: "2024-03-31", EndDate: "2024-04-01"})
+
+	assert.NoError(t, err)
+	assert.Len(t, taskResponses, 1)
+	assert.Equal(t, "2024-03-31T10:00:00+02:00", taskResponses[0].StartTime)
+	assert.Equal(t, "2024-03-31T11:00:00+02:00", taskResponses[0].EndTime)
+}
+
+// newUserRepositoryInZone returns a user repository whose users all live in the time zone.
+func newUserRepositoryInZone(ctrl *gomock.Controller, timeZone string) *repositories.MockUserRepository {
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	mockUserRepo.EXPECT().GetById(gomock.Any()).DoAndReturn(func(id uint) (*models.User, error) {
+		return &models.User{ID: id, TimeZone: timeZone}, nil
+	}).AnyTimes()
+
+	return mockUserRepo
+}
+
+func newRoundingPolicyRepository(ctrl *gomock.Controller, policies ...models.RoundingPolicy) *repositories.MockRoundingPolicyRepository {
+	mockRoundingRepo := repositories.NewMockRoundingPolicyRepository(ctrl)
+	mockRoundingRepo.EXPECT().GetAll().Return(policies, nil).AnyTimes()
+
+	return mockRoundingRepo
+}
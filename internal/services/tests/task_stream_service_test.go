@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/Dor1ma/Time-Tracker/internal/stream"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskStream_SubscribeLoadsMissedEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEventRepo := repositories.NewMockTaskEventRepository(ctrl)
+	service := services.NewTaskStreamServiceImpl(mockEventRepo, stream.NewHub(), logrus.New())
+
+	mockEventRepo.EXPECT().GetAfter(uint64(10), []uint{1, 2}, gomock.Any()).Return([]models.TaskEvent{
+		{ID: 11, UserID: 1, Event: models.EventTaskStarted, Payload: `{"id":7}`},
+		{ID: 13, UserID: 2, Event: models.EventTaskStopped, Payload: `{"id":8}`},
+	}, nil)
+
+	taskStream, err := service.Subscribe(dto.TaskStreamRequest{UserIDs: []string{"1,2"}, LastEventID: 10})
+
+	assert.NoError(t, err)
+	defer taskStream.Close()
+	assert.Len(t, taskStream.Backlog, 2)
+	assert.Equal(t, uint64(11), taskStream.Backlog[0].ID)
+	assert.Equal(t, models.EventTaskStopped, taskStream.Backlog[1].Event)
+	assert.JSONEq(t, `{"id":8}`, string(taskStream.Backlog[1].Data))
+}
+
+func TestTaskStream_SubscribeInvalidUserIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hub := stream.NewHub()
+	service := services.NewTaskStreamServiceImpl(repositories.NewMockTaskEventRepository(ctrl), hub, logrus.New())
+
+	taskStream, err := service.Subscribe(dto.TaskStreamRequest{UserIDs: []string{"1,abc"}})
+
+	assert.ErrorIs(t, err, models.ErrInvalidIDList)
+	assert.Nil(t, taskStream)
+	assert.Equal(t, 0, hub.Subscribers())
+}
+
+func TestTaskStream_RelaysNewEventsToSubscribersOfTheirUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEventRepo := repositories.NewMockTaskEventRepository(ctrl)
+	service := services.NewTaskStreamServiceImpl(mockEventRepo, stream.NewHub(), logrus.New())
+
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	mockEventRepo.EXPECT().GetSince(now.Add(-time.Minute), uint64(0), gomock.Any()).Return([]models.TaskEvent{
+		{ID: 20, UserID: 1, Event: models.EventTaskStarted, Payload: `{}`, CreatedAt: now.Add(-time.Second)},
+	}, nil)
+	relayed, err := service.RelayEvents(now)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, relayed)
+
+	taskStream, err := service.Subscribe(dto.TaskStreamRequest{UserIDs: []string{"1"}})
+	assert.NoError(t, err)
+	defer taskStream.Close()
+
+	now = now.Add(time.Second)
+	mockEventRepo.EXPECT().GetSince(now.Add(-time.Minute), uint64(0), gomock.Any()).Return([]models.TaskEvent{
+		{ID: 20, UserID: 1, Event: models.EventTaskStarted, Payload: `{}`, CreatedAt: now.Add(-2 * time.Second)},
+		{ID: 21, UserID: 2, Event: models.EventTaskStarted, Payload: `{}`, CreatedAt: now},
+		{ID: 22, UserID: 1, Event: models.EventTaskPaused, Payload: `{}`, CreatedAt: now},
+	}, nil)
+
+	relayed, err = service.RelayEvents(now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, relayed)
+	event := <-taskStream.Events()
+	assert.Equal(t, uint64(22), event.ID)
+	assert.Equal(t, models.EventTaskPaused, event.Event)
+	assert.Empty(t, taskStream.Events())
+}
+
+func TestTaskStream_RelaysEventsCommittedOutOfOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEventRepo := repositories.NewMockTaskEventRepository(ctrl)
+	service := services.NewTaskStreamServiceImpl(mockEventRepo, stream.NewHub(), logrus.New())
+
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	mockEventRepo.EXPECT().GetSince(gomock.Any(), uint64(0), gomock.Any()).Return(nil, nil)
+	_, err := service.RelayEvents(now)
+	assert.NoError(t, err)
+
+	taskStream, err := service.Subscribe(dto.TaskStreamRequest{UserIDs: []string{"1"}})
+	assert.NoError(t, err)
+	defer taskStream.Close()
+
+	// Event 30 is committed first; event 29, whose transaction started earlier, only after.
+	mockEventRepo.EXPECT().GetSince(gomock.Any(), uint64(0), gomock.Any()).Return([]models.TaskEvent{
+		{ID: 30, UserID: 1, Event: models.EventTaskStopped, Payload: `{}`, CreatedAt: now},
+	}, nil)
+	relayed, err := service.RelayEvents(now.Add(time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, relayed)
+
+	mockEventRepo.EXPECT().GetSince(gomock.Any(), uint64(0), gomock.Any()).Return([]models.TaskEvent{
+		{ID: 29, UserID: 1, Event: models.EventTaskStarted, Payload: `{}`, CreatedAt: now},
+		{ID: 30, UserID: 1, Event: models.EventTaskStopped, Payload: `{}`, CreatedAt: now},
+	}, nil)
+	relayed, err = service.RelayEvents(now.Add(2 * time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, relayed)
+
+	assert.Equal(t, uint64(30), (<-taskStream.Events()).ID)
+	assert.Equal(t, uint64(29), (<-taskStream.Events()).ID)
+	assert.Empty(t, taskStream.Events())
+}
+
+func TestTaskStream_ClosesSubscriptionThatFellBehind(t *testing.T) {
+	hub := stream.NewHub()
+	subscription := hub.Subscribe([]uint{1})
+
+	for id := uint64(1); id <= 100; id++ {
+		hub.Publish(dto.TaskStreamEvent{ID: id, UserID: 1})
+	}
+
+	var received int
+	for range subscription.Events() {
+		received++
+	}
+	assert.Less(t, received, 100)
+	assert.Equal(t, 0, hub.Subscribers())
+	subscription.Close()
+}
@@ -0,0 +1,51 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartTemplate_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTemplateRepo := repositories.NewMockTaskTemplateRepository(ctrl)
+	mockTaskRepo := repositories.NewMockTaskRepository(ctrl)
+	userRepo := newUserRepositoryInZone(ctrl, "UTC")
+	logger := logrus.New()
+
+	taskService := services.NewTaskServiceImpl(mockTaskRepo, userRepo, newRoundingPolicyRepository(ctrl),
+		repositories.NewMockProjectRepository(ctrl), services.RunningTaskPolicyReject, logger)
+	service := services.NewTaskTemplateServiceImpl(mockTemplateRepo, userRepo, taskService, logger)
+
+	projectID := uint(3)
+	mockTemplateRepo.EXPECT().GetById(uint(2)).Return(&models.TaskTemplate{
+		ID: 2, UserID: 1, TaskName: "Support", ProjectID: &projectID, DurationMinutes: 30,
+		Tags: []models.Tag{{ID: 4, Name: "ops"}},
+	}, nil)
+	mockTaskRepo.EXPECT().StartTask(gomock.Any(), gomock.Any(), false).DoAndReturn(func(_ context.Context, task *models.Task, stopRunning bool) error {
+		assert.Equal(t, uint(1), task.UserID)
+		assert.Equal(t, "Support", task.TaskName)
+		assert.Equal(t, &projectID, task.ProjectID)
+		assert.False(t, task.Billable)
+		assert.Equal(t, "ops", task.Tags[0].Name)
+		task.ID = 7
+		task.StartTime = time.Now()
+		task.Status = models.TaskStatusRunning
+		return nil
+	})
+
+	task, err := service.StartTemplate(context.Background(), 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), task.ID)
+	assert.Equal(t, "Support", task.TaskName)
+}
@@ -0,0 +1,150 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/audit"
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTimesheet_StartsOnMonday(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTimesheetRepo := repositories.NewMockTimesheetRepository(ctrl)
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTimesheetServiceImpl(mockTimesheetRepo, mockUserRepo, logger)
+
+	mockUserRepo.EXPECT().GetById(uint(1)).Return(&models.User{ID: 1}, nil)
+	mockTimesheetRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(timesheet *models.Timesheet) error {
+		assert.Equal(t, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), timesheet.WeekStart)
+		timesheet.ID = 1
+		timesheet.Status = models.TimesheetStatusDraft
+		return nil
+	})
+
+	timesheetResponse, err := service.CreateTimesheet(dto.CreateTimesheetRequest{UserID: 1, Week: "2024-07-07"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-07-01", timesheetResponse.WeekStart)
+	assert.Equal(t, "2024-07-07", timesheetResponse.WeekEnd)
+	assert.Equal(t, models.TimesheetStatusDraft, timesheetResponse.Status)
+}
+
+func TestApproveTimesheet_ByManager(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTimesheetRepo := repositories.NewMockTimesheetRepository(ctrl)
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTimesheetServiceImpl(mockTimesheetRepo, mockUserRepo, logger)
+
+	draft, submitted := models.TimesheetStatusDraft, models.TimesheetStatusSubmitted
+	reviewerID := uint(2)
+	mockUserRepo.EXPECT().GetById(reviewerID).Return(&models.User{ID: reviewerID, Role: models.UserRoleManager}, nil)
+	mockTimesheetRepo.EXPECT().GetById(uint(5)).Return(&models.Timesheet{ID: 5, UserID: 1, Status: submitted}, nil)
+	mockTimesheetRepo.EXPECT().Transition(uint(5), []string{models.TimesheetStatusSubmitted}, models.TimesheetStatusApproved, reviewerID, "Looks good").
+		Return(&models.Timesheet{ID: 5, UserID: 1, Status: models.TimesheetStatusApproved, Transitions: []models.TimesheetTransition{
+			{ToStatus: models.TimesheetStatusDraft},
+			{FromStatus: &draft, ToStatus: submitted},
+			{FromStatus: &submitted, ToStatus: models.TimesheetStatusApproved, ActorID: &reviewerID, Comment: "Looks good"},
+		}}, nil)
+
+	timesheetResponse, err := service.ApproveTimesheet(reviewContext(reviewerID, true), 5, dto.ReviewTimesheetRequest{Comment: "Looks good"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.TimesheetStatusApproved, timesheetResponse.Status)
+	assert.Len(t, timesheetResponse.Transitions, 3)
+	assert.Equal(t, submitted, timesheetResponse.Transitions[2].FromStatus)
+	assert.Equal(t, "Looks good", timesheetResponse.Transitions[2].Comment)
+}
+
+func TestApproveTimesheet_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTimesheetRepo := repositories.NewMockTimesheetRepository(ctrl)
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTimesheetServiceImpl(mockTimesheetRepo, mockUserRepo, logger)
+
+	mockUserRepo.EXPECT().GetById(uint(3)).Return(&models.User{ID: 3, Role: models.UserRoleUser}, nil)
+	_, err := service.ApproveTimesheet(reviewContext(3, true), 5, dto.ReviewTimesheetRequest{})
+	assert.ErrorIs(t, err, models.ErrNotManager)
+
+	mockUserRepo.EXPECT().GetById(uint(1)).Return(&models.User{ID: 1, Role: models.UserRoleManager}, nil)
+	mockTimesheetRepo.EXPECT().GetById(uint(5)).Return(&models.Timesheet{ID: 5, UserID: 1, Status: models.TimesheetStatusSubmitted}, nil)
+	_, err = service.ApproveTimesheet(reviewContext(1, true), 5, dto.ReviewTimesheetRequest{})
+	assert.ErrorIs(t, err, models.ErrOwnTimesheetReview)
+}
+
+func TestApproveTimesheet_RequiresAdminToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTimesheetRepo := repositories.NewMockTimesheetRepository(ctrl)
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTimesheetServiceImpl(mockTimesheetRepo, mockUserRepo, logger)
+
+	_, err := service.ApproveTimesheet(reviewContext(2, false), 5, dto.ReviewTimesheetRequest{})
+	assert.ErrorIs(t, err, models.ErrNotManager)
+
+	_, err = service.ApproveTimesheet(audit.NewContext(context.Background(), audit.Actor{Admin: true}), 5, dto.ReviewTimesheetRequest{})
+	assert.ErrorIs(t, err, models.ErrReviewerRequired)
+}
+
+func TestRejectTimesheet_RequiresComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTimesheetRepo := repositories.NewMockTimesheetRepository(ctrl)
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTimesheetServiceImpl(mockTimesheetRepo, mockUserRepo, logger)
+
+	_, err := service.RejectTimesheet(reviewContext(2, true), 5, dto.ReviewTimesheetRequest{})
+
+	assert.ErrorIs(t, err, models.ErrReviewCommentRequired)
+}
+
+func TestReopenTimesheet_OnlyAdmins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTimesheetRepo := repositories.NewMockTimesheetRepository(ctrl)
+	mockUserRepo := repositories.NewMockUserRepository(ctrl)
+	logger := logrus.New()
+
+	service := services.NewTimesheetServiceImpl(mockTimesheetRepo, mockUserRepo, logger)
+
+	_, err := service.ReopenTimesheet(reviewContext(4, false), 5, dto.ReviewTimesheetRequest{})
+	assert.ErrorIs(t, err, models.ErrNotAdmin)
+
+	mockTimesheetRepo.EXPECT().Transition(uint(5), []string{models.TimesheetStatusApproved}, models.TimesheetStatusDraft, uint(4), "Fix Friday").
+		Return(&models.Timesheet{ID: 5, UserID: 1, Status: models.TimesheetStatusDraft}, nil)
+	timesheetResponse, err := service.ReopenTimesheet(reviewContext(4, true), 5, dto.ReviewTimesheetRequest{Comment: "Fix Friday"})
+	assert.NoError(t, err)
+	assert.Equal(t, models.TimesheetStatusDraft, timesheetResponse.Status)
+}
+
+// reviewContext returns the context of a request made by reviewerID, authenticated as an
+// admin's if admin.
+func reviewContext(reviewerID uint, admin bool) context.Context {
+	return audit.NewContext(context.Background(), audit.Actor{UserID: &reviewerID, Admin: admin})
+}
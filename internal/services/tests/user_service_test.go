@@ -1,8 +1,10 @@
 package tests
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"github.com/Dor1ma/Time-Tracker/internal/audit"
 	"github.com/Dor1ma/Time-Tracker/internal/dto"
 	"github.com/Dor1ma/Time-Tracker/internal/models"
 	"github.com/Dor1ma/Time-Tracker/internal/repositories"
@@ -14,6 +16,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 type UserServiceTestSuite struct {
@@ -57,13 +60,13 @@ func (suite *UserServiceTestSuite) TestCreateUserSuccess() {
 	}
 
 	suite.userRepoMock.EXPECT().
-		Create(gomock.Any()).
-		DoAndReturn(func(user *models.User) error {
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, user *models.User) error {
 			user.ID = 1
 			return nil
 		})
 
-	userResponse, err := suite.userService.CreateUser(passportNumber)
+	userResponse, err := suite.userService.CreateUser(context.Background(), passportNumber)
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), userResponse)
 	assert.Equal(suite.T(), uint(1), userResponse.ID)
@@ -75,7 +78,7 @@ func (suite *UserServiceTestSuite) TestCreateUserSuccess() {
 
 func (suite *UserServiceTestSuite) TestCreateUserInvalidPassportNumber() {
 	passportNumber := "invalid"
-	userResponse, err := suite.userService.CreateUser(passportNumber)
+	userResponse, err := suite.userService.CreateUser(context.Background(), passportNumber)
 	assert.NotNil(suite.T(), err)
 	assert.Nil(suite.T(), userResponse)
 }
@@ -84,7 +87,7 @@ func (suite *UserServiceTestSuite) TestCreateUserExternalAPIError() {
 	suite.externalAPIMock.Close()
 
 	passportNumber := "1234-567890"
-	userResponse, err := suite.userService.CreateUser(passportNumber)
+	userResponse, err := suite.userService.CreateUser(context.Background(), passportNumber)
 	assert.NotNil(suite.T(), err)
 	assert.Nil(suite.T(), userResponse)
 }
@@ -102,12 +105,15 @@ func (suite *UserServiceTestSuite) TestGetUserByIdSuccess() {
 	suite.userRepoMock.EXPECT().
 		GetById(uint(1)).
 		Return(expectedUser, nil)
+	suite.userRepoMock.EXPECT().
+		OpenPassport(gomock.Any()).
+		Return(nil)
 
-	userResponse, err := suite.userService.GetUserById(1)
+	userResponse, err := suite.userService.GetUserById(context.Background(), 1)
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), userResponse)
 	assert.Equal(suite.T(), expectedUser.ID, userResponse.ID)
-	assert.Equal(suite.T(), expectedUser.PassportNumber, userResponse.PassportNumber)
+	assert.Equal(suite.T(), "12**-****90", userResponse.PassportNumber)
 	assert.Equal(suite.T(), expectedUser.Surname, userResponse.Surname)
 	assert.Equal(suite.T(), expectedUser.Name, userResponse.Name)
 	assert.Equal(suite.T(), expectedUser.Patronymic, userResponse.Patronymic)
@@ -119,7 +125,7 @@ func (suite *UserServiceTestSuite) TestGetUserByIdNotFound() {
 		GetById(uint(1)).
 		Return(nil, errors.New("user not found"))
 
-	userResponse, err := suite.userService.GetUserById(1)
+	userResponse, err := suite.userService.GetUserById(context.Background(), 1)
 	assert.NotNil(suite.T(), err)
 	assert.Nil(suite.T(), userResponse)
 }
@@ -147,8 +153,12 @@ func (suite *UserServiceTestSuite) TestGetAllUsersSuccess() {
 	suite.userRepoMock.EXPECT().
 		GetAll().
 		Return(expectedUsers, nil)
+	suite.userRepoMock.EXPECT().
+		OpenPassport(gomock.Any()).
+		Return(nil).
+		Times(2)
 
-	userResponses, err := suite.userService.GetAllUsers()
+	userResponses, err := suite.userService.GetAllUsers(context.Background())
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), userResponses)
 	assert.Len(suite.T(), userResponses, 2)
@@ -179,16 +189,19 @@ func (suite *UserServiceTestSuite) TestUpdateUserSuccess() {
 		Return(existingUser, nil)
 
 	suite.userRepoMock.EXPECT().
-		Update(gomock.Any()).
-		DoAndReturn(func(user *models.User) error {
+		Update(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, user *models.User) error {
 			existingUser.Name = userUpdateRequest.Name
 			existingUser.Surname = userUpdateRequest.Surname
 			existingUser.Patronymic = userUpdateRequest.Patronymic
 			existingUser.Address = userUpdateRequest.Address
 			return nil
 		})
+	suite.userRepoMock.EXPECT().
+		OpenPassport(gomock.Any()).
+		Return(nil)
 
-	updatedUserResponse, err := suite.userService.UpdateUser(userId, userUpdateRequest)
+	updatedUserResponse, err := suite.userService.UpdateUser(context.Background(), userId, userUpdateRequest)
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), updatedUserResponse)
 	assert.Equal(suite.T(), userId, updatedUserResponse.ID)
@@ -211,7 +224,7 @@ func (suite *UserServiceTestSuite) TestUpdateUserNotFound() {
 		GetById(userId).
 		Return(nil, errors.New("user not found"))
 
-	updatedUserResponse, err := suite.userService.UpdateUser(userId, userUpdateRequest)
+	updatedUserResponse, err := suite.userService.UpdateUser(context.Background(), userId, userUpdateRequest)
 	assert.NotNil(suite.T(), err)
 	assert.Nil(suite.T(), updatedUserResponse)
 }
@@ -220,11 +233,174 @@ func (suite *UserServiceTestSuite) TestDeleteUserSuccess() {
 	userId := uint(1)
 
 	suite.userRepoMock.EXPECT().
-		Delete(userId).
+		Delete(gomock.Any(), userId).
 		Return(nil)
 
-	err := suite.userService.DeleteUser(userId)
+	err := suite.userService.DeleteUser(context.Background(), userId)
+	assert.Nil(suite.T(), err)
+}
+
+func (suite *UserServiceTestSuite) TestUpdateDeletedUser() {
+	userId := uint(1)
+	deletedAt := time.Now()
+
+	suite.userRepoMock.EXPECT().
+		GetById(userId).
+		Return(&models.User{ID: userId, DeletedAt: &deletedAt}, nil)
+
+	updatedUserResponse, err := suite.userService.UpdateUser(context.Background(), userId, dto.UpdateUserRequest{Name: "Jane"})
+	assert.ErrorIs(suite.T(), err, models.ErrUserDeleted)
+	assert.Nil(suite.T(), updatedUserResponse)
+}
+
+func (suite *UserServiceTestSuite) TestRestoreUserSuccess() {
+	userId := uint(1)
+
+	suite.userRepoMock.EXPECT().
+		Restore(gomock.Any(), userId).
+		Return(&models.User{ID: userId, Name: "John"}, nil)
+	suite.userRepoMock.EXPECT().
+		OpenPassport(gomock.Any()).
+		Return(nil)
+
+	userResponse, err := suite.userService.RestoreUser(context.Background(), userId)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), userId, userResponse.ID)
+	assert.Empty(suite.T(), userResponse.DeletedAt)
+}
+
+func (suite *UserServiceTestSuite) TestRestoreUserNotDeleted() {
+	userId := uint(1)
+
+	suite.userRepoMock.EXPECT().
+		Restore(gomock.Any(), userId).
+		Return(nil, models.ErrUserNotDeleted)
+
+	userResponse, err := suite.userService.RestoreUser(context.Background(), userId)
+	assert.ErrorIs(suite.T(), err, models.ErrUserNotDeleted)
+	assert.Nil(suite.T(), userResponse)
+}
+
+func (suite *UserServiceTestSuite) TestPurgeDeletedUsers() {
+	deletedBefore := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	suite.userRepoMock.EXPECT().
+		Purge(gomock.Any(), deletedBefore).
+		Return([]uint{3, 4}, nil)
+
+	purged, err := suite.userService.PurgeDeletedUsers(context.Background(), deletedBefore)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, purged)
+}
+
+func (suite *UserServiceTestSuite) TestGetUsersExcludesDeletedByDefault() {
+	filters := map[string]interface{}{"name": "John"}
+
+	suite.userRepoMock.EXPECT().
+		GetAllWithFiltersAndPagination(filters, 1, 10, false).
+		Return([]models.User{{ID: 1, Name: "John"}}, nil)
+	suite.userRepoMock.EXPECT().
+		OpenPassport(gomock.Any()).
+		Return(nil)
+
+	users, err := suite.userService.GetUsersWithFiltersAndPagination(context.Background(), filters, 1, 10, false)
+	assert.Nil(suite.T(), err)
+	assert.Len(suite.T(), users, 1)
+}
+
+func (suite *UserServiceTestSuite) TestUpdateUserRoleRejectedWithoutAdmin() {
+	userId := uint(1)
+	actorID := uint(2)
+	role := models.UserRoleAdmin
+
+	suite.userRepoMock.EXPECT().
+		GetById(userId).
+		Return(&models.User{ID: userId, Role: models.UserRoleUser}, nil)
+
+	ctx := audit.NewContext(context.Background(), audit.Actor{UserID: &actorID})
+	updatedUserResponse, err := suite.userService.UpdateUser(ctx, userId, dto.UpdateUserRequest{Name: "Jane", Role: &role})
+	assert.ErrorIs(suite.T(), err, models.ErrRoleChange)
+	assert.Nil(suite.T(), updatedUserResponse)
+}
+
+func (suite *UserServiceTestSuite) TestUpdateUserRoleByAdmin() {
+	userId := uint(1)
+	role := models.UserRoleManager
+
+	suite.userRepoMock.EXPECT().
+		GetById(userId).
+		Return(&models.User{ID: userId, Role: models.UserRoleUser}, nil)
+	suite.userRepoMock.EXPECT().
+		Update(gomock.Any(), gomock.Any()).
+		Return(nil)
+	suite.userRepoMock.EXPECT().
+		OpenPassport(gomock.Any()).
+		Return(nil)
+
+	ctx := audit.NewContext(context.Background(), audit.Actor{Admin: true})
+	updatedUserResponse, err := suite.userService.UpdateUser(ctx, userId, dto.UpdateUserRequest{Name: "Jane", Role: &role})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), models.UserRoleManager, updatedUserResponse.Role)
+}
+
+func (suite *UserServiceTestSuite) expectPassportOpened(passportNumber string) {
+	suite.userRepoMock.EXPECT().
+		OpenPassport(gomock.Any()).
+		DoAndReturn(func(user *models.User) error {
+			user.PassportNumber = passportNumber
+			return nil
+		})
+}
+
+func (suite *UserServiceTestSuite) TestGetUserByIdRevealsPassportToAdmin() {
+	ctx := audit.NewContext(context.Background(), audit.Actor{Admin: true})
+
+	suite.userRepoMock.EXPECT().
+		GetById(uint(1)).
+		Return(&models.User{ID: 1}, nil)
+	suite.expectPassportOpened("1234 567890")
+
+	userResponse, err := suite.userService.GetUserById(ctx, 1)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "1234 567890", userResponse.PassportNumber)
+}
+
+func (suite *UserServiceTestSuite) TestGetUserByIdMasksPassportWithoutAdminToken() {
+	actorID := uint(9)
+	ctx := audit.NewContext(context.Background(), audit.Actor{UserID: &actorID})
+
+	suite.userRepoMock.EXPECT().
+		GetById(uint(1)).
+		Return(&models.User{ID: 1}, nil)
+	suite.expectPassportOpened("1234 567890")
+
+	userResponse, err := suite.userService.GetUserById(ctx, 1)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "12** ****90", userResponse.PassportNumber)
+}
+
+func (suite *UserServiceTestSuite) TestGetUserByIdFailsWhenPassportDoesNotOpen() {
+	suite.userRepoMock.EXPECT().
+		GetById(uint(1)).
+		Return(&models.User{ID: 1}, nil)
+	suite.userRepoMock.EXPECT().
+		OpenPassport(gomock.Any()).
+		Return(errors.New("unknown passport key"))
+
+	userResponse, err := suite.userService.GetUserById(context.Background(), 1)
+	assert.NotNil(suite.T(), err)
+	assert.Nil(suite.T(), userResponse)
+}
+
+func (suite *UserServiceTestSuite) TestRotatePassportKeysSealsInBatches() {
+	gomock.InOrder(
+		suite.userRepoMock.EXPECT().SealPassports(100).Return(100, nil),
+		suite.userRepoMock.EXPECT().SealPassports(100).Return(3, nil),
+	)
+
+	sealed, err := suite.userService.RotatePassportKeys()
 	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 103, sealed)
 }
 
 func TestUserServiceTestSuite(t *testing.T) {
@@ -0,0 +1,266 @@
+package tests
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/Dor1ma/Time-Tracker/internal/services"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeliverDue_SignsAndDelivers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var body []byte
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		header = r.Header
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	mockRepo := repositories.NewMockWebhookRepository(ctrl)
+	dispatcher := services.NewWebhookDispatcher(mockRepo, server.Client(), 3, time.Minute, logrus.New())
+
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	webhook := &models.Webhook{ID: 1, URL: server.URL, Secret: "secret", Events: models.EventTaskStarted, Active: true}
+	payload := `{"id":"abc","event":"task.started","occurred_at":"2024-03-04T10:00:00Z","data":{"id":7}}`
+	mockRepo.EXPECT().ClaimDueDeliveries(now, gomock.Any(), gomock.Any()).Return([]models.WebhookDelivery{{
+		ID: 5, WebhookID: 1, Webhook: webhook, EventID: "abc", Event: models.EventTaskStarted,
+		Payload: payload, Status: models.WebhookDeliveryPending, NextAttemptAt: &now,
+	}}, nil)
+
+	var saved models.WebhookDelivery
+	mockRepo.EXPECT().SaveDeliveryAttempt(gomock.Any()).DoAndReturn(func(delivery *models.WebhookDelivery) error {
+		saved = *delivery
+		return nil
+	})
+
+	succeeded, err := dispatcher.DeliverDue(now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, succeeded)
+	assert.Equal(t, payload, string(body))
+	assert.Equal(t, models.EventTaskStarted, header.Get(services.WebhookEventHeader))
+	assert.Equal(t, "5", header.Get(services.WebhookDeliveryHeader))
+
+	parts := strings.Split(header.Get(services.WebhookSignatureHeader), ",")
+	assert.Len(t, parts, 2)
+	timestamp := strings.TrimPrefix(parts[0], "t=")
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(timestamp + "." + payload))
+	assert.Equal(t, "v1="+hex.EncodeToString(mac.Sum(nil)), parts[1])
+
+	assert.Equal(t, models.WebhookDeliverySucceeded, saved.Status)
+	assert.Equal(t, 1, saved.Attempts)
+	assert.Equal(t, http.StatusNoContent, *saved.LastStatusCode)
+	assert.Equal(t, now, *saved.DeliveredAt)
+	assert.Nil(t, saved.NextAttemptAt)
+}
+
+func TestDeliverDue_RetriesWithBackoffThenFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	mockRepo := repositories.NewMockWebhookRepository(ctrl)
+	dispatcher := services.NewWebhookDispatcher(mockRepo, server.Client(), 3, time.Minute, logrus.New())
+
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	webhook := &models.Webhook{ID: 1, URL: server.URL, Secret: "secret", Events: models.EventTaskStarted, Active: true}
+	delivery := models.WebhookDelivery{ID: 5, WebhookID: 1, Webhook: webhook, Event: models.EventTaskStarted,
+		Payload: "{}", Status: models.WebhookDeliveryPending, Attempts: 1}
+
+	var saved []models.WebhookDelivery
+	mockRepo.EXPECT().ClaimDueDeliveries(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(time.Time, time.Duration, int) ([]models.WebhookDelivery, error) {
+			return []models.WebhookDelivery{delivery}, nil
+		}).Times(2)
+	mockRepo.EXPECT().SaveDeliveryAttempt(gomock.Any()).DoAndReturn(func(d *models.WebhookDelivery) error {
+		saved = append(saved, *d)
+		delivery = *d
+		return nil
+	}).Times(2)
+
+	succeeded, err := dispatcher.DeliverDue(now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, succeeded)
+	assert.Equal(t, models.WebhookDeliveryPending, saved[0].Status)
+	assert.Equal(t, 2, saved[0].Attempts)
+	assert.Equal(t, now.Add(2*time.Minute), *saved[0].NextAttemptAt)
+	assert.Equal(t, http.StatusServiceUnavailable, *saved[0].LastStatusCode)
+	assert.NotNil(t, saved[0].LastError)
+
+	_, err = dispatcher.DeliverDue(now.Add(2 * time.Minute))
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.WebhookDeliveryFailed, saved[1].Status)
+	assert.Equal(t, 3, saved[1].Attempts)
+	assert.Nil(t, saved[1].NextAttemptAt)
+}
+
+func TestRecord_CreatesDeliveriesForSubscribedWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockWebhookRepository(ctrl)
+	dispatcher := services.NewWebhookDispatcher(mockRepo, http.DefaultClient, 3, time.Minute, logrus.New())
+
+	mockRepo.EXPECT().GetActive().Return([]models.Webhook{
+		{ID: 1, Events: "task.started,task.stopped", Active: true},
+		{ID: 2, Events: "user.created", Active: true},
+	}, nil)
+	mockRepo.EXPECT().CreateDeliveries(gomock.Any()).DoAndReturn(func(deliveries []models.WebhookDelivery) error {
+		assert.Len(t, deliveries, 1)
+		assert.Equal(t, uint(1), deliveries[0].WebhookID)
+		assert.Equal(t, models.WebhookDeliveryPending, deliveries[0].Status)
+		assert.NotNil(t, deliveries[0].NextAttemptAt)
+
+		var event dto.WebhookEvent
+		assert.NoError(t, json.Unmarshal([]byte(deliveries[0].Payload), &event))
+		assert.Equal(t, "evt", event.ID)
+		assert.Equal(t, models.EventTaskStopped, event.Event)
+		return nil
+	})
+
+	count, err := dispatcher.Record(dto.WebhookEvent{ID: "evt", Event: models.EventTaskStopped, Data: map[string]any{"id": 7}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestHandleEvent_RecordsDeliveriesUnderOutboxID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockWebhookRepository(ctrl)
+	dispatcher := services.NewWebhookDispatcher(mockRepo, http.DefaultClient, 3, time.Minute, logrus.New())
+
+	mockRepo.EXPECT().GetActive().Return([]models.Webhook{
+		{ID: 1, Events: "task.created,task.updated", Active: true},
+	}, nil)
+	mockRepo.EXPECT().CreateDeliveries(gomock.Any()).DoAndReturn(func(deliveries []models.WebhookDelivery) error {
+		assert.Len(t, deliveries, 1)
+		assert.Equal(t, "42", deliveries[0].EventID)
+		assert.JSONEq(t, `{"id":"42","event":"task.updated","occurred_at":"2024-03-04T10:00:00Z","data":{"task_id":7}}`,
+			deliveries[0].Payload)
+		return nil
+	})
+
+	err := dispatcher.HandleEvent(context.Background(), &models.OutboxEvent{
+		ID: 42, Event: models.EventTaskUpdated, AggregateID: 7, Payload: `{"task_id":7}`,
+		CreatedAt: time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC),
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestCreateWebhook_UnknownEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := services.NewWebhookServiceImpl(repositories.NewMockWebhookRepository(ctrl), logrus.New())
+
+	webhook, err := service.CreateWebhook(dto.CreateWebhookRequest{
+		URL:    "https://example.com/hooks",
+		Events: []string{models.EventTaskStarted, "task.deleted"},
+	})
+
+	assert.ErrorIs(t, err, models.ErrUnknownWebhookEvent)
+	assert.Nil(t, webhook)
+}
+
+func TestCreateWebhook_ReturnsSecretOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockWebhookRepository(ctrl)
+	service := services.NewWebhookServiceImpl(mockRepo, logrus.New())
+
+	mockRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(webhook *models.Webhook) error {
+		assert.Equal(t, "task.started,task.stopped", webhook.Events)
+		assert.True(t, webhook.Active)
+		webhook.ID = 1
+		return nil
+	})
+
+	created, err := service.CreateWebhook(dto.CreateWebhookRequest{
+		URL:    "https://example.com/hooks",
+		Events: []string{models.EventTaskStarted, models.EventTaskStopped, models.EventTaskStarted},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, created.Secret, 64)
+	assert.Equal(t, []string{models.EventTaskStarted, models.EventTaskStopped}, created.Events)
+
+	mockRepo.EXPECT().GetById(uint(1)).Return(&models.Webhook{ID: 1, Secret: created.Secret, Events: "task.started"}, nil)
+
+	fetched, err := service.GetWebhook(1)
+
+	assert.NoError(t, err)
+	assert.Empty(t, fetched.Secret)
+}
+
+func TestReplayDelivery_CreatesPendingCopy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockWebhookRepository(ctrl)
+	service := services.NewWebhookServiceImpl(mockRepo, logrus.New())
+
+	original := &models.WebhookDelivery{ID: 5, WebhookID: 1, EventID: "evt", Event: models.EventTaskStarted,
+		Payload: `{"id":"evt"}`, Status: models.WebhookDeliveryFailed, Attempts: 8}
+	mockRepo.EXPECT().GetDelivery(uint(1), uint(5)).Return(original, nil)
+	mockRepo.EXPECT().CreateDeliveries(gomock.Any()).DoAndReturn(func(deliveries []models.WebhookDelivery) error {
+		deliveries[0].ID = 6
+		return nil
+	})
+
+	replay, err := service.ReplayDelivery(1, 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(6), replay.ID)
+	assert.Equal(t, models.WebhookDeliveryPending, replay.Status)
+	assert.Equal(t, 0, replay.Attempts)
+	assert.Equal(t, "evt", replay.EventID)
+	assert.Equal(t, uint(5), *replay.ReplayOf)
+	assert.NotEmpty(t, replay.NextAttemptAt)
+}
+
+func TestReplayDelivery_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockWebhookRepository(ctrl)
+	service := services.NewWebhookServiceImpl(mockRepo, logrus.New())
+
+	expectedError := errors.New("record not found")
+	mockRepo.EXPECT().GetDelivery(uint(1), uint(5)).Return(nil, expectedError)
+
+	replay, err := service.ReplayDelivery(1, 5)
+
+	assert.Equal(t, expectedError, err)
+	assert.Nil(t, replay)
+}
@@ -0,0 +1,17 @@
+package services
+
+import (
+	"context"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+)
+
+type TimesheetService interface {
+	CreateTimesheet(request dto.CreateTimesheetRequest) (*dto.TimesheetResponse, error)
+	GetTimesheet(id uint) (*dto.TimesheetResponse, error)
+	GetTimesheets(request dto.GetTimesheetsRequest) ([]dto.TimesheetResponse, error)
+	SubmitTimesheet(id uint) (*dto.TimesheetResponse, error)
+	ApproveTimesheet(ctx context.Context, id uint, request dto.ReviewTimesheetRequest) (*dto.TimesheetResponse, error)
+	RejectTimesheet(ctx context.Context, id uint, request dto.ReviewTimesheetRequest) (*dto.TimesheetResponse, error)
+	ReopenTimesheet(ctx context.Context, id uint, request dto.ReviewTimesheetRequest) (*dto.TimesheetResponse, error)
+}
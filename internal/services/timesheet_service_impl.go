@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/audit"
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/sirupsen/logrus"
+)
+
+type TimesheetServiceImpl struct {
+	timesheetRepo repositories.TimesheetRepository
+	userRepo      repositories.UserRepository
+	logger        *logrus.Logger
+}
+
+func NewTimesheetServiceImpl(timesheetRepo repositories.TimesheetRepository, userRepo repositories.UserRepository, logger *logrus.Logger) *TimesheetServiceImpl {
+	return &TimesheetServiceImpl{
+		timesheetRepo: timesheetRepo,
+		userRepo:      userRepo,
+		logger:        logger,
+	}
+}
+
+func (s *TimesheetServiceImpl) CreateTimesheet(request dto.CreateTimesheetRequest) (*dto.TimesheetResponse, error) {
+	s.logger.Infof("CreateTimesheet: creating timesheet for user ID: %d, week: %s", request.UserID, request.Week)
+	week, err := parseDate(request.Week)
+	if err != nil {
+		s.logger.Debugf("CreateTimesheet: invalid week: %v", err)
+		return nil, err
+	}
+
+	if _, err := s.userRepo.GetById(request.UserID); err != nil {
+		s.logger.Debugf("CreateTimesheet: failed to get user: %v", err)
+		return nil, err
+	}
+
+	timesheet := &models.Timesheet{
+		UserID:    request.UserID,
+		WeekStart: models.TimesheetWeek(week),
+	}
+	if err := s.timesheetRepo.Create(timesheet); err != nil {
+		s.logger.Debugf("CreateTimesheet: failed to create timesheet: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("CreateTimesheet: timesheet created with ID: %d", timesheet.ID)
+	return newTimesheetResponse(timesheet), nil
+}
+
+func (s *TimesheetServiceImpl) GetTimesheet(id uint) (*dto.TimesheetResponse, error) {
+	s.logger.Infof("GetTimesheet: getting timesheet with ID: %d", id)
+	timesheet, err := s.timesheetRepo.GetById(id)
+	if err != nil {
+		s.logger.Debugf("GetTimesheet: failed to get timesheet: %v", err)
+		return nil, err
+	}
+
+	return newTimesheetResponse(timesheet), nil
+}
+
+func (s *TimesheetServiceImpl) GetTimesheets(request dto.GetTimesheetsRequest) ([]dto.TimesheetResponse, error) {
+	s.logger.Info("GetTimesheets: fetching timesheets")
+	timesheets, err := s.timesheetRepo.GetAll(request.UserID, request.Status)
+	if err != nil {
+		s.logger.Debugf("GetTimesheets: failed to fetch timesheets: %v", err)
+		return nil, err
+	}
+
+	timesheetResponses := make([]dto.TimesheetResponse, len(timesheets))
+	for i := range timesheets {
+		timesheetResponses[i] = *newTimesheetResponse(&timesheets[i])
+	}
+
+	return timesheetResponses, nil
+}
+
+// SubmitTimesheet hands a draft or rejected timesheet in for review on behalf of its user,
+// locking the tasks of its week.
+func (s *TimesheetServiceImpl) SubmitTimesheet(id uint) (*dto.TimesheetResponse, error) {
+	s.logger.Infof("SubmitTimesheet: submitting timesheet with ID: %d", id)
+	timesheet, err := s.timesheetRepo.GetById(id)
+	if err != nil {
+		s.logger.Debugf("SubmitTimesheet: failed to get timesheet: %v", err)
+		return nil, err
+	}
+
+	timesheet, err = s.timesheetRepo.Transition(id,
+		[]string{models.TimesheetStatusDraft, models.TimesheetStatusRejected},
+		models.TimesheetStatusSubmitted, timesheet.UserID, "")
+	if err != nil {
+		s.logger.Debugf("SubmitTimesheet: failed to submit timesheet: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("SubmitTimesheet: timesheet submitted with ID: %d", id)
+	return newTimesheetResponse(timesheet), nil
+}
+
+// ApproveTimesheet approves a submitted timesheet on behalf of the reviewer of the request.
+func (s *TimesheetServiceImpl) ApproveTimesheet(ctx context.Context, id uint, request dto.ReviewTimesheetRequest) (*dto.TimesheetResponse, error) {
+	s.logger.Infof("ApproveTimesheet: approving timesheet with ID: %d", id)
+	reviewerID, err := s.checkReviewer(ctx, id)
+	if err != nil {
+		s.logger.Debugf("ApproveTimesheet: reviewer cannot approve timesheet: %v", err)
+		return nil, err
+	}
+
+	timesheet, err := s.timesheetRepo.Transition(id, []string{models.TimesheetStatusSubmitted},
+		models.TimesheetStatusApproved, reviewerID, request.Comment)
+	if err != nil {
+		s.logger.Debugf("ApproveTimesheet: failed to approve timesheet: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("ApproveTimesheet: timesheet approved with ID: %d by user ID: %d", id, reviewerID)
+	return newTimesheetResponse(timesheet), nil
+}
+
+// RejectTimesheet sends a submitted timesheet back to its user with a comment,
+// unlocking the tasks of its week.
+func (s *TimesheetServiceImpl) RejectTimesheet(ctx context.Context, id uint, request dto.ReviewTimesheetRequest) (*dto.TimesheetResponse, error) {
+	s.logger.Infof("RejectTimesheet: rejecting timesheet with ID: %d", id)
+	if request.Comment == "" {
+		return nil, models.ErrReviewCommentRequired
+	}
+	reviewerID, err := s.checkReviewer(ctx, id)
+	if err != nil {
+		s.logger.Debugf("RejectTimesheet: reviewer cannot reject timesheet: %v", err)
+		return nil, err
+	}
+
+	timesheet, err := s.timesheetRepo.Transition(id, []string{models.TimesheetStatusSubmitted},
+		models.TimesheetStatusRejected, reviewerID, request.Comment)
+	if err != nil {
+		s.logger.Debugf("RejectTimesheet: failed to reject timesheet: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("RejectTimesheet: timesheet rejected with ID: %d by user ID: %d", id, reviewerID)
+	return newTimesheetResponse(timesheet), nil
+}
+
+// ReopenTimesheet turns an approved timesheet back into a draft, which only requests
+// authenticated as an admin's may do.
+func (s *TimesheetServiceImpl) ReopenTimesheet(ctx context.Context, id uint, request dto.ReviewTimesheetRequest) (*dto.TimesheetResponse, error) {
+	s.logger.Infof("ReopenTimesheet: reopening timesheet with ID: %d", id)
+	actor := audit.FromContext(ctx)
+	if !actor.Admin {
+		return nil, models.ErrNotAdmin
+	}
+	if actor.UserID == nil {
+		return nil, models.ErrReviewerRequired
+	}
+
+	timesheet, err := s.timesheetRepo.Transition(id, []string{models.TimesheetStatusApproved},
+		models.TimesheetStatusDraft, *actor.UserID, request.Comment)
+	if err != nil {
+		s.logger.Debugf("ReopenTimesheet: failed to reopen timesheet: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("ReopenTimesheet: timesheet reopened with ID: %d by user ID: %d", id, *actor.UserID)
+	return newTimesheetResponse(timesheet), nil
+}
+
+// checkReviewer returns the reviewer of the request if they may approve or reject the
+// timesheet. Only requests authenticated as an admin's can review, on behalf of the user they
+// name, who must be a manager or an admin and cannot review their own timesheets.
+func (s *TimesheetServiceImpl) checkReviewer(ctx context.Context, timesheetID uint) (uint, error) {
+	actor := audit.FromContext(ctx)
+	if !actor.Admin {
+		return 0, models.ErrNotManager
+	}
+	if actor.UserID == nil {
+		return 0, models.ErrReviewerRequired
+	}
+
+	reviewer, err := s.userRepo.GetById(*actor.UserID)
+	if err != nil {
+		return 0, err
+	}
+	if reviewer.Role != models.UserRoleManager && reviewer.Role != models.UserRoleAdmin {
+		return 0, models.ErrNotManager
+	}
+
+	timesheet, err := s.timesheetRepo.GetById(timesheetID)
+	if err != nil {
+		return 0, err
+	}
+	if timesheet.UserID == reviewer.ID {
+		return 0, models.ErrOwnTimesheetReview
+	}
+
+	return reviewer.ID, nil
+}
+
+func newTimesheetResponse(timesheet *models.Timesheet) *dto.TimesheetResponse {
+	response := &dto.TimesheetResponse{
+		ID:        timesheet.ID,
+		UserID:    timesheet.UserID,
+		WeekStart: timesheet.WeekStart.Format(dateLayout),
+		WeekEnd:   timesheet.WeekStart.AddDate(0, 0, 6).Format(dateLayout),
+		Status:    timesheet.Status,
+	}
+
+	for _, transition := range timesheet.Transitions {
+		transitionResponse := dto.TimesheetTransitionResponse{
+			ToStatus:  transition.ToStatus,
+			ActorID:   transition.ActorID,
+			Comment:   transition.Comment,
+			CreatedAt: transition.CreatedAt.Format(time.RFC3339),
+		}
+		if transition.FromStatus != nil {
+			transitionResponse.FromStatus = *transition.FromStatus
+		}
+		response.Transitions = append(response.Transitions, transitionResponse)
+	}
+
+	return response
+}
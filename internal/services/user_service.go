@@ -1,14 +1,20 @@
 package services
 
 import (
+	"context"
+	"time"
+
 	"github.com/Dor1ma/Time-Tracker/internal/dto"
 )
 
 type UserService interface {
-	CreateUser(passportNumber string) (*dto.UserResponse, error)
-	GetUserById(userId uint) (*dto.UserResponse, error)
-	GetAllUsers() ([]dto.UserResponse, error)
-	GetUsersWithFiltersAndPagination(filters map[string]interface{}, page int, pageSize int) ([]dto.UserResponse, error)
-	UpdateUser(userId uint, userUpdateRequest dto.UpdateUserRequest) (*dto.UserResponse, error)
-	DeleteUser(id uint) error
+	CreateUser(ctx context.Context, passportNumber string) (*dto.UserResponse, error)
+	GetUserById(ctx context.Context, userId uint) (*dto.UserResponse, error)
+	GetAllUsers(ctx context.Context) ([]dto.UserResponse, error)
+	GetUsersWithFiltersAndPagination(ctx context.Context, filters map[string]interface{}, page int, pageSize int, includeDeleted bool) ([]dto.UserResponse, error)
+	UpdateUser(ctx context.Context, userId uint, userUpdateRequest dto.UpdateUserRequest) (*dto.UserResponse, error)
+	DeleteUser(ctx context.Context, id uint) error
+	RestoreUser(ctx context.Context, id uint) (*dto.UserResponse, error)
+	PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) (int, error)
+	RotatePassportKeys() (int, error)
 }
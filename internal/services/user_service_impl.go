@@ -1,23 +1,31 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/Dor1ma/Time-Tracker/internal/audit"
 	"github.com/Dor1ma/Time-Tracker/internal/dto"
 	"github.com/Dor1ma/Time-Tracker/internal/models"
 	"github.com/Dor1ma/Time-Tracker/internal/repositories"
 	"github.com/sirupsen/logrus"
 	"net/http"
 	"strconv"
+	"time"
+	"unicode"
 )
 
+// passportBatchSize is the number of passport numbers sealed in a transaction.
+const passportBatchSize = 100
+
 type UserServiceImpl struct {
 	userRepo       repositories.UserRepository
 	externalAPIURL string
 	logger         *logrus.Logger
 }
 
-func NewUserServiceImpl(userRepo repositories.UserRepository, externalAPIURL string, logger *logrus.Logger) *UserServiceImpl {
+func NewUserServiceImpl(userRepo repositories.UserRepository, externalAPIURL string,
+	logger *logrus.Logger) *UserServiceImpl {
 	return &UserServiceImpl{
 		userRepo:       userRepo,
 		externalAPIURL: externalAPIURL,
@@ -25,8 +33,8 @@ func NewUserServiceImpl(userRepo repositories.UserRepository, externalAPIURL str
 	}
 }
 
-func (s *UserServiceImpl) CreateUser(passportNumber string) (*dto.UserResponse, error) {
-	s.logger.Infof("): creating user with passport number: %s", passportNumber)
+func (s *UserServiceImpl) CreateUser(ctx context.Context, passportNumber string) (*dto.UserResponse, error) {
+	s.logger.Info("CreateUser: creating user")
 	passportSerie, err := strconv.Atoi(passportNumber[:4])
 	if err != nil {
 		s.logger.Debugf("CreateUser: invalid passport series: %v", err)
@@ -60,23 +68,16 @@ func (s *UserServiceImpl) CreateUser(passportNumber string) (*dto.UserResponse,
 		Address:        apiResponse.Address,
 	}
 
-	if err := s.userRepo.Create(user); err != nil {
+	if err := s.userRepo.Create(ctx, user); err != nil {
 		s.logger.Debugf("CreateUser: failed to create user in database: %v", err)
 		return nil, err
 	}
 
 	s.logger.Infof("CreateUser: user created with ID: %d", user.ID)
-	return &dto.UserResponse{
-		ID:             user.ID,
-		PassportNumber: user.PassportNumber,
-		Surname:        user.Surname,
-		Name:           user.Name,
-		Patronymic:     user.Patronymic,
-		Address:        user.Address,
-	}, nil
+	return newUserResponse(user, passportAccess(ctx)), nil
 }
 
-func (s *UserServiceImpl) GetUserById(id uint) (*dto.UserResponse, error) {
+func (s *UserServiceImpl) GetUserById(ctx context.Context, id uint) (*dto.UserResponse, error) {
 	s.logger.Infof("GetUserById: getting user with id: %d", id)
 	user, err := s.userRepo.GetById(id)
 	if err != nil {
@@ -85,17 +86,10 @@ func (s *UserServiceImpl) GetUserById(id uint) (*dto.UserResponse, error) {
 	}
 
 	s.logger.Infof("GetUserById: got user with ID: %d", user.ID)
-	return &dto.UserResponse{
-		ID:             user.ID,
-		PassportNumber: user.PassportNumber,
-		Surname:        user.Surname,
-		Name:           user.Name,
-		Patronymic:     user.Patronymic,
-		Address:        user.Address,
-	}, nil
+	return s.userResponse(ctx, user)
 }
 
-func (s *UserServiceImpl) GetAllUsers() ([]dto.UserResponse, error) {
+func (s *UserServiceImpl) GetAllUsers(ctx context.Context) ([]dto.UserResponse, error) {
 	s.logger.Info("GetAllUsers: fetching all users")
 	users, err := s.userRepo.GetAll()
 	if err != nil {
@@ -103,53 +97,59 @@ func (s *UserServiceImpl) GetAllUsers() ([]dto.UserResponse, error) {
 		return nil, err
 	}
 
-	var userResponses []dto.UserResponse
-	for _, user := range users {
-		userResponses = append(userResponses, dto.UserResponse{
-			ID:             user.ID,
-			PassportNumber: user.PassportNumber,
-			Surname:        user.Surname,
-			Name:           user.Name,
-			Patronymic:     user.Patronymic,
-			Address:        user.Address,
-		})
-	}
-
-	return userResponses, nil
+	return s.userResponses(ctx, users)
 }
 
-func (s *UserServiceImpl) UpdateUser(userId uint, userUpdateRequest dto.UpdateUserRequest) (*dto.UserResponse, error) {
+func (s *UserServiceImpl) UpdateUser(ctx context.Context, userId uint, userUpdateRequest dto.UpdateUserRequest) (*dto.UserResponse, error) {
 	s.logger.Infof("UpdateUser: updating user with ID: %d", userId)
 	user, err := s.userRepo.GetById(userId)
 	if err != nil {
 		s.logger.Errorf("UpdateUser: failed to update user: %v", err)
 		return nil, err
 	}
+	if user.DeletedAt != nil {
+		s.logger.Debugf("UpdateUser: user with ID %d is deleted", userId)
+		return nil, models.ErrUserDeleted
+	}
+	if user.ErasedAt != nil {
+		s.logger.Debugf("UpdateUser: personal data of user with ID %d has been erased", userId)
+		return nil, models.ErrUserErased
+	}
+	if userUpdateRequest.Role != nil && *userUpdateRequest.Role != user.Role && !audit.FromContext(ctx).Admin {
+		s.logger.Debugf("UpdateUser: role of user with ID %d changed by a request not authenticated as an admin's", userId)
+		return nil, models.ErrRoleChange
+	}
+
+	if userUpdateRequest.TimeZone != nil {
+		loc, err := loadTimeZone(*userUpdateRequest.TimeZone)
+		if err != nil {
+			s.logger.Debugf("UpdateUser: invalid time zone: %v", err)
+			return nil, err
+		}
+		user.TimeZone = loc.String()
+	}
 
 	user.Name = userUpdateRequest.Name
 	user.Surname = userUpdateRequest.Surname
 	user.Patronymic = userUpdateRequest.Patronymic
 	user.Address = userUpdateRequest.Address
+	user.WorkdayEnd = userUpdateRequest.WorkdayEnd
+	if userUpdateRequest.Role != nil {
+		user.Role = *userUpdateRequest.Role
+	}
 
-	if err := s.userRepo.Update(user); err != nil {
+	if err := s.userRepo.Update(ctx, user); err != nil {
 		s.logger.Errorf("UpdateUser: failed to update user: %v", err)
 		return nil, err
 	}
 
 	s.logger.Infof("UpdateUser: user updated with ID: %d", user.ID)
-	return &dto.UserResponse{
-		ID:             user.ID,
-		PassportNumber: user.PassportNumber,
-		Surname:        user.Surname,
-		Name:           user.Name,
-		Patronymic:     user.Patronymic,
-		Address:        user.Address,
-	}, nil
+	return s.userResponse(ctx, user)
 }
 
-func (s *UserServiceImpl) DeleteUser(id uint) error {
+func (s *UserServiceImpl) DeleteUser(ctx context.Context, id uint) error {
 	s.logger.Infof("DeleteUser: deleting user with ID: %d", id)
-	if err := s.userRepo.Delete(id); err != nil {
+	if err := s.userRepo.Delete(ctx, id); err != nil {
 		s.logger.Debugf("DeleteUser: failed to delete user: %v", err)
 		return err
 	}
@@ -157,27 +157,134 @@ func (s *UserServiceImpl) DeleteUser(id uint) error {
 	return nil
 }
 
-func (s *UserServiceImpl) GetUsersWithFiltersAndPagination(filters map[string]interface{}, page int, pageSize int) ([]dto.UserResponse, error) {
-	s.logger.Infof("GetUsersWithFiltersAndPagination: fetching users with filters and pagination: filters=%d page=%d, pageSize=%d",
-		len(filters), page, pageSize)
-	users, err := s.userRepo.GetAllWithFiltersAndPagination(filters, page, pageSize)
+func (s *UserServiceImpl) RestoreUser(ctx context.Context, id uint) (*dto.UserResponse, error) {
+	s.logger.Infof("RestoreUser: restoring user with ID: %d", id)
+	user, err := s.userRepo.Restore(ctx, id)
+	if err != nil {
+		s.logger.Debugf("RestoreUser: failed to restore user: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("RestoreUser: user restored with ID: %d", id)
+	return s.userResponse(ctx, user)
+}
+
+// PurgeDeletedUsers deletes for good the users deleted before deletedBefore, together with
+// their tasks. It returns the number of users purged.
+func (s *UserServiceImpl) PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) (int, error) {
+	s.logger.Infof("PurgeDeletedUsers: purging users deleted before %s", deletedBefore.Format(time.RFC3339))
+	purged, err := s.userRepo.Purge(ctx, deletedBefore)
+	if err != nil {
+		s.logger.Debugf("PurgeDeletedUsers: failed to purge users: %v", err)
+		return len(purged), err
+	}
+
+	s.logger.Infof("PurgeDeletedUsers: purged %d users", len(purged))
+	return len(purged), nil
+}
+
+func (s *UserServiceImpl) GetUsersWithFiltersAndPagination(ctx context.Context, filters map[string]interface{}, page int, pageSize int,
+	includeDeleted bool) ([]dto.UserResponse, error) {
+	s.logger.Infof("GetUsersWithFiltersAndPagination: fetching users with filters and pagination: filters=%d page=%d, pageSize=%d, include deleted: %t",
+		len(filters), page, pageSize, includeDeleted)
+	users, err := s.userRepo.GetAllWithFiltersAndPagination(filters, page, pageSize, includeDeleted)
 	if err != nil {
 		s.logger.Debugf("GetUsersWithFiltersAndPagination: failed to fetch users: %v", err)
 		return nil, err
 	}
 
+	return s.userResponses(ctx, users)
+}
+
+// RotatePassportKeys seals the passport numbers still stored in plain text, and rewraps and
+// rehashes the others by the current keys. It returns the number of users updated.
+func (s *UserServiceImpl) RotatePassportKeys() (int, error) {
+	total := 0
+	for {
+		count, err := s.userRepo.SealPassports(passportBatchSize)
+		total += count
+		if err != nil {
+			s.logger.Debugf("RotatePassportKeys: failed to seal passport numbers: %v", err)
+			return total, err
+		}
+		if count < passportBatchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		s.logger.Infof("RotatePassportKeys: sealed passport numbers of %d users", total)
+	}
+	return total, nil
+}
+
+// passportAccess tells whether the request may see passport numbers in full, which only the
+// requests authenticated as an admin's may. Everyone else, and changes made without a request,
+// get them masked.
+func passportAccess(ctx context.Context) bool {
+	return audit.FromContext(ctx).Admin
+}
+
+// userResponse opens the passport number of the user to show it, in full or masked.
+func (s *UserServiceImpl) userResponse(ctx context.Context, user *models.User) (*dto.UserResponse, error) {
+	if err := s.userRepo.OpenPassport(user); err != nil {
+		s.logger.Debugf("userResponse: failed to open passport number of user with ID %d: %v", user.ID, err)
+		return nil, err
+	}
+
+	return newUserResponse(user, passportAccess(ctx)), nil
+}
+
+func (s *UserServiceImpl) userResponses(ctx context.Context, users []models.User) ([]dto.UserResponse, error) {
 	var userResponses []dto.UserResponse
-	for _, user := range users {
-		userResponse := dto.UserResponse{
-			ID:             user.ID,
-			PassportNumber: user.PassportNumber,
-			Surname:        user.Surname,
-			Name:           user.Name,
-			Patronymic:     user.Patronymic,
-			Address:        user.Address,
+	for i := range users {
+		response, err := s.userResponse(ctx, &users[i])
+		if err != nil {
+			return nil, err
 		}
-		userResponses = append(userResponses, userResponse)
+		userResponses = append(userResponses, *response)
 	}
 
 	return userResponses, nil
 }
+
+// newUserResponse returns the user with their passport number masked, unless passportAccess.
+func newUserResponse(user *models.User, passportAccess bool) *dto.UserResponse {
+	passportNumber := user.PassportNumber
+	if !passportAccess {
+		passportNumber = maskPassportNumber(passportNumber)
+	}
+
+	response := &dto.UserResponse{
+		ID:             user.ID,
+		PassportNumber: passportNumber,
+		Surname:        user.Surname,
+		Name:           user.Name,
+		Patronymic:     user.Patronymic,
+		Address:        user.Address,
+		Role:           user.Role,
+		TimeZone:       user.TimeZone,
+		WorkdayEnd:     user.WorkdayEnd,
+	}
+	if user.DeletedAt != nil {
+		response.DeletedAt = user.DeletedAt.Format(time.RFC3339)
+	}
+
+	return response
+}
+
+// maskPassportNumber hides all letters and digits of a passport number but the first two and
+// the last two, keeping the separators: 1234 567890 becomes 12** ****90.
+func maskPassportNumber(passportNumber string) string {
+	runes := []rune(passportNumber)
+	for i, r := range runes {
+		if (i < 2 || i >= len(runes)-2) && len(runes) > 4 {
+			continue
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			runes[i] = '*'
+		}
+	}
+
+	return string(runes)
+}
@@ -0,0 +1,263 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	webhookBatchSize = 50
+	webhookWorkers   = 8
+	// webhookLease is how long a claimed delivery is kept from other senders; it must
+	// exceed the timeout of the HTTP client.
+	webhookLease = 2 * time.Minute
+	// webhookMaxRetryDelay caps the exponential backoff between attempts.
+	webhookMaxRetryDelay = 6 * time.Hour
+	// webhookResponseLimit is how much of a response body is read before it is discarded.
+	webhookResponseLimit = 64 << 10
+)
+
+const (
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// WebhookDispatcher delivers events to the webhooks subscribed to them. HandleEvent, which is
+// subscribed to the event bus, stores a delivery per webhook; Run sends them, retrying failed
+// attempts with exponential backoff until maxAttempts have been made.
+type WebhookDispatcher struct {
+	webhookRepo repositories.WebhookRepository
+	client      *http.Client
+	maxAttempts int
+	retryBase   time.Duration
+	wake        chan struct{}
+	logger      *logrus.Logger
+}
+
+func NewWebhookDispatcher(webhookRepo repositories.WebhookRepository, client *http.Client, maxAttempts int,
+	retryBase time.Duration, logger *logrus.Logger) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		webhookRepo: webhookRepo,
+		client:      client,
+		maxAttempts: maxAttempts,
+		retryBase:   retryBase,
+		wake:        make(chan struct{}, 1),
+		logger:      logger,
+	}
+}
+
+// HandleEvent stores a delivery of an event relayed from the outbox for every webhook
+// subscribed to it, and wakes Run up to send them. The outbox ID of the event is its webhook
+// event ID, so an event handled again stores no second delivery.
+func (d *WebhookDispatcher) HandleEvent(ctx context.Context, event *models.OutboxEvent) error {
+	count, err := d.Record(dto.WebhookEvent{
+		ID:         strconv.FormatUint(event.ID, 10),
+		Event:      event.Event,
+		OccurredAt: event.CreatedAt.UTC().Format(time.RFC3339Nano),
+		Data:       json.RawMessage(event.Payload),
+	})
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		select {
+		case d.wake <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Run sends the deliveries stored by HandleEvent right away, and the deliveries due for a
+// retry every interval, until ctx is done.
+func (d *WebhookDispatcher) Run(ctx context.Context, interval time.Duration) {
+	d.logger.Infof("Webhook dispatcher has started with interval %s", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.wake:
+			if _, err := d.DeliverDue(time.Now()); err != nil {
+				d.logger.Errorf("Webhook dispatcher failed to deliver events: %v", err)
+			}
+		case now := <-ticker.C:
+			if _, err := d.DeliverDue(now); err != nil {
+				d.logger.Errorf("Webhook dispatcher failed to deliver events: %v", err)
+			}
+		}
+	}
+}
+
+// Record stores a pending delivery of the event for every active webhook subscribed to it
+// and returns how many there are. Deliveries already stored for the event are kept as they are.
+func (d *WebhookDispatcher) Record(event dto.WebhookEvent) (int, error) {
+	webhooks, err := d.webhookRepo.GetActive()
+	if err != nil {
+		return 0, err
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	var deliveries []models.WebhookDelivery
+	for _, webhook := range webhooks {
+		if !slices.Contains(webhookEvents(&webhook), event.Event) {
+			continue
+		}
+		deliveries = append(deliveries, models.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventID:       event.ID,
+			Event:         event.Event,
+			Payload:       string(payload),
+			Status:        models.WebhookDeliveryPending,
+			NextAttemptAt: &now,
+		})
+	}
+
+	if err := d.webhookRepo.CreateDeliveries(deliveries); err != nil {
+		return 0, err
+	}
+
+	return len(deliveries), nil
+}
+
+// DeliverDue sends a batch of the pending deliveries due by now, a few at a time, and
+// returns how many of them succeeded.
+func (d *WebhookDispatcher) DeliverDue(now time.Time) (int, error) {
+	deliveries, err := d.webhookRepo.ClaimDueDeliveries(now, webhookLease, webhookBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var succeeded int
+	workers := make(chan struct{}, webhookWorkers)
+	for i := range deliveries {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(delivery *models.WebhookDelivery) {
+			defer wg.Done()
+			defer func() { <-workers }()
+
+			d.attempt(delivery, now)
+			if err := d.webhookRepo.SaveDeliveryAttempt(delivery); err != nil {
+				d.logger.Errorf("DeliverDue: failed to save attempt of delivery with ID %d: %v", delivery.ID, err)
+			}
+			if delivery.Status == models.WebhookDeliverySucceeded {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}(&deliveries[i])
+	}
+	wg.Wait()
+
+	if len(deliveries) > 0 {
+		d.logger.Infof("DeliverDue: %d of %d webhook deliveries succeeded", succeeded, len(deliveries))
+	}
+	return succeeded, nil
+}
+
+// attempt sends the delivery once and records the outcome in it: success, a retry
+// scheduled with exponential backoff, or failure once no attempts are left.
+func (d *WebhookDispatcher) attempt(delivery *models.WebhookDelivery, now time.Time) {
+	delivery.Attempts++
+	statusCode, err := d.send(delivery)
+	if statusCode != 0 {
+		delivery.LastStatusCode = &statusCode
+	}
+
+	if err == nil {
+		delivery.Status = models.WebhookDeliverySucceeded
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+		delivery.LastError = nil
+		return
+	}
+
+	lastError := err.Error()
+	delivery.LastError = &lastError
+	if delivery.Webhook == nil || !delivery.Webhook.Active || delivery.Attempts >= d.maxAttempts {
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		return
+	}
+
+	nextAttemptAt := now.Add(backoffDelay(d.retryBase, webhookMaxRetryDelay, delivery.Attempts))
+	delivery.NextAttemptAt = &nextAttemptAt
+}
+
+func (d *WebhookDispatcher) send(delivery *models.WebhookDelivery) (int, error) {
+	webhook := delivery.Webhook
+	if webhook == nil || !webhook.Active {
+		return 0, fmt.Errorf("webhook is not active")
+	}
+
+	body := []byte(delivery.Payload)
+	request, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "Time-Tracker-Webhooks/1.0")
+	request.Header.Set(WebhookEventHeader, delivery.Event)
+	request.Header.Set(WebhookDeliveryHeader, strconv.FormatUint(uint64(delivery.ID), 10))
+	request.Header.Set(WebhookSignatureHeader,
+		fmt.Sprintf("t=%s,v1=%s", timestamp, signWebhookPayload(webhook.Secret, timestamp, body)))
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, webhookResponseLimit))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("unexpected response status %s", response.Status)
+	}
+
+	return response.StatusCode, nil
+}
+
+// signWebhookPayload returns the hex HMAC-SHA256, keyed with the secret of the webhook, of
+// the timestamp and the body joined by a dot. Signing the timestamp lets receivers reject
+// replayed requests.
+func signWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func webhookEvents(webhook *models.Webhook) []string {
+	return strings.Split(webhook.Events, ",")
+}
@@ -0,0 +1,13 @@
+package services
+
+import "github.com/Dor1ma/Time-Tracker/internal/dto"
+
+type WebhookService interface {
+	CreateWebhook(request dto.CreateWebhookRequest) (*dto.WebhookResponse, error)
+	GetWebhooks() ([]dto.WebhookResponse, error)
+	GetWebhook(id uint) (*dto.WebhookResponse, error)
+	UpdateWebhook(id uint, request dto.UpdateWebhookRequest) (*dto.WebhookResponse, error)
+	DeleteWebhook(id uint) error
+	GetDeliveries(webhookID uint, request dto.GetWebhookDeliveriesRequest) ([]dto.WebhookDeliveryResponse, error)
+	ReplayDelivery(webhookID, deliveryID uint) (*dto.WebhookDeliveryResponse, error)
+}
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+	"github.com/Dor1ma/Time-Tracker/internal/models"
+	"github.com/Dor1ma/Time-Tracker/internal/repositories"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	webhookSecretBytes          = 32
+	defaultWebhookDeliveryLimit = 50
+)
+
+type WebhookServiceImpl struct {
+	webhookRepo repositories.WebhookRepository
+	logger      *logrus.Logger
+}
+
+func NewWebhookServiceImpl(webhookRepo repositories.WebhookRepository, logger *logrus.Logger) *WebhookServiceImpl {
+	return &WebhookServiceImpl{
+		webhookRepo: webhookRepo,
+		logger:      logger,
+	}
+}
+
+// CreateWebhook subscribes a URL to events and generates the secret its deliveries are
+// signed with. The secret is returned only here.
+func (s *WebhookServiceImpl) CreateWebhook(request dto.CreateWebhookRequest) (*dto.WebhookResponse, error) {
+	s.logger.Infof("CreateWebhook: creating webhook for %s, events: %v", request.URL, request.Events)
+	events, err := normalizeWebhookEvents(request.Events)
+	if err != nil {
+		s.logger.Debugf("CreateWebhook: invalid events: %v", err)
+		return nil, err
+	}
+
+	secret := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		s.logger.Debugf("CreateWebhook: failed to generate secret: %v", err)
+		return nil, err
+	}
+
+	webhook := &models.Webhook{
+		URL:    request.URL,
+		Secret: hex.EncodeToString(secret),
+		Events: events,
+		Active: request.Active == nil || *request.Active,
+	}
+
+	if err := s.webhookRepo.Create(webhook); err != nil {
+		s.logger.Debugf("CreateWebhook: failed to create webhook in database: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("CreateWebhook: webhook created with ID: %d", webhook.ID)
+	response := newWebhookResponse(webhook)
+	response.Secret = webhook.Secret
+	return response, nil
+}
+
+func (s *WebhookServiceImpl) GetWebhooks() ([]dto.WebhookResponse, error) {
+	s.logger.Info("GetWebhooks: fetching webhooks")
+	webhooks, err := s.webhookRepo.GetAll()
+	if err != nil {
+		s.logger.Debugf("GetWebhooks: failed to fetch webhooks: %v", err)
+		return nil, err
+	}
+
+	webhookResponses := make([]dto.WebhookResponse, len(webhooks))
+	for i := range webhooks {
+		webhookResponses[i] = *newWebhookResponse(&webhooks[i])
+	}
+
+	return webhookResponses, nil
+}
+
+func (s *WebhookServiceImpl) GetWebhook(id uint) (*dto.WebhookResponse, error) {
+	s.logger.Infof("GetWebhook: getting webhook with ID: %d", id)
+	webhook, err := s.webhookRepo.GetById(id)
+	if err != nil {
+		s.logger.Debugf("GetWebhook: failed to get webhook: %v", err)
+		return nil, err
+	}
+
+	return newWebhookResponse(webhook), nil
+}
+
+func (s *WebhookServiceImpl) UpdateWebhook(id uint, request dto.UpdateWebhookRequest) (*dto.WebhookResponse, error) {
+	s.logger.Infof("UpdateWebhook: updating webhook with ID: %d", id)
+	events, err := normalizeWebhookEvents(request.Events)
+	if err != nil {
+		s.logger.Debugf("UpdateWebhook: invalid events: %v", err)
+		return nil, err
+	}
+
+	webhook, err := s.webhookRepo.GetById(id)
+	if err != nil {
+		s.logger.Debugf("UpdateWebhook: failed to get webhook: %v", err)
+		return nil, err
+	}
+
+	webhook.URL = request.URL
+	webhook.Events = events
+	if request.Active != nil {
+		webhook.Active = *request.Active
+	}
+
+	if err := s.webhookRepo.Update(webhook); err != nil {
+		s.logger.Debugf("UpdateWebhook: failed to update webhook in database: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("UpdateWebhook: webhook updated with ID: %d", id)
+	return newWebhookResponse(webhook), nil
+}
+
+func (s *WebhookServiceImpl) DeleteWebhook(id uint) error {
+	s.logger.Infof("DeleteWebhook: deleting webhook with ID: %d", id)
+	if err := s.webhookRepo.Delete(id); err != nil {
+		s.logger.Debugf("DeleteWebhook: failed to delete webhook: %v", err)
+		return err
+	}
+
+	s.logger.Infof("DeleteWebhook: webhook deleted with ID: %d", id)
+	return nil
+}
+
+// GetDeliveries returns the delivery log of the webhook, latest first.
+func (s *WebhookServiceImpl) GetDeliveries(webhookID uint, request dto.GetWebhookDeliveriesRequest) ([]dto.WebhookDeliveryResponse, error) {
+	s.logger.Infof("GetDeliveries: fetching deliveries of webhook ID: %d", webhookID)
+	if _, err := s.webhookRepo.GetById(webhookID); err != nil {
+		s.logger.Debugf("GetDeliveries: failed to get webhook: %v", err)
+		return nil, err
+	}
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultWebhookDeliveryLimit
+	}
+
+	deliveries, err := s.webhookRepo.GetDeliveries(webhookID, request.Status, limit)
+	if err != nil {
+		s.logger.Debugf("GetDeliveries: failed to fetch deliveries: %v", err)
+		return nil, err
+	}
+
+	deliveryResponses := make([]dto.WebhookDeliveryResponse, len(deliveries))
+	for i := range deliveries {
+		deliveryResponses[i] = *newWebhookDeliveryResponse(&deliveries[i])
+	}
+
+	return deliveryResponses, nil
+}
+
+// ReplayDelivery sends the payload of a delivery again as a new delivery, so that the log of
+// the original is kept. The payload keeps its event ID, which lets receivers spot duplicates.
+func (s *WebhookServiceImpl) ReplayDelivery(webhookID, deliveryID uint) (*dto.WebhookDeliveryResponse, error) {
+	s.logger.Infof("ReplayDelivery: replaying delivery ID %d of webhook ID: %d", deliveryID, webhookID)
+	delivery, err := s.webhookRepo.GetDelivery(webhookID, deliveryID)
+	if err != nil {
+		s.logger.Debugf("ReplayDelivery: failed to get delivery: %v", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	replay := models.WebhookDelivery{
+		WebhookID:     delivery.WebhookID,
+		EventID:       delivery.EventID,
+		Event:         delivery.Event,
+		Payload:       delivery.Payload,
+		Status:        models.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+		ReplayOf:      &delivery.ID,
+	}
+
+	deliveries := []models.WebhookDelivery{replay}
+	if err := s.webhookRepo.CreateDeliveries(deliveries); err != nil {
+		s.logger.Debugf("ReplayDelivery: failed to create delivery in database: %v", err)
+		return nil, err
+	}
+
+	s.logger.Infof("ReplayDelivery: delivery ID %d queued again as ID: %d", deliveryID, deliveries[0].ID)
+	return newWebhookDeliveryResponse(&deliveries[0]), nil
+}
+
+// normalizeWebhookEvents checks the events against models.WebhookEvents and joins them,
+// without duplicates, into the comma separated list stored with a webhook.
+func normalizeWebhookEvents(events []string) (string, error) {
+	var normalized []string
+	for _, event := range events {
+		event = strings.TrimSpace(event)
+		if !slices.Contains(models.WebhookEvents, event) {
+			return "", fmt.Errorf("%w: %q", models.ErrUnknownWebhookEvent, event)
+		}
+		if !slices.Contains(normalized, event) {
+			normalized = append(normalized, event)
+		}
+	}
+
+	return strings.Join(normalized, ","), nil
+}
+
+func newWebhookResponse(webhook *models.Webhook) *dto.WebhookResponse {
+	return &dto.WebhookResponse{
+		ID:     webhook.ID,
+		URL:    webhook.URL,
+		Events: webhookEvents(webhook),
+		Active: webhook.Active,
+	}
+}
+
+func newWebhookDeliveryResponse(delivery *models.WebhookDelivery) *dto.WebhookDeliveryResponse {
+	response := &dto.WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		EventID:        delivery.EventID,
+		Event:          delivery.Event,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		ReplayOf:       delivery.ReplayOf,
+		CreatedAt:      delivery.CreatedAt.Format(time.RFC3339),
+		Payload:        json.RawMessage(delivery.Payload),
+	}
+	if delivery.NextAttemptAt != nil {
+		response.NextAttemptAt = delivery.NextAttemptAt.Format(time.RFC3339)
+	}
+	if delivery.LastError != nil {
+		response.LastError = *delivery.LastError
+	}
+	if delivery.DeliveredAt != nil {
+		response.DeliveredAt = delivery.DeliveredAt.Format(time.RFC3339)
+	}
+
+	return response
+}
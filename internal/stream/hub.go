@@ -0,0 +1,102 @@
+// Package stream fans events out to the clients of one API instance that are subscribed
+// to the users the events are about.
+package stream
+
+import (
+	"sync"
+
+	"github.com/Dor1ma/Time-Tracker/internal/dto"
+)
+
+// subscriptionBuffer is how many events a subscriber may fall behind before it is dropped.
+const subscriptionBuffer = 64
+
+// Hub delivers published events to the subscriptions of their user.
+type Hub struct {
+	mu            sync.Mutex
+	subscriptions map[*Subscription]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subscriptions: make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscription receives the events of a set of users until it is closed.
+type Subscription struct {
+	hub     *Hub
+	userIDs map[uint]struct{}
+	events  chan dto.TaskStreamEvent
+	closed  bool
+}
+
+// Subscribe returns a subscription to the events of the users.
+func (h *Hub) Subscribe(userIDs []uint) *Subscription {
+	subscription := &Subscription{
+		hub:     h,
+		userIDs: make(map[uint]struct{}, len(userIDs)),
+		events:  make(chan dto.TaskStreamEvent, subscriptionBuffer),
+	}
+	for _, userID := range userIDs {
+		subscription.userIDs[userID] = struct{}{}
+	}
+
+	h.mu.Lock()
+	h.subscriptions[subscription] = struct{}{}
+	h.mu.Unlock()
+
+	return subscription
+}
+
+// Publish hands the event to the subscriptions of its user without blocking. A subscription
+// whose buffer is full is closed instead, so that its client reconnects and catches up from
+// the last event it received.
+func (h *Hub) Publish(event dto.TaskStreamEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for subscription := range h.subscriptions {
+		if _, ok := subscription.userIDs[event.UserID]; !ok {
+			continue
+		}
+
+		select {
+		case subscription.events <- event:
+		default:
+			h.close(subscription)
+		}
+	}
+}
+
+// Subscribers returns the number of open subscriptions.
+func (h *Hub) Subscribers() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.subscriptions)
+}
+
+func (h *Hub) close(subscription *Subscription) {
+	if subscription.closed {
+		return
+	}
+
+	subscription.closed = true
+	delete(h.subscriptions, subscription)
+	close(subscription.events)
+}
+
+// Events returns the channel of the events of the subscription. It is closed once the
+// subscription is closed, by Close or because it fell behind.
+func (s *Subscription) Events() <-chan dto.TaskStreamEvent {
+	return s.events
+}
+
+// Close ends the subscription. It may be called more than once.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	s.hub.close(s)
+}
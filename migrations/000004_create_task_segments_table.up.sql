@@ -0,0 +1,19 @@
+ALTER TABLE tasks ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'stopped';
+
+UPDATE tasks SET status = 'running' WHERE end_time IS NULL OR end_time < start_time;
+
+CREATE TABLE task_segments (
+    id SERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_time TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_task_segments_task_id ON task_segments(task_id);
+
+INSERT INTO task_segments (task_id, start_time, end_time)
+SELECT id, start_time, CASE WHEN status = 'running' THEN NULL ELSE end_time END
+FROM tasks
+WHERE start_time IS NOT NULL;
@@ -0,0 +1,27 @@
+CREATE TEMPORARY TABLE duplicate_running_tasks AS
+SELECT id
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY start_time DESC) AS rn
+    FROM tasks
+    WHERE status = 'running'
+) ranked
+WHERE rn > 1;
+
+UPDATE task_segments SET end_time = CURRENT_TIMESTAMP
+WHERE end_time IS NULL AND task_id IN (SELECT id FROM duplicate_running_tasks);
+
+UPDATE tasks t
+SET status = 'stopped',
+    end_time = CURRENT_TIMESTAMP,
+    hours = s.seconds / 3600,
+    minutes = s.seconds / 60
+FROM (
+    SELECT task_id, FLOOR(SUM(EXTRACT(EPOCH FROM end_time - start_time)))::INTEGER AS seconds
+    FROM task_segments
+    GROUP BY task_id
+) s
+WHERE s.task_id = t.id AND t.id IN (SELECT id FROM duplicate_running_tasks);
+
+DROP TABLE duplicate_running_tasks;
+
+CREATE UNIQUE INDEX idx_tasks_one_running_per_user ON tasks(user_id) WHERE status = 'running';
@@ -0,0 +1,5 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS invoice_id;
+DROP TABLE IF EXISTS invoice_lines;
+DROP TABLE IF EXISTS invoices;
+DROP TABLE IF EXISTS rates;
+ALTER TABLE tasks DROP COLUMN IF EXISTS billable;
@@ -0,0 +1,44 @@
+ALTER TABLE tasks ADD COLUMN billable BOOLEAN NOT NULL DEFAULT TRUE;
+
+CREATE TABLE rates (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id),
+    project_id INTEGER REFERENCES projects(id),
+    client_id INTEGER REFERENCES clients(id),
+    hourly_rate_cents BIGINT NOT NULL CHECK (hourly_rate_cents >= 0),
+    currency CHAR(3) NOT NULL,
+    effective_from TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (project_id IS NULL OR client_id IS NULL)
+);
+
+CREATE INDEX idx_rates_scope ON rates(user_id, project_id, client_id, effective_from);
+
+CREATE TABLE invoices (
+    id SERIAL PRIMARY KEY,
+    sequence INTEGER NOT NULL UNIQUE,
+    number VARCHAR(20) NOT NULL UNIQUE,
+    client_id INTEGER NOT NULL REFERENCES clients(id),
+    period_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    period_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    currency CHAR(3) NOT NULL,
+    total_cents BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE invoice_lines (
+    id SERIAL PRIMARY KEY,
+    invoice_id INTEGER NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    task_id INTEGER NOT NULL UNIQUE REFERENCES tasks(id),
+    description VARCHAR(100) NOT NULL,
+    minutes INTEGER NOT NULL,
+    hourly_rate_cents BIGINT NOT NULL,
+    amount_cents BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE tasks ADD COLUMN invoice_id INTEGER REFERENCES invoices(id);
+
+CREATE INDEX idx_tasks_unbilled ON tasks(project_id, start_time) WHERE invoice_id IS NULL AND billable;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_task_segments_start_time;
+DROP INDEX IF EXISTS idx_tasks_user_id_start_time;
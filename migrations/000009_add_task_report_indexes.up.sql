@@ -0,0 +1,3 @@
+CREATE INDEX idx_tasks_user_id_start_time ON tasks(user_id, start_time);
+
+CREATE INDEX idx_task_segments_start_time ON task_segments(start_time);
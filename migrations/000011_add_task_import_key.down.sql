@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tasks_import_key;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS import_key;
@@ -0,0 +1,3 @@
+ALTER TABLE tasks ADD COLUMN import_key VARCHAR(64);
+
+CREATE UNIQUE INDEX idx_tasks_import_key ON tasks(import_key) WHERE import_key IS NOT NULL;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_tasks_status_running;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS auto_stop_reason;
+ALTER TABLE tasks DROP COLUMN IF EXISTS auto_stopped;
+
+ALTER TABLE users DROP COLUMN IF EXISTS workday_end;
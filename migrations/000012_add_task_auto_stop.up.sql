@@ -0,0 +1,6 @@
+ALTER TABLE users ADD COLUMN workday_end VARCHAR(5);
+
+ALTER TABLE tasks ADD COLUMN auto_stopped BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE tasks ADD COLUMN auto_stop_reason VARCHAR(255);
+
+CREATE INDEX idx_tasks_status_running ON tasks(status) WHERE status = 'running';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS timesheet_transitions;
+DROP TABLE IF EXISTS timesheets;
+
+ALTER TABLE users DROP COLUMN IF EXISTS role;
@@ -0,0 +1,3 @@
+ALTER TABLE invoice_lines DROP COLUMN IF EXISTS rounded_minutes;
+
+DROP TABLE IF EXISTS rounding_policies;
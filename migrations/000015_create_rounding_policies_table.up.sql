@@ -0,0 +1,18 @@
+CREATE TABLE rounding_policies (
+    id SERIAL PRIMARY KEY,
+    project_id INTEGER REFERENCES projects(id) ON DELETE CASCADE,
+    client_id INTEGER REFERENCES clients(id) ON DELETE CASCADE,
+    increment_minutes INTEGER NOT NULL CHECK (increment_minutes > 0),
+    mode VARCHAR(10) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (project_id IS NULL OR client_id IS NULL)
+);
+
+CREATE UNIQUE INDEX idx_rounding_policies_global ON rounding_policies((TRUE)) WHERE project_id IS NULL AND client_id IS NULL;
+CREATE UNIQUE INDEX idx_rounding_policies_project ON rounding_policies(project_id) WHERE project_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_rounding_policies_client ON rounding_policies(client_id) WHERE client_id IS NOT NULL;
+
+ALTER TABLE invoice_lines ADD COLUMN rounded_minutes INTEGER;
+UPDATE invoice_lines SET rounded_minutes = minutes;
+ALTER TABLE invoice_lines ALTER COLUMN rounded_minutes SET NOT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_tasks_task_name;
+DROP TABLE IF EXISTS budgets;
@@ -0,0 +1,13 @@
+CREATE TABLE budgets (
+    id SERIAL PRIMARY KEY,
+    project_id INTEGER REFERENCES projects(id) ON DELETE CASCADE,
+    task_name VARCHAR(100),
+    estimate_minutes INTEGER NOT NULL CHECK (estimate_minutes > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((project_id IS NULL) <> (task_name IS NULL))
+);
+
+CREATE UNIQUE INDEX idx_budgets_project ON budgets(project_id) WHERE project_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_budgets_task_name ON budgets(task_name) WHERE task_name IS NOT NULL;
+CREATE INDEX idx_tasks_task_name ON tasks(task_name);
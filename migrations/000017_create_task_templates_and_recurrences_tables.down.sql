@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_tasks_recurrence_occurrence;
+ALTER TABLE tasks DROP COLUMN IF EXISTS recurrence_id;
+DROP TABLE IF EXISTS recurrence_skips;
+DROP TABLE IF EXISTS recurrences;
+DROP TABLE IF EXISTS task_template_tags;
+DROP TABLE IF EXISTS task_templates;
@@ -0,0 +1,43 @@
+CREATE TABLE task_templates (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    task_name VARCHAR(100) NOT NULL,
+    project_id INTEGER REFERENCES projects(id) ON DELETE SET NULL,
+    billable BOOLEAN NOT NULL,
+    duration_minutes INTEGER NOT NULL CHECK (duration_minutes > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_task_templates_user_id ON task_templates(user_id);
+
+CREATE TABLE task_template_tags (
+    task_template_id INTEGER NOT NULL REFERENCES task_templates(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (task_template_id, tag_id)
+);
+
+CREATE TABLE recurrences (
+    id SERIAL PRIMARY KEY,
+    template_id INTEGER NOT NULL REFERENCES task_templates(id) ON DELETE CASCADE,
+    rule VARCHAR(255) NOT NULL,
+    start_date DATE NOT NULL,
+    start_time VARCHAR(5) NOT NULL,
+    mode VARCHAR(10) NOT NULL,
+    generated_until TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_recurrences_template_id ON recurrences(template_id);
+
+CREATE TABLE recurrence_skips (
+    id SERIAL PRIMARY KEY,
+    recurrence_id INTEGER NOT NULL REFERENCES recurrences(id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (recurrence_id, date)
+);
+
+ALTER TABLE tasks ADD COLUMN recurrence_id INTEGER REFERENCES recurrences(id) ON DELETE SET NULL;
+CREATE UNIQUE INDEX idx_tasks_recurrence_occurrence ON tasks(recurrence_id, start_time) WHERE recurrence_id IS NOT NULL;
@@ -0,0 +1,13 @@
+CREATE TABLE focus_sessions (
+    task_id INTEGER PRIMARY KEY REFERENCES tasks(id) ON DELETE CASCADE,
+    work_minutes INTEGER NOT NULL CHECK (work_minutes > 0),
+    break_minutes INTEGER NOT NULL CHECK (break_minutes > 0),
+    cycles INTEGER NOT NULL CHECK (cycles > 0),
+    completed_cycles INTEGER NOT NULL DEFAULT 0,
+    phase VARCHAR(10) NOT NULL,
+    phase_ends_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_focus_sessions_phase_ends_at ON focus_sessions(phase_ends_at) WHERE phase <> 'done';
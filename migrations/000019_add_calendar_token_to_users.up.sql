@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN calendar_token_hash VARCHAR(64);
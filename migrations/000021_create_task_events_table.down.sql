@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS task_events_notify ON task_events;
+DROP FUNCTION IF EXISTS notify_task_event();
+DROP TABLE IF EXISTS task_events;
@@ -0,0 +1,22 @@
+CREATE TABLE task_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event VARCHAR(50) NOT NULL,
+    payload TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_task_events_user_id ON task_events (user_id, id);
+CREATE INDEX idx_task_events_created_at ON task_events (created_at);
+
+-- Every API instance listens on task_events and relays the new events to its stream clients.
+CREATE FUNCTION notify_task_event() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('task_events', NEW.id::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER task_events_notify
+    AFTER INSERT ON task_events
+    FOR EACH ROW EXECUTE FUNCTION notify_task_event();
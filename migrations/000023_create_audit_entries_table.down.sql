@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS audit_entries_no_truncate ON audit_entries;
+DROP TRIGGER IF EXISTS audit_entries_append_only ON audit_entries;
+DROP TABLE IF EXISTS audit_entries;
+DROP FUNCTION IF EXISTS reject_audit_entry_change();
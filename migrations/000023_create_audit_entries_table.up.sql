@@ -0,0 +1,29 @@
+CREATE TABLE audit_entries (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id INTEGER,
+    action VARCHAR(20) NOT NULL,
+    entity_type VARCHAR(20) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    request_id VARCHAR(64),
+    changes TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_entries_entity ON audit_entries (entity_type, entity_id, id);
+CREATE INDEX idx_audit_entries_actor_id ON audit_entries (actor_id, id);
+CREATE INDEX idx_audit_entries_created_at ON audit_entries (created_at);
+
+-- The audit log is append-only: entries can be added but never changed or deleted.
+CREATE FUNCTION reject_audit_entry_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit entries cannot be changed or deleted';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_entries_append_only
+    BEFORE UPDATE OR DELETE ON audit_entries
+    FOR EACH ROW EXECUTE FUNCTION reject_audit_entry_change();
+
+CREATE TRIGGER audit_entries_no_truncate
+    BEFORE TRUNCATE ON audit_entries
+    FOR EACH STATEMENT EXECUTE FUNCTION reject_audit_entry_change();
@@ -0,0 +1,9 @@
+CREATE OR REPLACE FUNCTION reject_audit_entry_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit entries cannot be changed or deleted';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TABLE IF EXISTS erasures;
+
+ALTER TABLE users DROP COLUMN IF EXISTS erased_at;
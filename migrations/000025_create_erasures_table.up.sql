@@ -0,0 +1,34 @@
+ALTER TABLE users ADD COLUMN erased_at TIMESTAMP WITH TIME ZONE;
+
+-- Erasures prove that the personal data of a user has been erased. They are kept after the
+-- user is purged, so user_id does not reference users.
+CREATE TABLE erasures (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL UNIQUE,
+    actor_id INTEGER,
+    request_id VARCHAR(64),
+    fields TEXT NOT NULL,
+    audit_entries_redacted INTEGER NOT NULL,
+    webhook_deliveries_deleted INTEGER NOT NULL,
+    erased_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Audit entries stay append-only, except that an erasure may redact the recorded changes.
+-- It enables this for its own transaction with SET LOCAL audit.redaction = 'on'.
+CREATE OR REPLACE FUNCTION reject_audit_entry_change() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND current_setting('audit.redaction', true) = 'on'
+        AND NEW.id = OLD.id
+        AND NEW.actor_id IS NOT DISTINCT FROM OLD.actor_id
+        AND NEW.action = OLD.action
+        AND NEW.entity_type = OLD.entity_type
+        AND NEW.entity_id = OLD.entity_id
+        AND NEW.request_id IS NOT DISTINCT FROM OLD.request_id
+        AND NEW.created_at IS NOT DISTINCT FROM OLD.created_at THEN
+        RETURN NEW;
+    END IF;
+
+    RAISE EXCEPTION 'audit entries cannot be changed or deleted';
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,9 @@
+-- Sealed passport numbers cannot be decrypted here: they are lost, and passport_number is left
+-- nullable for the users whose passport numbers were sealed.
+DROP INDEX IF EXISTS idx_users_passport_hash;
+
+ALTER TABLE users DROP COLUMN IF EXISTS passport_key_id;
+ALTER TABLE users DROP COLUMN IF EXISTS passport_data_key;
+ALTER TABLE users DROP COLUMN IF EXISTS passport_ciphertext;
+ALTER TABLE users DROP COLUMN IF EXISTS passport_hash_key_id;
+ALTER TABLE users DROP COLUMN IF EXISTS passport_hash;
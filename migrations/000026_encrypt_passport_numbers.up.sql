@@ -0,0 +1,15 @@
+-- Passport numbers are stored sealed with envelope encryption: passport_ciphertext is encrypted
+-- under passport_data_key, which is wrapped by the master key passport_key_id from config.
+-- passport_hash is a keyed hash of the passport number under the hash key passport_hash_key_id,
+-- unique and used for exact lookups.
+ALTER TABLE users ADD COLUMN passport_hash VARCHAR(64);
+ALTER TABLE users ADD COLUMN passport_hash_key_id VARCHAR(64);
+ALTER TABLE users ADD COLUMN passport_ciphertext BYTEA;
+ALTER TABLE users ADD COLUMN passport_data_key BYTEA;
+ALTER TABLE users ADD COLUMN passport_key_id VARCHAR(64);
+
+CREATE UNIQUE INDEX idx_users_passport_hash ON users (passport_hash);
+
+-- The encryption keys are not known to the database, so existing passport numbers are sealed
+-- by the application when it starts, which then clears passport_number.
+ALTER TABLE users ALTER COLUMN passport_number DROP NOT NULL;